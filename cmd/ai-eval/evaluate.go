@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/ai"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/google/uuid"
+)
+
+type evaluator struct {
+	generator    ai.AI
+	systemPrompt string
+	trino        *sql.DB
+	policy       trino.Policy
+	timeout      time.Duration
+}
+
+// evaluate generates the SQL for evalCase, runs it against the fixture
+// databases and checks the result against its expectations.
+func (e *evaluator) evaluate(ctx context.Context, evalCase Case) (result CaseResult) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+
+	defer cancel()
+
+	startedAt := time.Now()
+	result = CaseResult{Name: evalCase.Name}
+
+	defer func() {
+		result.DurationMs = time.Since(startedAt).Milliseconds()
+	}()
+
+	output, err := e.generator.GenerateDynamicQuery(ctx, postgres.DynamicQuery{
+		ID:     uuid.New(),
+		Name:   evalCase.Name,
+		Prompt: evalCase.Prompt,
+		Status: postgres.DynamicQueryStatusInProgress,
+	}, e.systemPrompt, func(progress ai.Progress) {
+		if progress.ToolCall != nil {
+			result.ToolCalls++
+		}
+	})
+
+	result.SqlQuery = output.SqlQuery
+	result.Tokens = output.Usage.TotalTokens
+
+	if err != nil {
+		return result.fail("generation failed: %s", err.Error())
+	}
+
+	if err := trino.ValidateQuery(output.SqlQuery, e.policy); err != nil {
+		return result.fail("the SQL is not allowed: %s", err.Error())
+	}
+
+	if err := trino.ValidateParameters(output.SqlQuery, output.Parameters); err != nil {
+		return result.fail("the parameters are invalid: %s", err.Error())
+	}
+
+	values := evalCase.Values
+
+	if values == nil {
+		values = map[string]string{}
+	}
+
+	rows, _, err := trino.Execute(ctx, e.trino, e.policy, output.SqlQuery, output.Parameters, values, trino.ResultOptions{})
+
+	if err != nil {
+		return result.fail("the SQL failed to run: %s", err.Error())
+	}
+
+	result.Rows = len(rows.Data)
+	result.Checksum = checksum(rows, evalCase.Expect.Ordered)
+
+	e.check(&result, evalCase.Expect, output.Parameters, rows)
+
+	result.Passed = len(result.Failures) == 0
+
+	return result
+}
+
+func (e *evaluator) check(result *CaseResult, expect Expectation, parameters system.DynamicQueryParameters, rows system.DynamicQueryResult) {
+	columns := []string{}
+
+	for _, column := range rows.Columns {
+		columns = append(columns, column.Name)
+	}
+
+	if expect.Columns != nil && !slices.Equal(columns, expect.Columns) {
+		result.Failures = append(result.Failures, fmt.Sprintf("expected the columns %q, got %q", expect.Columns, columns))
+	}
+
+	for _, column := range expect.IncludeColumns {
+		if !slices.Contains(columns, column) {
+			result.Failures = append(result.Failures, fmt.Sprintf("expected a %q column, got %q", column, columns))
+		}
+	}
+
+	for _, name := range expect.Parameters {
+		if !slices.ContainsFunc(parameters, func(parameter system.DynamicQueryParameter) bool { return parameter.Name == name }) {
+			result.Failures = append(result.Failures, fmt.Sprintf("expected a %q parameter", name))
+		}
+	}
+
+	if expect.RowCount != nil && result.Rows != *expect.RowCount {
+		result.Failures = append(result.Failures, fmt.Sprintf("expected %d rows, got %d", *expect.RowCount, result.Rows))
+	}
+
+	if expect.MinRows != nil && result.Rows < *expect.MinRows {
+		result.Failures = append(result.Failures, fmt.Sprintf("expected at least %d rows, got %d", *expect.MinRows, result.Rows))
+	}
+
+	if expect.MaxRows != nil && result.Rows > *expect.MaxRows {
+		result.Failures = append(result.Failures, fmt.Sprintf("expected at most %d rows, got %d", *expect.MaxRows, result.Rows))
+	}
+
+	if expect.Checksum != "" && result.Checksum != expect.Checksum {
+		result.Failures = append(result.Failures, fmt.Sprintf("expected the checksum %s, got %s", expect.Checksum, result.Checksum))
+	}
+
+	if expect.MaxToolCalls > 0 && result.ToolCalls > expect.MaxToolCalls {
+		result.Failures = append(result.Failures, fmt.Sprintf("expected at most %d tool calls, got %d", expect.MaxToolCalls, result.ToolCalls))
+	}
+}
+
+// checksum hashes every row of result, formatted the same way as the CSV
+// export. Rows are sorted first unless ordered is set, so that a query that
+// returns the same rows in another order still matches.
+func checksum(result system.DynamicQueryResult, ordered bool) string {
+	lines := []string{}
+
+	for _, row := range result.Data {
+		values := []string{}
+
+		for _, column := range result.Columns {
+			values = append(values, trino.FormatValue(row[column.Name]))
+		}
+
+		lines = append(lines, strings.Join(values, "\x1f"))
+	}
+
+	if !ordered {
+		slices.Sort(lines)
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+
+	return hex.EncodeToString(sum[:])[:16]
+}
@@ -0,0 +1,231 @@
+// Command ai-eval runs a YAML suite of prompts through dynamic query
+// generation, runs the SQL generated for each of them against seeded fixture
+// databases through Trino and prints a pass/fail scorecard. Writing the
+// scorecard with -out and passing it to a later run with -baseline shows what
+// a change to the system prompt or the model fixed and what it broke.
+//
+// Generation is configured with the same AI_* environment variables as the
+// API and uses the built-in prompt template unless -prompt names another, so
+// an edited template can be scored before it is saved. The glossary is read
+// from the Postgres that -postgres points at, and left out when it is empty.
+//
+// The fixture databases and the Trino that reads them are the eval-mysql and
+// eval-trino services of the docker compose eval profile, which -trino and
+// the seed DSNs default to. The MCP tools generation calls must be served by
+// an API connected to the same Trino, started with TRINO_DSN pointing at it.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/common"
+	"github.com/connor-davis/zingfibre-core/internal/ai"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/trinodb/trino-go-client/trino"
+)
+
+func main() {
+	suitePath := flag.String("suite", "cmd/ai-eval/suites/zing.yaml", "the YAML suite to run")
+	trinoDsn := flag.String("trino", common.EnvString("EVAL_TRINO_DSN", "http://eval@localhost:8082"), "the Trino the generated SQL is run against")
+	postgresDsn := flag.String("postgres", common.EnvString("POSTGRES_DSN", ""), "the Postgres the glossary is read from")
+	seed := flag.Bool("seed", true, "seed the fixture databases before running")
+	caseName := flag.String("case", "", "only run the case with this name")
+	promptPath := flag.String("prompt", "", "generate with the prompt template in this file instead of the built-in one")
+	label := flag.String("label", "", "a label for this run, such as the prompt revision")
+	out := flag.String("out", "", "write the scorecard as JSON to this file")
+	baselinePath := flag.String("baseline", "", "compare against the scorecard in this file")
+	timeout := flag.Duration("timeout", 5*time.Minute, "how long each case may take")
+
+	flag.Parse()
+
+	os.Exit(run(*suitePath, *trinoDsn, *postgresDsn, *seed, *caseName, *promptPath, *label, *out, *baselinePath, *timeout))
+}
+
+// run returns the exit status: 0 when every case passed, 1 when a case
+// failed and 2 when the suite could not be run.
+func run(suitePath string, trinoDsn string, postgresDsn string, seed bool, caseName string, promptPath string, label string, out string, baselinePath string, timeout time.Duration) int {
+	ctx := context.Background()
+
+	suite, err := LoadSuite(suitePath)
+
+	if err != nil {
+		log.Errorf("🔥 %s", err.Error())
+
+		return 2
+	}
+
+	var baseline *Scorecard
+
+	if baselinePath != "" {
+		loaded, err := LoadScorecard(baselinePath)
+
+		if err != nil {
+			log.Errorf("🔥 %s", err.Error())
+
+			return 2
+		}
+
+		baseline = &loaded
+	}
+
+	aiConfig, err := ai.ConfigFromEnv()
+
+	if err != nil {
+		log.Errorf("🔥 Invalid AI configuration: %s", err.Error())
+
+		return 2
+	}
+
+	generator, err := ai.New(aiConfig)
+
+	if err != nil {
+		log.Errorf("🔥 Failed to configure the AI provider: %s", err.Error())
+
+		return 2
+	}
+
+	policy, err := trino.PolicyFromEnv()
+
+	if err != nil {
+		log.Errorf("🔥 Invalid Trino policy: %s", err.Error())
+
+		return 2
+	}
+
+	promptTemplate := ai.DefaultPromptTemplate
+
+	if promptPath != "" {
+		data, err := os.ReadFile(promptPath)
+
+		if err != nil {
+			log.Errorf("🔥 %s", err.Error())
+
+			return 2
+		}
+
+		promptTemplate = string(data)
+	}
+
+	glossary, err := loadGlossary(ctx, postgresDsn)
+
+	if err != nil {
+		log.Errorf("🔥 Failed to load the glossary: %s", err.Error())
+
+		return 2
+	}
+
+	systemPrompt, err := ai.RenderPrompt(promptTemplate, ai.PromptData{
+		Catalogs:   policy.Catalogs,
+		DateFormat: aiConfig.DateFormat,
+		Glossary:   glossary,
+	})
+
+	if err != nil {
+		log.Errorf("🔥 Failed to render the prompt template: %s", err.Error())
+
+		return 2
+	}
+
+	trinoDb, err := sql.Open("trino", trinoDsn)
+
+	if err != nil {
+		log.Errorf("🔥 Failed to connect to Trino: %s", err.Error())
+
+		return 2
+	}
+
+	defer trinoDb.Close()
+
+	if seed {
+		if err := suite.Seed(ctx); err != nil {
+			log.Errorf("🔥 %s", err.Error())
+
+			return 2
+		}
+	}
+
+	evaluator := &evaluator{
+		generator:    generator,
+		systemPrompt: systemPrompt,
+		trino:        trinoDb,
+		policy:       policy,
+		timeout:      timeout,
+	}
+
+	scorecard := Scorecard{
+		Suite:     suite.Name,
+		Label:     label,
+		Provider:  aiConfig.Provider,
+		Model:     aiConfig.Model,
+		StartedAt: time.Now(),
+	}
+
+	for _, evalCase := range suite.Cases {
+		if caseName != "" && evalCase.Name != caseName {
+			continue
+		}
+
+		log.Infof("🔃 Running %s", evalCase.Name)
+
+		result := evaluator.evaluate(ctx, evalCase)
+
+		if result.Passed {
+			log.Infof("✅ %s passed", evalCase.Name)
+		} else {
+			log.Warnf("⚠️ %s failed", evalCase.Name)
+		}
+
+		scorecard.add(result)
+	}
+
+	if scorecard.Total == 0 {
+		log.Errorf("🔥 The suite has no case named %q", caseName)
+
+		return 2
+	}
+
+	scorecard.Print(os.Stdout, baseline)
+
+	if out != "" {
+		if err := scorecard.Write(out); err != nil {
+			log.Errorf("🔥 Failed to write the scorecard: %s", err.Error())
+
+			return 2
+		}
+	}
+
+	if scorecard.Passed < scorecard.Total {
+		return 1
+	}
+
+	return 0
+}
+
+// loadGlossary returns the glossary kept in the Postgres at dsn, or none when
+// dsn is empty.
+func loadGlossary(ctx context.Context, dsn string) ([]ai.GlossaryTerm, error) {
+	if dsn == "" {
+		log.Warn("⚠️ No Postgres DSN, generating without the glossary")
+
+		return nil, nil
+	}
+
+	pool, err := pgxpool.New(ctx, dsn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer pool.Close()
+
+	return ai.LoadGlossary(ctx, postgres.New(pool))
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+type CaseResult struct {
+	Name       string   `json:"name"`
+	Passed     bool     `json:"passed"`
+	Failures   []string `json:"failures"`
+	SqlQuery   string   `json:"sql_query"`
+	Rows       int      `json:"rows"`
+	Checksum   string   `json:"checksum"`
+	ToolCalls  int      `json:"tool_calls"`
+	Tokens     int64    `json:"tokens"`
+	DurationMs int64    `json:"duration_ms"`
+}
+
+// fail records a failure that stops the case from being checked any further.
+func (r CaseResult) fail(format string, args ...any) CaseResult {
+	r.Failures = append(r.Failures, fmt.Sprintf(format, args...))
+	r.Passed = false
+
+	return r
+}
+
+// Scorecard is the outcome of one run of a suite. It is written as JSON so
+// that a later run, for example with a changed system prompt, can be compared
+// against it.
+type Scorecard struct {
+	Suite      string       `json:"suite"`
+	Label      string       `json:"label"`
+	Provider   string       `json:"provider"`
+	Model      string       `json:"model"`
+	StartedAt  time.Time    `json:"started_at"`
+	Passed     int          `json:"passed"`
+	Total      int          `json:"total"`
+	ToolCalls  int          `json:"tool_calls"`
+	Tokens     int64        `json:"tokens"`
+	DurationMs int64        `json:"duration_ms"`
+	Cases      []CaseResult `json:"cases"`
+}
+
+func (s *Scorecard) add(result CaseResult) {
+	s.Cases = append(s.Cases, result)
+	s.Total++
+	s.ToolCalls += result.ToolCalls
+	s.Tokens += result.Tokens
+	s.DurationMs += result.DurationMs
+
+	if result.Passed {
+		s.Passed++
+	}
+}
+
+// LoadScorecard reads a scorecard written by a previous run.
+func LoadScorecard(path string) (Scorecard, error) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return Scorecard{}, err
+	}
+
+	scorecard := Scorecard{}
+
+	if err := json.Unmarshal(data, &scorecard); err != nil {
+		return Scorecard{}, fmt.Errorf("unable to parse the scorecard in %s: %w", path, err)
+	}
+
+	return scorecard, nil
+}
+
+func (s Scorecard) Write(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Print writes the scorecard as a table, followed by the failures of every
+// case that failed. When baseline is set, each case is compared against its
+// result in the baseline.
+func (s Scorecard) Print(w io.Writer, baseline *Scorecard) {
+	previous := map[string]CaseResult{}
+
+	if baseline != nil {
+		for _, result := range baseline.Cases {
+			previous[result.Name] = result
+		}
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	header := "CASE\tRESULT\tROWS\tCHECKSUM\tTOOL CALLS\tTOKENS\tDURATION"
+
+	if baseline != nil {
+		header += "\tBASELINE"
+	}
+
+	fmt.Fprintln(table, header)
+
+	for _, result := range s.Cases {
+		line := fmt.Sprintf("%s\t%s\t%d\t%s\t%d\t%d\t%s", result.Name, status(result.Passed), result.Rows, result.Checksum, result.ToolCalls, result.Tokens, time.Duration(result.DurationMs)*time.Millisecond)
+
+		if baseline != nil {
+			line += "\t" + compare(result, previous)
+		}
+
+		fmt.Fprintln(table, line)
+	}
+
+	table.Flush()
+
+	fmt.Fprintf(w, "\n%s: %d/%d passed, %d tool calls, %d tokens", s.Suite, s.Passed, s.Total, s.ToolCalls, s.Tokens)
+
+	if baseline != nil {
+		fmt.Fprintf(w, " (baseline %s: %d/%d passed, %d tool calls, %d tokens)", baseline.Label, baseline.Passed, baseline.Total, baseline.ToolCalls, baseline.Tokens)
+	}
+
+	fmt.Fprintln(w)
+
+	for _, result := range s.Cases {
+		if result.Passed {
+			continue
+		}
+
+		fmt.Fprintf(w, "\n✗ %s\n", result.Name)
+
+		for _, failure := range result.Failures {
+			fmt.Fprintf(w, "  - %s\n", failure)
+		}
+
+		if result.SqlQuery != "" {
+			fmt.Fprintf(w, "  SQL:\n    %s\n", strings.ReplaceAll(result.SqlQuery, "\n", "\n    "))
+		}
+	}
+}
+
+func status(passed bool) string {
+	if passed {
+		return "PASS"
+	}
+
+	return "FAIL"
+}
+
+// compare describes how result changed since the baseline.
+func compare(result CaseResult, previous map[string]CaseResult) string {
+	before, ok := previous[result.Name]
+
+	switch {
+	case !ok:
+		return "new"
+	case before.Passed && !result.Passed:
+		return "regressed"
+	case !before.Passed && result.Passed:
+		return "fixed"
+	default:
+		return status(before.Passed)
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/common"
+	"github.com/gofiber/fiber/v2/log"
+	"gopkg.in/yaml.v3"
+)
+
+// fixtureDsns are the databases of the eval-mysql docker compose service
+// that the eval-trino catalogs read.
+var fixtureDsns = map[string]string{
+	"zing":   "zing:eval@tcp(localhost:3307)/zing",
+	"radius": "radius:eval@tcp(localhost:3307)/radius",
+}
+
+// Suite is a YAML file of prompts and what the SQL generated for each of
+// them must return when it is run against the fixture databases.
+type Suite struct {
+	Name string `yaml:"name"`
+	// Seeds maps a Trino catalog to a SQL file, relative to the suite, that
+	// seeds the MySQL database behind it. The database is reached through
+	// the EVAL_<CATALOG>_DSN environment variable, which defaults to the
+	// eval-mysql service for the zing and radius catalogs.
+	Seeds map[string]string `yaml:"seeds"`
+	Cases []Case            `yaml:"cases"`
+
+	path string
+}
+
+type Case struct {
+	Name   string `yaml:"name"`
+	Prompt string `yaml:"prompt"`
+	// Values are the parameter values the generated SQL is run with. Missing
+	// values fall back to the parameter defaults.
+	Values map[string]string `yaml:"values"`
+	Expect Expectation       `yaml:"expect"`
+}
+
+// Expectation lists the properties a case checks. Properties that are left
+// out are not checked.
+type Expectation struct {
+	// Columns are the output column names, in order.
+	Columns []string `yaml:"columns"`
+	// IncludeColumns must be among the output columns, in any order.
+	IncludeColumns []string `yaml:"include_columns"`
+	// Parameters must be declared by the generated SQL.
+	Parameters []string `yaml:"parameters"`
+	RowCount   *int     `yaml:"row_count"`
+	MinRows    *int     `yaml:"min_rows"`
+	MaxRows    *int     `yaml:"max_rows"`
+	// Checksum is the checksum of every row returned, as printed in the
+	// scorecard. Rows are sorted first unless Ordered is set.
+	Checksum     string `yaml:"checksum"`
+	Ordered      bool   `yaml:"ordered"`
+	MaxToolCalls int    `yaml:"max_tool_calls"`
+}
+
+// LoadSuite reads and checks the suite at path.
+func LoadSuite(path string) (Suite, error) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return Suite{}, err
+	}
+
+	suite := Suite{path: path}
+
+	if err := yaml.Unmarshal(data, &suite); err != nil {
+		return Suite{}, fmt.Errorf("unable to parse the suite in %s: %w", path, err)
+	}
+
+	if len(suite.Cases) == 0 {
+		return Suite{}, fmt.Errorf("the suite in %s has no cases", path)
+	}
+
+	names := map[string]bool{}
+
+	for index, evalCase := range suite.Cases {
+		if strings.TrimSpace(evalCase.Name) == "" {
+			return Suite{}, fmt.Errorf("case %d has no name", index+1)
+		}
+
+		if names[evalCase.Name] {
+			return Suite{}, fmt.Errorf("case %q is declared more than once", evalCase.Name)
+		}
+
+		names[evalCase.Name] = true
+
+		if strings.TrimSpace(evalCase.Prompt) == "" {
+			return Suite{}, fmt.Errorf("case %q has no prompt", evalCase.Name)
+		}
+	}
+
+	if suite.Name == "" {
+		suite.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	return suite, nil
+}
+
+// Seed runs the seed file of every catalog against its fixture database.
+func (s Suite) Seed(ctx context.Context) error {
+	for catalog, seed := range s.Seeds {
+		variable := fmt.Sprintf("EVAL_%s_DSN", strings.ToUpper(catalog))
+		dsn := common.EnvString(variable, fixtureDsns[catalog])
+
+		if dsn == "" {
+			return fmt.Errorf("seeding the %s catalog requires %s", catalog, variable)
+		}
+
+		data, err := os.ReadFile(filepath.Join(filepath.Dir(s.path), seed))
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("🔃 Seeding the %s fixture database from %s", catalog, seed)
+
+		if err := seedDatabase(ctx, dsn, string(data)); err != nil {
+			return fmt.Errorf("unable to seed the %s fixture database: %w", catalog, err)
+		}
+	}
+
+	return nil
+}
+
+func seedDatabase(ctx context.Context, dsn string, statements string) error {
+	if !strings.Contains(dsn, "multiStatements=") {
+		separator := "?"
+
+		if strings.Contains(dsn, "?") {
+			separator = "&"
+		}
+
+		dsn += separator + "multiStatements=true"
+	}
+
+	db, err := sql.Open("mysql", dsn)
+
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	if strings.TrimSpace(statements) == "" {
+		return errors.New("the seed file is empty")
+	}
+
+	_, err = db.ExecContext(ctx, statements)
+
+	return err
+}
@@ -0,0 +1,169 @@
+-- The Zing fixture data the zing suite is scored against. Tables are created
+-- from the same DDL as production, with foreign key checks off so that the
+-- tables they reference need not be seeded.
+SET FOREIGN_KEY_CHECKS = 0;
+
+DROP TABLE IF EXISTS Recharges, Products, Customers, Addresses;
+
+CREATE TABLE
+    `Addresses` (
+        `Id` char(36) CHARACTER
+        SET
+            ascii COLLATE ascii_general_ci NOT NULL,
+            `ERF` longtext DEFAULT NULL,
+            `StreetAddress` longtext DEFAULT NULL,
+            `MDUName` longtext DEFAULT NULL,
+            `MDUUnitNumber` longtext DEFAULT NULL,
+            `MDUBlock` longtext DEFAULT NULL,
+            `Township` longtext DEFAULT NULL,
+            `PropertyType` longtext DEFAULT NULL,
+            `POP` longtext DEFAULT NULL,
+            `InstallDate` datetime (6) DEFAULT NULL,
+            `RadiusUsername` longtext DEFAULT NULL,
+            `RadiusPassword` longtext DEFAULT NULL,
+            `InstallComplete` tinyint (1) NOT NULL,
+            `W3W` longtext DEFAULT NULL,
+            `InstallState` tinyint (3) unsigned DEFAULT NULL,
+            `PoleNumber` longtext DEFAULT NULL,
+            `ServiceID` bigint (20) NOT NULL AUTO_INCREMENT,
+            `BuildId` char(36) CHARACTER
+        SET
+            ascii COLLATE ascii_general_ci DEFAULT NULL,
+            `SalesAgentId` char(36) CHARACTER
+        SET
+            ascii COLLATE ascii_general_ci DEFAULT NULL,
+            `DateCreated` datetime (6) NOT NULL,
+            `Deleted` tinyint (1) NOT NULL,
+            PRIMARY KEY (`Id`),
+            UNIQUE KEY `ServiceID_UNIQUE` (`ServiceID`),
+            UNIQUE KEY `Id_UNIQUE` (`Id`),
+            KEY `IX_Addresses_BuildId` (`BuildId`),
+            KEY `IX_Addresses_SalesAgentId` (`SalesAgentId`),
+            CONSTRAINT `FK_Addresses_Builds_BuildId` FOREIGN KEY (`BuildId`) REFERENCES `Builds` (`Id`),
+            CONSTRAINT `FK_Addresses_SalesAgents_SalesAgentId` FOREIGN KEY (`SalesAgentId`) REFERENCES `SalesAgents` (`Id`)
+    ) ENGINE = InnoDB AUTO_INCREMENT = 6102 DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_general_ci;
+
+CREATE TABLE
+    `Customers` (
+        `Id` char(36) CHARACTER
+        SET
+            ascii COLLATE ascii_general_ci NOT NULL,
+            `FirstName` longtext DEFAULT NULL,
+            `Surname` longtext DEFAULT NULL,
+            `Password` longtext DEFAULT NULL,
+            `PasswordSalt` longtext DEFAULT NULL,
+            `Email` longtext DEFAULT NULL,
+            `PhoneNumber` longtext DEFAULT NULL,
+            `IdNumber` longtext DEFAULT NULL,
+            `RadiusUsername` longtext DEFAULT NULL,
+            `PreferEmailCommunication` tinyint (1) NOT NULL,
+            `Language` longtext DEFAULT NULL,
+            `RegistrationApproved` tinyint (1) NOT NULL,
+            `RegistrationDeclined` tinyint (1) NOT NULL,
+            `SetOwnPassword` tinyint (1) NOT NULL,
+            `SubscriptionToken` longtext DEFAULT NULL,
+            `ProofOfAddressDocumentId` char(36) CHARACTER
+        SET
+            ascii COLLATE ascii_general_ci DEFAULT NULL,
+            `IDBookDocumentId` char(36) CHARACTER
+        SET
+            ascii COLLATE ascii_general_ci DEFAULT NULL,
+            `ApprovedByUserId` char(36) CHARACTER
+        SET
+            ascii COLLATE ascii_general_ci DEFAULT NULL,
+            `AddressId` char(36) CHARACTER
+        SET
+            ascii COLLATE ascii_general_ci DEFAULT NULL,
+            `PotentialAddress` longtext DEFAULT NULL,
+            `SalesAgentId` char(36) CHARACTER
+        SET
+            ascii COLLATE ascii_general_ci DEFAULT NULL,
+            `DateCreated` datetime (6) NOT NULL,
+            `Deleted` tinyint (1) NOT NULL,
+            PRIMARY KEY (`Id`),
+            KEY `IX_Customers_AddressId` (`AddressId`),
+            KEY `IX_Customers_ApprovedByUserId` (`ApprovedByUserId`),
+            KEY `IX_Customers_IDBookDocumentId` (`IDBookDocumentId`),
+            KEY `IX_Customers_ProofOfAddressDocumentId` (`ProofOfAddressDocumentId`),
+            KEY `IX_Customers_SalesAgentId` (`SalesAgentId`),
+            CONSTRAINT `FK_Customers_Addresses_AddressId` FOREIGN KEY (`AddressId`) REFERENCES `Addresses` (`Id`),
+            CONSTRAINT `FK_Customers_Documents_IDBookDocumentId` FOREIGN KEY (`IDBookDocumentId`) REFERENCES `Documents` (`Id`),
+            CONSTRAINT `FK_Customers_Documents_ProofOfAddressDocumentId` FOREIGN KEY (`ProofOfAddressDocumentId`) REFERENCES `Documents` (`Id`),
+            CONSTRAINT `FK_Customers_SalesAgents_SalesAgentId` FOREIGN KEY (`SalesAgentId`) REFERENCES `SalesAgents` (`Id`),
+            CONSTRAINT `FK_Customers_Users_ApprovedByUserId` FOREIGN KEY (`ApprovedByUserId`) REFERENCES `Users` (`Id`)
+    ) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_general_ci;
+
+CREATE TABLE
+    `Products` (
+        `Id` char(36) CHARACTER
+        SET
+            ascii COLLATE ascii_general_ci NOT NULL,
+            `Price` decimal(18, 2) NOT NULL,
+            `Name` longtext DEFAULT NULL,
+            `Category` longtext DEFAULT NULL,
+            `Period` int (11) NOT NULL,
+            `ServiceId` int (11) NOT NULL,
+            `Months` int (11) DEFAULT NULL,
+            `DateCreated` datetime (6) NOT NULL,
+            `Deleted` tinyint (1) NOT NULL,
+            PRIMARY KEY (`Id`)
+    ) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_general_ci;
+
+CREATE TABLE
+    `Recharges` (
+        `Id` char(36) CHARACTER
+        SET
+            ascii COLLATE ascii_general_ci NOT NULL,
+            `CustomerId` char(36) CHARACTER
+        SET
+            ascii COLLATE ascii_general_ci DEFAULT NULL,
+            `ProductId` char(36) CHARACTER
+        SET
+            ascii COLLATE ascii_general_ci DEFAULT NULL,
+            `Method` longtext DEFAULT NULL,
+            `PaymentServicePaymentId` longtext DEFAULT NULL,
+            `PaymentServicePayload` longtext DEFAULT NULL,
+            `PaymentServiceQueryParams` longtext DEFAULT NULL,
+            `RechargeSuccessful` tinyint (1) NOT NULL,
+            `FailureReason` longtext DEFAULT NULL,
+            `PaymentAmount` decimal(18, 2) DEFAULT NULL,
+            `ExpiryDate` datetime (6) DEFAULT NULL,
+            `PreviousRMExpiryDate` datetime (6) DEFAULT NULL,
+            `UserId` char(36) CHARACTER
+        SET
+            ascii COLLATE ascii_general_ci DEFAULT NULL,
+            `FromRMSvcID` int (11) DEFAULT NULL,
+            `ToRMSvcID` int (11) DEFAULT NULL,
+            `DateCreated` datetime (6) NOT NULL,
+            `Deleted` tinyint (1) NOT NULL,
+            PRIMARY KEY (`Id`),
+            KEY `IX_Recharges_CustomerId` (`CustomerId`),
+            KEY `IX_Recharges_ProductId` (`ProductId`),
+            KEY `IX_Recharges_UserId` (`UserId`),
+            CONSTRAINT `FK_Recharges_Customers_CustomerId` FOREIGN KEY (`CustomerId`) REFERENCES `Customers` (`Id`),
+            CONSTRAINT `FK_Recharges_Products_ProductId` FOREIGN KEY (`ProductId`) REFERENCES `Products` (`Id`),
+            CONSTRAINT `FK_Recharges_Users_UserId` FOREIGN KEY (`UserId`) REFERENCES `Users` (`Id`)
+    ) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_general_ci;
+
+INSERT INTO Addresses (Id, StreetAddress, POP, RadiusUsername, InstallComplete, DateCreated, Deleted) VALUES
+    ('a0000000-0000-0000-0000-000000000001', '1 Main Road', 'Edenvale', 'thandi.m', 1, '2024-11-01 09:00:00', 0),
+    ('a0000000-0000-0000-0000-000000000002', '3 Main Road', 'Edenvale', 'pieter.v', 1, '2024-11-03 09:00:00', 0),
+    ('a0000000-0000-0000-0000-000000000003', '8 Pretoria Road', 'Kempton Park', 'lerato.k', 1, '2024-12-01 09:00:00', 0);
+
+INSERT INTO Customers (Id, FirstName, Surname, Password, Email, PhoneNumber, RadiusUsername, PreferEmailCommunication, RegistrationApproved, RegistrationDeclined, SetOwnPassword, AddressId, DateCreated, Deleted) VALUES
+    ('c0000000-0000-0000-0000-000000000001', 'Thandi', 'Mokoena', 'not-a-real-hash', 'thandi@example.com', '0821234567', 'thandi.m', 1, 1, 0, 1, 'a0000000-0000-0000-0000-000000000001', '2024-11-01 09:30:00', 0),
+    ('c0000000-0000-0000-0000-000000000002', 'Pieter', 'van Wyk', 'not-a-real-hash', 'pieter@example.com', '0837654321', 'pieter.v', 1, 1, 0, 1, 'a0000000-0000-0000-0000-000000000002', '2024-11-03 09:30:00', 0),
+    ('c0000000-0000-0000-0000-000000000003', 'Lerato', 'Khumalo', 'not-a-real-hash', 'lerato@example.com', '0761112222', 'lerato.k', 0, 1, 0, 1, 'a0000000-0000-0000-0000-000000000003', '2024-12-01 09:30:00', 0),
+    ('c0000000-0000-0000-0000-000000000004', 'Sipho', 'Dlamini', 'not-a-real-hash', 'sipho@example.com', '0729998888', NULL, 1, 0, 0, 0, NULL, '2025-01-15 12:00:00', 0);
+
+INSERT INTO Products (Id, Price, Name, Category, Period, ServiceId, Months, DateCreated, Deleted) VALUES
+    ('b0000000-0000-0000-0000-000000000001', 499.00, '50Mbps', 'Uncapped', 30, 10, 1, '2024-01-01 00:00:00', 0),
+    ('b0000000-0000-0000-0000-000000000002', 699.00, '100Mbps', 'Uncapped', 30, 11, 1, '2024-01-01 00:00:00', 0);
+
+INSERT INTO Recharges (Id, CustomerId, ProductId, Method, RechargeSuccessful, FailureReason, PaymentAmount, ExpiryDate, DateCreated, Deleted) VALUES
+    ('d0000000-0000-0000-0000-000000000001', 'c0000000-0000-0000-0000-000000000003', NULL, 'Intro', 1, NULL, 0.00, '2025-01-02 10:00:00', '2024-12-02 10:00:00', 0),
+    ('d0000000-0000-0000-0000-000000000002', 'c0000000-0000-0000-0000-000000000001', 'b0000000-0000-0000-0000-000000000001', 'Card', 1, NULL, 499.00, '2025-02-05 10:00:00', '2025-01-05 10:00:00', 0),
+    ('d0000000-0000-0000-0000-000000000003', 'c0000000-0000-0000-0000-000000000002', 'b0000000-0000-0000-0000-000000000002', 'EFT', 1, NULL, 699.00, '2025-02-12 14:00:00', '2025-01-12 14:00:00', 0),
+    ('d0000000-0000-0000-0000-000000000004', 'c0000000-0000-0000-0000-000000000003', 'b0000000-0000-0000-0000-000000000001', 'Card', 0, 'Declined', 499.00, NULL, '2025-01-20 08:15:00', 0),
+    ('d0000000-0000-0000-0000-000000000005', 'c0000000-0000-0000-0000-000000000003', 'b0000000-0000-0000-0000-000000000001', 'Card', 1, NULL, 499.00, '2025-02-21 08:20:00', '2025-01-21 08:20:00', 0),
+    ('d0000000-0000-0000-0000-000000000006', 'c0000000-0000-0000-0000-000000000001', 'b0000000-0000-0000-0000-000000000001', 'Card', 1, NULL, 499.00, '2025-03-05 10:00:00', '2025-02-05 10:00:00', 0);
@@ -0,0 +1,155 @@
+package dynamicQueries
+
+import (
+	"context"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// access is how much a user may do with a dynamic query. Each level includes
+// the ones before it.
+type access int
+
+const (
+	noAccess access = iota
+	// viewAccess allows reading the query, its versions, runs and schedule.
+	viewAccess
+	// runAccess also allows fetching and exporting its results.
+	runAccess
+	// editAccess also allows changing and regenerating it.
+	editAccess
+	// ownerAccess also allows deleting it, changing its visibility and
+	// sharing it.
+	ownerAccess
+)
+
+// validVisibility reports whether visibility is one of the known values.
+func validVisibility(visibility postgres.DynamicQueryVisibility) bool {
+	switch visibility {
+	case postgres.DynamicQueryVisibilityPrivate, postgres.DynamicQueryVisibilityShared, postgres.DynamicQueryVisibilityPublic:
+		return true
+	default:
+		return false
+	}
+}
+
+func permissionAccess(permission postgres.DynamicQueryPermission) access {
+	switch permission {
+	case postgres.DynamicQueryPermissionEdit:
+		return editAccess
+	case postgres.DynamicQueryPermissionRun:
+		return runAccess
+	case postgres.DynamicQueryPermissionView:
+		return viewAccess
+	default:
+		return noAccess
+	}
+}
+
+// accessFor works out how much user may do with dynamicQuery. Admins and the
+// owner may do anything. Anyone may run a public query, and shares to the
+// user or their role grant more on a public or shared query. Nobody else may
+// see a private one.
+func (r *DynamicQueriesRouter) accessFor(ctx context.Context, user postgres.User, dynamicQuery postgres.DynamicQuery) (access, error) {
+	if user.Role == postgres.RoleTypeAdmin || (dynamicQuery.CreatedBy.Valid && uuid.UUID(dynamicQuery.CreatedBy.Bytes) == user.ID) {
+		return ownerAccess, nil
+	}
+
+	granted := noAccess
+
+	switch dynamicQuery.Visibility {
+	case postgres.DynamicQueryVisibilityPublic:
+		granted = runAccess
+	case postgres.DynamicQueryVisibilityShared:
+	default:
+		return noAccess, nil
+	}
+
+	permissions, err := r.Postgres.GetDynamicQueryPermissions(ctx, postgres.GetDynamicQueryPermissionsParams{
+		DynamicQueryID: dynamicQuery.ID,
+		UserID:         pgtype.UUID{Bytes: user.ID, Valid: true},
+		Role:           postgres.NullRoleType{RoleType: user.Role, Valid: true},
+	})
+
+	if err != nil {
+		return noAccess, err
+	}
+
+	for _, permission := range permissions {
+		granted = max(granted, permissionAccess(permission))
+	}
+
+	return granted, nil
+}
+
+// authorize checks that the current user has at least required access to
+// dynamicQuery. When they do not, it responds with a 404 if they may not see
+// the query at all, so that private queries are not revealed, or with a 403
+// if they may, and reports false along with the error from responding.
+func (r *DynamicQueriesRouter) authorize(c *fiber.Ctx, dynamicQuery postgres.DynamicQuery, required access) (bool, error) {
+	granted, err := r.accessFor(c.Context(), c.Locals("user").(postgres.User), dynamicQuery)
+
+	if err != nil {
+		log.Errorf("🔥 Error retrieving dynamic query permissions: %s", err.Error())
+
+		return false, c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+			"error":   constants.InternalServerError,
+			"details": constants.InternalServerErrorDetails,
+		})
+	}
+
+	if granted == noAccess {
+		log.Warnf("⚠️ Dynamic Query with ID %s is not visible to the current user", dynamicQuery.ID)
+
+		return false, c.Status(fiber.StatusNotFound).JSON(&fiber.Map{
+			"error":   constants.NotFoundError,
+			"details": constants.NotFoundErrorDetails,
+		})
+	}
+
+	if granted < required {
+		log.Warnf("⚠️ The current user may not do that with Dynamic Query %s", dynamicQuery.ID)
+
+		return false, c.Status(fiber.StatusForbidden).JSON(&fiber.Map{
+			"error":   constants.ForbiddenError,
+			"details": constants.ForbiddenErrorDetails,
+		})
+	}
+
+	return true, nil
+}
+
+// authorizedDynamicQuery loads the dynamic query with id and authorizes the
+// current user for required access to it. It responds with a 404 when the
+// query does not exist and reports false in the same way as authorize.
+func (r *DynamicQueriesRouter) authorizedDynamicQuery(c *fiber.Ctx, id uuid.UUID, required access) (postgres.DynamicQuery, bool, error) {
+	dynamicQuery, err := r.Postgres.GetDynamicQuery(c.Context(), id)
+
+	if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+		log.Errorf("🔥 Error retrieving dynamic query: %s", err.Error())
+
+		return dynamicQuery, false, c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+			"error":   constants.InternalServerError,
+			"details": constants.InternalServerErrorDetails,
+		})
+	}
+
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		log.Warnf("⚠️ Dynamic Query with ID %s not found", id)
+
+		return dynamicQuery, false, c.Status(fiber.StatusNotFound).JSON(&fiber.Map{
+			"error":   constants.NotFoundError,
+			"details": constants.NotFoundErrorDetails,
+		})
+	}
+
+	ok, err := r.authorize(c, dynamicQuery, required)
+
+	return dynamicQuery, ok, err
+}
@@ -0,0 +1,161 @@
+package dynamicQueries
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// permissionsDB answers GetDynamicQueryPermissions with permissions.
+type permissionsDB struct {
+	permissions []postgres.DynamicQueryPermission
+}
+
+func (db permissionsDB) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, nil
+}
+
+func (db permissionsDB) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	return &permissionRows{permissions: db.permissions, index: -1}, nil
+}
+
+func (db permissionsDB) QueryRow(context.Context, string, ...any) pgx.Row {
+	return nil
+}
+
+type permissionRows struct {
+	pgx.Rows
+	permissions []postgres.DynamicQueryPermission
+	index       int
+}
+
+func (rows *permissionRows) Next() bool {
+	rows.index++
+
+	return rows.index < len(rows.permissions)
+}
+
+func (rows *permissionRows) Scan(dest ...any) error {
+	*dest[0].(*postgres.DynamicQueryPermission) = rows.permissions[rows.index]
+
+	return nil
+}
+
+func (rows *permissionRows) Err() error {
+	return nil
+}
+
+func (rows *permissionRows) Close() {}
+
+var (
+	testOwner = postgres.User{ID: uuid.New(), Role: postgres.RoleTypeUser}
+	testUser  = postgres.User{ID: uuid.New(), Role: postgres.RoleTypeUser}
+	testAdmin = postgres.User{ID: uuid.New(), Role: postgres.RoleTypeAdmin}
+)
+
+func testDynamicQuery(visibility postgres.DynamicQueryVisibility) postgres.DynamicQuery {
+	return postgres.DynamicQuery{
+		ID:         uuid.New(),
+		CreatedBy:  pgtype.UUID{Bytes: testOwner.ID, Valid: true},
+		Visibility: visibility,
+	}
+}
+
+func TestAccessFor(t *testing.T) {
+	view, run, edit := postgres.DynamicQueryPermissionView, postgres.DynamicQueryPermissionRun, postgres.DynamicQueryPermissionEdit
+
+	tests := []struct {
+		name        string
+		user        postgres.User
+		visibility  postgres.DynamicQueryVisibility
+		permissions []postgres.DynamicQueryPermission
+		want        access
+	}{
+		{"owner", testOwner, postgres.DynamicQueryVisibilityPrivate, nil, ownerAccess},
+		{"admin", testAdmin, postgres.DynamicQueryVisibilityPrivate, nil, ownerAccess},
+		{"private", testUser, postgres.DynamicQueryVisibilityPrivate, nil, noAccess},
+		{"private ignores shares", testUser, postgres.DynamicQueryVisibilityPrivate, []postgres.DynamicQueryPermission{edit}, noAccess},
+		{"shared without share", testUser, postgres.DynamicQueryVisibilityShared, nil, noAccess},
+		{"shared view", testUser, postgres.DynamicQueryVisibilityShared, []postgres.DynamicQueryPermission{view}, viewAccess},
+		{"shared run", testUser, postgres.DynamicQueryVisibilityShared, []postgres.DynamicQueryPermission{run}, runAccess},
+		{"shared edit", testUser, postgres.DynamicQueryVisibilityShared, []postgres.DynamicQueryPermission{edit}, editAccess},
+		{"shared user and role", testUser, postgres.DynamicQueryVisibilityShared, []postgres.DynamicQueryPermission{view, edit, run}, editAccess},
+		{"public", testUser, postgres.DynamicQueryVisibilityPublic, nil, runAccess},
+		{"public view", testUser, postgres.DynamicQueryVisibilityPublic, []postgres.DynamicQueryPermission{view}, runAccess},
+		{"public edit", testUser, postgres.DynamicQueryVisibilityPublic, []postgres.DynamicQueryPermission{edit}, editAccess},
+		{"unknown visibility", testUser, postgres.DynamicQueryVisibility("unknown"), []postgres.DynamicQueryPermission{edit}, noAccess},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &DynamicQueriesRouter{Postgres: postgres.New(permissionsDB{permissions: test.permissions})}
+
+			granted, err := r.accessFor(context.Background(), test.user, testDynamicQuery(test.visibility))
+
+			if err != nil {
+				t.Fatalf("accessFor() error = %v, want none", err)
+			}
+
+			if granted != test.want {
+				t.Fatalf("accessFor() = %d, want %d", granted, test.want)
+			}
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	view := postgres.DynamicQueryPermissionView
+
+	tests := []struct {
+		name        string
+		user        postgres.User
+		visibility  postgres.DynamicQueryVisibility
+		permissions []postgres.DynamicQueryPermission
+		required    access
+		status      int
+	}{
+		{"none", testUser, postgres.DynamicQueryVisibilityPrivate, nil, viewAccess, fiber.StatusNotFound},
+		{"view", testUser, postgres.DynamicQueryVisibilityShared, []postgres.DynamicQueryPermission{view}, viewAccess, fiber.StatusOK},
+		{"view cannot run", testUser, postgres.DynamicQueryVisibilityShared, []postgres.DynamicQueryPermission{view}, runAccess, fiber.StatusForbidden},
+		{"run", testUser, postgres.DynamicQueryVisibilityPublic, nil, runAccess, fiber.StatusOK},
+		{"run cannot edit", testUser, postgres.DynamicQueryVisibilityPublic, nil, editAccess, fiber.StatusForbidden},
+		{"edit cannot delete", testUser, postgres.DynamicQueryVisibilityShared, []postgres.DynamicQueryPermission{postgres.DynamicQueryPermissionEdit}, ownerAccess, fiber.StatusForbidden},
+		{"owner", testOwner, postgres.DynamicQueryVisibilityPrivate, nil, ownerAccess, fiber.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &DynamicQueriesRouter{Postgres: postgres.New(permissionsDB{permissions: test.permissions})}
+			dynamicQuery := testDynamicQuery(test.visibility)
+
+			app := fiber.New()
+
+			app.Get("/", func(c *fiber.Ctx) error {
+				c.Locals("user", test.user)
+
+				if ok, err := r.authorize(c, dynamicQuery, test.required); !ok {
+					return err
+				}
+
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			response, err := app.Test(httptest.NewRequest("GET", "/", nil))
+
+			if err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+
+			if response.StatusCode != test.status {
+				t.Fatalf("authorize() responded %d, want %d", response.StatusCode, test.status)
+			}
+		})
+	}
+}
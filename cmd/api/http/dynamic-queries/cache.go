@@ -0,0 +1,176 @@
+package dynamicQueries
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// cachedDynamicQueryResult is a dynamic query result and whether it was
+// served from the result cache, in which case Age is how many seconds ago it
+// was computed.
+type cachedDynamicQueryResult struct {
+	Result system.DynamicQueryResult
+	Total  int64
+	Hit    bool
+	Age    int64
+}
+
+// cacheHeaders documents the headers set by setCacheHeaders.
+var cacheHeaders = openapi3.Headers{
+	"X-Cache": &openapi3.HeaderRef{
+		Value: &openapi3.Header{
+			Parameter: openapi3.Parameter{
+				Description: "HIT when the result was served from the result cache, MISS when the query was run.",
+				Schema:      openapi3.NewStringSchema().WithEnum("HIT", "MISS").NewRef(),
+			},
+		},
+	},
+	"Age": &openapi3.HeaderRef{
+		Value: &openapi3.Header{
+			Parameter: openapi3.Parameter{
+				Description: "Seconds since the result was computed.",
+				Schema:      openapi3.NewIntegerSchema().NewRef(),
+			},
+		},
+	},
+}
+
+// executeCachedDynamicQuery returns the cached result for the query's current
+// SQL and parameters, the parameter values, the options and the limit of the
+// user's role while it is fresh, and otherwise runs the query and caches what
+// it returns. Cached results are stored before masking. Refresh skips the
+// lookup. Caching is off when CacheTTL is not positive.
+func (r *DynamicQueriesRouter) executeCachedDynamicQuery(ctx context.Context, user postgres.User, dynamicQuery postgres.DynamicQuery, values map[string]string, options trino.ResultOptions, refresh bool) (cachedDynamicQueryResult, error) {
+	if r.CacheTTL <= 0 {
+		result, total, err := r.executeDynamicQuery(ctx, user, dynamicQuery, values, options)
+
+		return cachedDynamicQueryResult{Result: result, Total: total}, err
+	}
+
+	// A cached result must still be allowed by the current policy.
+	if err := trino.ValidateQuery(dynamicQuery.Query.String, r.Policy); err != nil {
+		return cachedDynamicQueryResult{}, err
+	}
+
+	sqlHash, cacheKey, err := resultCacheKeys(dynamicQuery, values, options, r.Executions.Limit(string(user.Role)))
+
+	if err != nil {
+		return cachedDynamicQueryResult{}, err
+	}
+
+	if !refresh {
+		cached, err := r.Postgres.GetDynamicQueryResultCache(ctx, postgres.GetDynamicQueryResultCacheParams{
+			DynamicQueryID: dynamicQuery.ID,
+			SqlHash:        sqlHash,
+			CacheKey:       cacheKey,
+		})
+
+		if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+			log.Errorf("🔥 Error retrieving cached dynamic query result: %s", err.Error())
+		}
+
+		if err == nil {
+			result, err := trino.DecodeDynamicQueryResult(cached.Result)
+
+			if err == nil {
+				return cachedDynamicQueryResult{
+					Result: result,
+					Total:  cached.Total,
+					Hit:    true,
+					Age:    max(cached.AgeSeconds, 0),
+				}, nil
+			}
+
+			log.Warnf("⚠️ Discarding unreadable cached dynamic query result: %s", err.Error())
+		}
+	}
+
+	result, total, err := r.executeDynamicQuery(ctx, user, dynamicQuery, values, options)
+
+	if err != nil {
+		return cachedDynamicQueryResult{}, err
+	}
+
+	r.cacheResult(ctx, dynamicQuery.ID, sqlHash, cacheKey, result, total)
+
+	return cachedDynamicQueryResult{Result: result, Total: total}, nil
+}
+
+// resultCacheKeys returns the hash of the query's SQL, which changes whenever
+// the SQL does, and the key of a result for it. The parameters hold the
+// defaults missing values fall back to, and the limit of the user's role
+// decides how many rows the result may hold, so both are part of the key.
+func resultCacheKeys(dynamicQuery postgres.DynamicQuery, values map[string]string, options trino.ResultOptions, limit trino.Limit) (string, string, error) {
+	key, err := json.Marshal(map[string]any{
+		"parameters": dynamicQuery.Parameters,
+		"values":     values,
+		"options":    options,
+		"limit":      limit,
+	})
+
+	if err != nil {
+		return "", "", err
+	}
+
+	return hash([]byte(dynamicQuery.Query.String)), hash(key), nil
+}
+
+// cacheResult stores result under sqlHash and cacheKey and deletes the
+// results cached for any other SQL. Failures are only logged, since the
+// result can still be returned.
+func (r *DynamicQueriesRouter) cacheResult(ctx context.Context, dynamicQueryID uuid.UUID, sqlHash string, cacheKey string, result system.DynamicQueryResult, total int64) {
+	data, err := json.Marshal(result)
+
+	if err != nil {
+		log.Errorf("🔥 Error encoding dynamic query result for the cache: %s", err.Error())
+
+		return
+	}
+
+	if err := r.Postgres.UpsertDynamicQueryResultCache(ctx, postgres.UpsertDynamicQueryResultCacheParams{
+		DynamicQueryID: dynamicQueryID,
+		SqlHash:        sqlHash,
+		CacheKey:       cacheKey,
+		Result:         data,
+		Total:          total,
+		TtlSeconds:     r.CacheTTL.Seconds(),
+	}); err != nil {
+		log.Errorf("🔥 Error caching dynamic query result: %s", err.Error())
+	}
+
+	// Results for earlier versions of the SQL can never be served again.
+	if err := r.Postgres.DeleteStaleDynamicQueryResultCaches(ctx, postgres.DeleteStaleDynamicQueryResultCachesParams{
+		DynamicQueryID: dynamicQueryID,
+		SqlHash:        sqlHash,
+	}); err != nil {
+		log.Errorf("🔥 Error deleting stale cached dynamic query results: %s", err.Error())
+	}
+}
+
+func setCacheHeaders(c *fiber.Ctx, cached cachedDynamicQueryResult) {
+	if cached.Hit {
+		c.Set("X-Cache", "HIT")
+	} else {
+		c.Set("X-Cache", "MISS")
+	}
+
+	c.Set(fiber.HeaderAge, strconv.FormatInt(cached.Age, 10))
+}
+
+func hash(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
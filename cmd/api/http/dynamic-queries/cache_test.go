@@ -0,0 +1,124 @@
+package dynamicQueries
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestResultCacheKeys(t *testing.T) {
+	limits := trino.Limits{
+		Default: trino.Limit{Timeout: time.Minute, MaxRows: 1000},
+		Roles: map[string]trino.Limit{
+			"admin": {Timeout: time.Minute, MaxRows: 100000},
+		},
+	}
+
+	dynamicQuery := postgres.DynamicQuery{
+		Query:      pgtype.Text{String: "SELECT * FROM zing.zing.customers WHERE pop = {{pop}}", Valid: true},
+		Parameters: system.DynamicQueryParameters{{Name: "pop", Type: system.POPParameter}},
+	}
+
+	values := map[string]string{"pop": "north"}
+	options := trino.ResultOptions{Page: 1, PageSize: 50}
+
+	sqlHash, cacheKey, err := resultCacheKeys(dynamicQuery, values, options, limits.For("user"))
+
+	if err != nil {
+		t.Fatalf("resultCacheKeys() error = %v, want none", err)
+	}
+
+	edited := dynamicQuery
+	edited.Query = pgtype.Text{String: "SELECT * FROM zing.zing.customers WHERE pop = {{pop}} LIMIT 10", Valid: true}
+
+	defaulted := dynamicQuery
+	defaulted.Parameters = system.DynamicQueryParameters{{Name: "pop", Type: system.POPParameter, Default: "south"}}
+
+	tests := []struct {
+		name         string
+		dynamicQuery postgres.DynamicQuery
+		values       map[string]string
+		options      trino.ResultOptions
+		role         string
+		sameSQL      bool
+		sameKey      bool
+	}{
+		{"same", dynamicQuery, map[string]string{"pop": "north"}, trino.ResultOptions{Page: 1, PageSize: 50}, "user", true, true},
+		{"role with the same limit", dynamicQuery, values, options, "staff", true, true},
+		{"role with another limit", dynamicQuery, values, options, "admin", true, false},
+		{"parameter value", dynamicQuery, map[string]string{"pop": "south"}, options, "user", true, false},
+		{"missing parameter value", dynamicQuery, map[string]string{}, options, "user", true, false},
+		{"parameter default", defaulted, values, options, "user", true, false},
+		{"page", dynamicQuery, values, trino.ResultOptions{Page: 2, PageSize: 50}, "user", true, false},
+		{"sort", dynamicQuery, values, trino.ResultOptions{Page: 1, PageSize: 50, Sort: []trino.ResultSort{{Column: "name"}}}, "user", true, false},
+		{"sql", edited, values, options, "user", false, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			otherSQLHash, otherCacheKey, err := resultCacheKeys(test.dynamicQuery, test.values, test.options, limits.For(test.role))
+
+			if err != nil {
+				t.Fatalf("resultCacheKeys() error = %v, want none", err)
+			}
+
+			if sameSQL := otherSQLHash == sqlHash; sameSQL != test.sameSQL {
+				t.Errorf("resultCacheKeys() same SQL hash = %v, want %v", sameSQL, test.sameSQL)
+			}
+
+			if sameKey := otherCacheKey == cacheKey; sameKey != test.sameKey {
+				t.Errorf("resultCacheKeys() same cache key = %v, want %v", sameKey, test.sameKey)
+			}
+		})
+	}
+}
+
+// execDB records the statements run with Exec.
+type execDB struct {
+	statements []string
+	args       [][]any
+}
+
+func (db *execDB) Exec(_ context.Context, statement string, args ...any) (pgconn.CommandTag, error) {
+	db.statements = append(db.statements, statement)
+	db.args = append(db.args, args)
+
+	return pgconn.CommandTag{}, nil
+}
+
+func (db *execDB) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	return nil, pgx.ErrNoRows
+}
+
+func (db *execDB) QueryRow(context.Context, string, ...any) pgx.Row {
+	return nil
+}
+
+func TestCacheResultClearsOtherSQL(t *testing.T) {
+	db := &execDB{}
+	r := &DynamicQueriesRouter{Postgres: postgres.New(db), CacheTTL: time.Minute}
+	id := uuid.New()
+
+	r.cacheResult(context.Background(), id, "edited", "key", system.DynamicQueryResult{}, 0)
+
+	if len(db.statements) != 2 {
+		t.Fatalf("cacheResult() ran %d statements, want 2", len(db.statements))
+	}
+
+	if !strings.Contains(db.statements[0], "-- name: UpsertDynamicQueryResultCache") || db.args[0][1] != "edited" {
+		t.Errorf("cacheResult() first ran %q with %v, want the result stored under the new SQL hash", db.statements[0], db.args[0])
+	}
+
+	if !strings.Contains(db.statements[1], "sql_hash <> $2") || db.args[1][0] != id || db.args[1][1] != "edited" {
+		t.Errorf("cacheResult() then ran %q with %v, want the results for other SQL deleted", db.statements[1], db.args[1])
+	}
+}
@@ -0,0 +1,635 @@
+package dynamicQueries
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxDynamicQueryTags caps how many tags a dynamic query may have.
+const maxDynamicQueryTags = 20
+
+// normalizeTags trims and lowercases tags and drops empty and duplicate ones,
+// returning them sorted.
+func normalizeTags(tags []string) []string {
+	normalized := []string{}
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+
+		if tag == "" || slices.Contains(normalized, tag) {
+			continue
+		}
+
+		normalized = append(normalized, tag)
+	}
+
+	slices.Sort(normalized)
+
+	return normalized
+}
+
+// dynamicQueryListFilters are the filters the dynamic query list accepts in
+// its query string.
+type dynamicQueryListFilters struct {
+	FolderID       pgtype.UUID
+	Tag            pgtype.Text
+	Status         postgres.NullDynamicQueryStatus
+	OwnerID        pgtype.UUID
+	FavouritesOnly bool
+	Sort           string
+}
+
+// dynamicQueryFilters parses the list filters from the query string. It
+// responds with a 400 and reports false when one of them is invalid.
+func dynamicQueryFilters(c *fiber.Ctx) (dynamicQueryListFilters, bool, error) {
+	filters := dynamicQueryListFilters{
+		FavouritesOnly: c.QueryBool("favourites"),
+		Sort:           c.Query("sort", "name"),
+	}
+
+	invalid := func(details string) (dynamicQueryListFilters, bool, error) {
+		log.Warnf("⚠️ Invalid dynamic query filter: %s", details)
+
+		return filters, false, c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+			"error":   constants.BadRequestError,
+			"details": details,
+		})
+	}
+
+	if folder := c.Query("folder"); folder != "" {
+		folderID, err := uuid.Parse(folder)
+
+		if err != nil {
+			return invalid("folder must be a folder ID.")
+		}
+
+		filters.FolderID = pgtype.UUID{Bytes: folderID, Valid: true}
+	}
+
+	if owner := c.Query("owner"); owner != "" {
+		ownerID, err := uuid.Parse(owner)
+
+		if err != nil {
+			return invalid("owner must be a user ID.")
+		}
+
+		filters.OwnerID = pgtype.UUID{Bytes: ownerID, Valid: true}
+	}
+
+	if tag := strings.ToLower(strings.TrimSpace(c.Query("tag"))); tag != "" {
+		filters.Tag = pgtype.Text{String: tag, Valid: true}
+	}
+
+	if status := postgres.DynamicQueryStatus(c.Query("status")); status != "" {
+		switch status {
+		case postgres.DynamicQueryStatusComplete, postgres.DynamicQueryStatusInProgress, postgres.DynamicQueryStatusError:
+		default:
+			return invalid("status must be complete, in_progress or error.")
+		}
+
+		filters.Status = postgres.NullDynamicQueryStatus{DynamicQueryStatus: status, Valid: true}
+	}
+
+	switch filters.Sort {
+	case "name", "recent", "updated":
+	default:
+		return invalid("sort must be name, recent or updated.")
+	}
+
+	return filters, true, nil
+}
+
+// recordDynamicQueryRun marks dynamicQueryID as just run by user for their
+// recently run list. Failing to record it does not fail the run.
+func (r *DynamicQueriesRouter) recordDynamicQueryRun(ctx context.Context, user postgres.User, dynamicQueryID uuid.UUID) {
+	if err := r.Postgres.RecordDynamicQueryRun(ctx, postgres.RecordDynamicQueryRunParams{
+		UserID:         user.ID,
+		DynamicQueryID: dynamicQueryID,
+	}); err != nil {
+		log.Errorf("🔥 Error recording dynamic query run: %s", err.Error())
+	}
+}
+
+func (r *DynamicQueriesRouter) GetDynamicQueryTagsRoute() system.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.SuccessResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query tags retrieved successfully.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"message": constants.Success,
+						"details": constants.SuccessDetails,
+						"data":    []string{},
+					},
+					Schema: schemas.SuccessResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Unauthorized.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.UnauthorizedError,
+						"details": constants.UnauthorizedErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Internal Server Error.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	return system.Route{
+		OpenAPIMetadata: system.OpenAPIMetadata{
+			Summary:     "Get Dynamic Query Tags",
+			Description: "Endpoint to list the tags used on the dynamic queries the current user can see.",
+			Tags:        []string{"Dynamic Queries"},
+			Parameters:  nil,
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: system.GetMethod,
+		Path:   "/dynamic-queries/tags",
+		Middlewares: []fiber.Handler{
+			r.Middleware.Authorized(),
+			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(postgres.User)
+
+			tags, err := r.Postgres.GetDynamicQueryTags(c.Context(), postgres.GetDynamicQueryTagsParams{
+				UserID: currentUser.ID,
+				Role:   currentUser.Role,
+			})
+
+			if err != nil {
+				log.Errorf("🔥 Error retrieving dynamic query tags: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			if tags == nil {
+				tags = []string{}
+			}
+
+			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
+				"message": constants.Success,
+				"details": constants.SuccessDetails,
+				"data":    tags,
+			})
+		},
+	}
+}
+
+func (r *DynamicQueriesRouter) GetRecentDynamicQueriesRoute() system.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.SuccessResponseSchema.Value,
+			).
+			WithDescription("Recently run Dynamic Queries retrieved successfully.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"message": constants.Success,
+						"details": constants.SuccessDetails,
+						"data":    schemas.RecentDynamicQueryArraySchema.Value,
+					},
+					Schema: schemas.SuccessResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Unauthorized.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.UnauthorizedError,
+						"details": constants.UnauthorizedErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Internal Server Error.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	return system.Route{
+		OpenAPIMetadata: system.OpenAPIMetadata{
+			Summary:     "Get Recent Dynamic Queries",
+			Description: "Endpoint to list the dynamic queries the current user ran most recently, by fetching or exporting their results, newest first.",
+			Tags:        []string{"Dynamic Queries"},
+			Parameters:  nil,
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: system.GetMethod,
+		Path:   "/dynamic-queries/recent",
+		Middlewares: []fiber.Handler{
+			r.Middleware.Authorized(),
+			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(postgres.User)
+
+			dynamicQueries, err := r.Postgres.GetRecentDynamicQueries(c.Context(), postgres.GetRecentDynamicQueriesParams{
+				Limit:  10,
+				UserID: currentUser.ID,
+				Role:   currentUser.Role,
+			})
+
+			if err != nil {
+				log.Errorf("🔥 Error retrieving recent dynamic queries: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
+				"message": constants.Success,
+				"details": constants.SuccessDetails,
+				"data":    dynamicQueries,
+			})
+		},
+	}
+}
+
+func (r *DynamicQueriesRouter) FavouriteDynamicQueryRoute() system.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.SuccessResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query favourites updated successfully.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"message": constants.Success,
+						"details": constants.SuccessDetails,
+					},
+					Schema: schemas.SuccessResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Bad Request.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.BadRequestError,
+						"details": constants.BadRequestErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Unauthorized.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.UnauthorizedError,
+						"details": constants.UnauthorizedErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query not found.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.NotFoundError,
+						"details": constants.NotFoundErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Internal Server Error.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	parameters := []*openapi3.ParameterRef{
+		{
+			Value: &openapi3.Parameter{
+				Name:     "id",
+				In:       "path",
+				Required: true,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{"string"},
+					},
+				},
+			},
+		},
+	}
+
+	return system.Route{
+		OpenAPIMetadata: system.OpenAPIMetadata{
+			Summary:     "Favourite Dynamic Query",
+			Description: "Endpoint to add a dynamic query to the current user's favourites.",
+			Tags:        []string{"Dynamic Queries"},
+			Parameters:  parameters,
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: system.PutMethod,
+		Path:   "/dynamic-queries/{id}/favourite",
+		Middlewares: []fiber.Handler{
+			r.Middleware.Authorized(),
+			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			id, err := uuid.Parse(c.Params("id"))
+
+			if err != nil {
+				log.Errorf("🔥 Invalid UUID format: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": constants.BadRequestErrorDetails,
+				})
+			}
+
+			dynamicQuery, ok, err := r.authorizedDynamicQuery(c, id, viewAccess)
+
+			if !ok {
+				return err
+			}
+
+			err = r.Postgres.CreateDynamicQueryFavourite(c.Context(), postgres.CreateDynamicQueryFavouriteParams{
+				UserID:         c.Locals("user").(postgres.User).ID,
+				DynamicQueryID: dynamicQuery.ID,
+			})
+
+			if err != nil {
+				log.Errorf("🔥 Error favouriting dynamic query: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
+				"message": constants.Success,
+				"details": constants.SuccessDetails,
+			})
+		},
+	}
+}
+
+func (r *DynamicQueriesRouter) UnfavouriteDynamicQueryRoute() system.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.SuccessResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query favourites updated successfully.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"message": constants.Success,
+						"details": constants.SuccessDetails,
+					},
+					Schema: schemas.SuccessResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Bad Request.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.BadRequestError,
+						"details": constants.BadRequestErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Unauthorized.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.UnauthorizedError,
+						"details": constants.UnauthorizedErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query not found.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.NotFoundError,
+						"details": constants.NotFoundErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Internal Server Error.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	parameters := []*openapi3.ParameterRef{
+		{
+			Value: &openapi3.Parameter{
+				Name:     "id",
+				In:       "path",
+				Required: true,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{"string"},
+					},
+				},
+			},
+		},
+	}
+
+	return system.Route{
+		OpenAPIMetadata: system.OpenAPIMetadata{
+			Summary:     "Unfavourite Dynamic Query",
+			Description: "Endpoint to remove a dynamic query from the current user's favourites.",
+			Tags:        []string{"Dynamic Queries"},
+			Parameters:  parameters,
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: system.DeleteMethod,
+		Path:   "/dynamic-queries/{id}/favourite",
+		Middlewares: []fiber.Handler{
+			r.Middleware.Authorized(),
+			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			id, err := uuid.Parse(c.Params("id"))
+
+			if err != nil {
+				log.Errorf("🔥 Invalid UUID format: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": constants.BadRequestErrorDetails,
+				})
+			}
+
+			dynamicQuery, ok, err := r.authorizedDynamicQuery(c, id, viewAccess)
+
+			if !ok {
+				return err
+			}
+
+			err = r.Postgres.DeleteDynamicQueryFavourite(c.Context(), postgres.DeleteDynamicQueryFavouriteParams{
+				UserID:         c.Locals("user").(postgres.User).ID,
+				DynamicQueryID: dynamicQuery.ID,
+			})
+
+			if err != nil {
+				log.Errorf("🔥 Error unfavouriting dynamic query: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
+				"message": constants.Success,
+				"details": constants.SuccessDetails,
+			})
+		},
+	}
+}
@@ -0,0 +1,42 @@
+package dynamicQueries
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+const disconnectPollInterval = time.Second
+
+// requestContext returns a context for the work done on behalf of c that is
+// cancelled once the client disconnects, so that a Trino query nobody is
+// waiting for is killed. It must be cancelled before the handler returns.
+func requestContext(c *fiber.Ctx) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(c.Context())
+	conn := c.Context().Conn()
+	path := c.Path()
+
+	go func() {
+		ticker := time.NewTicker(disconnectPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if disconnected(conn) {
+					log.Warnf("⚠️ Client disconnected from %s, cancelling its work", path)
+
+					cancel()
+
+					return
+				}
+			}
+		}
+	}()
+
+	return ctx, cancel
+}
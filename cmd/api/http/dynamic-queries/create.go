@@ -1,6 +1,8 @@
 package dynamicQueries
 
 import (
+	"fmt"
+
 	"github.com/connor-davis/zingfibre-core/internal/constants"
 	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
 	"github.com/connor-davis/zingfibre-core/internal/models/system"
@@ -8,12 +10,19 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type CreateDynamicQueryRequest struct {
-	Name   string `json:"name"`
-	Prompt string `json:"prompt"`
+	Name       string                          `json:"name"`
+	Prompt     string                          `json:"prompt"`
+	Visibility postgres.DynamicQueryVisibility `json:"visibility"`
+	FolderID   *uuid.UUID                      `json:"folder_id"`
+	Tags       []string                        `json:"tags"`
+	// PromptTemplateID selects the prompt template the query is generated
+	// with. Nil uses the default prompt template.
+	PromptTemplateID *uuid.UUID `json:"prompt_template_id"`
 }
 
 func (r *DynamicQueriesRouter) CreateDynamicQueryRoute() system.Route {
@@ -119,7 +128,7 @@ func (r *DynamicQueriesRouter) CreateDynamicQueryRoute() system.Route {
 		Path:   "/dynamic-queries",
 		Middlewares: []fiber.Handler{
 			r.Middleware.Authorized(),
-			r.Middleware.HasRole(postgres.RoleTypeAdmin),
+			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
 		},
 		Handler: func(c *fiber.Ctx) error {
 			var createDynamicQueryRequest CreateDynamicQueryRequest
@@ -133,14 +142,56 @@ func (r *DynamicQueriesRouter) CreateDynamicQueryRoute() system.Route {
 				})
 			}
 
+			if createDynamicQueryRequest.Visibility == "" {
+				createDynamicQueryRequest.Visibility = postgres.DynamicQueryVisibilityPrivate
+			}
+
+			if !validVisibility(createDynamicQueryRequest.Visibility) {
+				log.Warnf("⚠️ Invalid dynamic query visibility %s", createDynamicQueryRequest.Visibility)
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": "visibility must be private, shared or public.",
+				})
+			}
+
+			folderID, ok, err := r.dynamicQueryFolderID(c, createDynamicQueryRequest.FolderID)
+
+			if !ok {
+				return err
+			}
+
+			promptTemplateID, ok, err := r.dynamicQueryPromptTemplateID(c, createDynamicQueryRequest.PromptTemplateID)
+
+			if !ok {
+				return err
+			}
+
+			tags := normalizeTags(createDynamicQueryRequest.Tags)
+
+			if len(tags) > maxDynamicQueryTags {
+				log.Warnf("⚠️ Dynamic Query has %d tags", len(tags))
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": fmt.Sprintf("A dynamic query may have at most %d tags.", maxDynamicQueryTags),
+				})
+			}
+
 			dynamicQuery, err := r.Postgres.CreateDynamicQuery(
 				c.Context(),
 				postgres.CreateDynamicQueryParams{
-					Name:       createDynamicQueryRequest.Name,
-					Query:      pgtype.Text{String: "", Valid: false},
-					ResponseID: pgtype.Text{String: "", Valid: false},
-					Status:     postgres.DynamicQueryStatusInProgress,
-					Prompt:     createDynamicQueryRequest.Prompt,
+					Name:             createDynamicQueryRequest.Name,
+					Query:            pgtype.Text{String: "", Valid: false},
+					ResponseID:       pgtype.Text{String: "", Valid: false},
+					Status:           postgres.DynamicQueryStatusInProgress,
+					Prompt:           createDynamicQueryRequest.Prompt,
+					Parameters:       system.DynamicQueryParameters{},
+					CreatedBy:        currentUserID(c),
+					Visibility:       createDynamicQueryRequest.Visibility,
+					FolderID:         folderID,
+					Tags:             tags,
+					PromptTemplateID: promptTemplateID,
 				},
 			)
 
@@ -153,6 +204,15 @@ func (r *DynamicQueriesRouter) CreateDynamicQueryRoute() system.Route {
 				})
 			}
 
+			if err := recordDynamicQueryVersion(c.Context(), r.Postgres, dynamicQuery, currentUserID(c)); err != nil {
+				log.Errorf("🔥 Error recording dynamic query version: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
 			return c.Status(fiber.StatusCreated).JSON(&fiber.Map{
 				"message": constants.Success,
 				"details": constants.SuccessDetails,
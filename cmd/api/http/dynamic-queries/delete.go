@@ -112,7 +112,7 @@ func (r *DynamicQueriesRouter) DeleteDynamicQueryRoute() system.Route {
 		Path:   "/dynamic-queries/{id}",
 		Middlewares: []fiber.Handler{
 			r.Middleware.Authorized(),
-			r.Middleware.HasRole(postgres.RoleTypeAdmin),
+			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
 		},
 		Handler: func(c *fiber.Ctx) error {
 			id, err := uuid.Parse(c.Params("id"))
@@ -126,7 +126,7 @@ func (r *DynamicQueriesRouter) DeleteDynamicQueryRoute() system.Route {
 				})
 			}
 
-			_, err = r.Postgres.GetDynamicQuery(c.Context(), id)
+			dynamicQuery, err := r.Postgres.GetDynamicQuery(c.Context(), id)
 
 			if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
 				log.Errorf("🔥 Error retrieving dynamic query: %s", err.Error())
@@ -146,6 +146,10 @@ func (r *DynamicQueriesRouter) DeleteDynamicQueryRoute() system.Route {
 				})
 			}
 
+			if ok, err := r.authorize(c, dynamicQuery, ownerAccess); !ok {
+				return err
+			}
+
 			_, err = r.Postgres.DeleteDynamicQuery(c.Context(), id)
 
 			if err != nil {
@@ -0,0 +1,40 @@
+//go:build linux || darwin
+
+package dynamicQueries
+
+import (
+	"errors"
+	"net"
+	"syscall"
+)
+
+// disconnected peeks at conn without consuming anything and reports whether
+// the client has closed it.
+func disconnected(conn net.Conn) bool {
+	syscallConn, ok := conn.(syscall.Conn)
+
+	if !ok {
+		return false
+	}
+
+	rawConn, err := syscallConn.SyscallConn()
+
+	if err != nil {
+		return false
+	}
+
+	closed := false
+	buffer := make([]byte, 1)
+
+	if err := rawConn.Read(func(fd uintptr) bool {
+		n, _, err := syscall.Recvfrom(int(fd), buffer, syscall.MSG_PEEK|syscall.MSG_DONTWAIT)
+
+		closed = (n == 0 && err == nil) || errors.Is(err, syscall.ECONNRESET)
+
+		return true
+	}); err != nil {
+		return true
+	}
+
+	return closed
+}
@@ -0,0 +1,13 @@
+//go:build !linux && !darwin
+
+package dynamicQueries
+
+import (
+	"net"
+)
+
+// disconnected cannot tell on this platform, so work carries on until it ends
+// or times out.
+func disconnected(conn net.Conn) bool {
+	return false
+}
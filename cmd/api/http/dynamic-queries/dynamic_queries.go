@@ -2,58 +2,100 @@ package dynamicQueries
 
 import (
 	"database/sql"
+	"time"
 
 	"github.com/connor-davis/zingfibre-core/cmd/api/http/middleware"
-	"github.com/connor-davis/zingfibre-core/common"
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
 	"github.com/connor-davis/zingfibre-core/internal/models/system"
 	"github.com/connor-davis/zingfibre-core/internal/mysql/radius"
 	"github.com/connor-davis/zingfibre-core/internal/mysql/zing"
 	"github.com/connor-davis/zingfibre-core/internal/postgres"
 	"github.com/gofiber/fiber/v2/middleware/session"
-	"github.com/openai/openai-go/v3"
-	"github.com/openai/openai-go/v3/option"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type DynamicQueriesRouter struct {
 	Postgres   *postgres.Queries
+	Pool       *pgxpool.Pool
 	Zing       *zing.Queries
 	Radius     *radius.Queries
 	Middleware *middleware.Middleware
 	Sessions   *session.Store
-	OpenAI     openai.Client
 	Trino      *sql.DB
+	Policy     trino.Policy
+	CacheTTL   time.Duration
+	Executions *trino.Executions
 }
 
 func NewDynamicQueriesRouter(
 	postgres *postgres.Queries,
+	pool *pgxpool.Pool,
 	zing *zing.Queries,
 	radius *radius.Queries,
 	middleware *middleware.Middleware,
 	sessions *session.Store,
-	trino *sql.DB,
+	trinoDb *sql.DB,
+	policy trino.Policy,
+	cacheTTL time.Duration,
+	executions *trino.Executions,
 ) *DynamicQueriesRouter {
-	openai := openai.NewClient(option.WithAPIKey(common.EnvString("OPENAI_API_KEY", "")))
-
 	return &DynamicQueriesRouter{
 		Postgres:   postgres,
+		Pool:       pool,
 		Zing:       zing,
 		Radius:     radius,
 		Middleware: middleware,
 		Sessions:   sessions,
-		OpenAI:     openai,
-		Trino:      trino,
+		Trino:      trinoDb,
+		Policy:     policy,
+		CacheTTL:   cacheTTL,
+		Executions: executions,
 	}
 }
 
 func (r *DynamicQueriesRouter) RegisterRoutes() []system.Route {
 	return []system.Route{
 		r.GetDynamicQueriesRoute(),
+		r.GetRecentDynamicQueriesRoute(),
+		r.GetDynamicQueryTagsRoute(),
+		r.GetDynamicQueryFoldersRoute(),
+		r.CreateDynamicQueryFolderRoute(),
+		r.UpdateDynamicQueryFolderRoute(),
+		r.DeleteDynamicQueryFolderRoute(),
+		r.GetDynamicQueryExecutionsRoute(),
+		r.KillDynamicQueryExecutionRoute(),
 		r.GetDynamicQueryResultsRoute(),
+		r.RefreshDynamicQueryResultsRoute(),
 		r.GetDynamicQueryExportRoute(),
+		r.GetDynamicQueryVersionsRoute(),
+		r.GetDynamicQueryVersionDiffRoute(),
+		r.RestoreDynamicQueryVersionRoute(),
 		r.GenerateDynamicQueryRoute(),
+		r.CancelDynamicQueryGenerationRoute(),
+		r.GetDynamicQueryJobRoute(),
+		r.ExplainDynamicQueryRoute(),
+		r.DryRunDynamicQuerySqlRoute(),
+		r.UpdateDynamicQuerySqlRoute(),
+		r.UpdateDynamicQueryPromptTemplateRoute(),
+		r.GetDynamicQueryMessagesRoute(),
+		r.CreateDynamicQueryMessageRoute(),
+		r.GetDynamicQueryMessageEventsRoute(),
+		r.AcceptDynamicQueryMessageRoute(),
+		r.DiscardDynamicQueryMessageRoute(),
+		r.GetDynamicQueryRunsRoute(),
+		r.GetDynamicQueryRunRoute(),
+		r.GetDynamicQueryScheduleRunsRoute(),
+		r.GetDynamicQueryScheduleRoute(),
+		r.UpdateDynamicQueryScheduleRoute(),
+		r.DeleteDynamicQueryScheduleRoute(),
 		r.GetDynamicQueryRoute(),
 		r.CreateDynamicQueryRoute(),
 		r.UpdateDynamicQueryRoute(),
 		r.DeleteDynamicQueryRoute(),
+		r.GetDynamicQuerySharesRoute(),
+		r.CreateDynamicQueryShareRoute(),
+		r.DeleteDynamicQueryShareRoute(),
+		r.FavouriteDynamicQueryRoute(),
+		r.UnfavouriteDynamicQueryRoute(),
 	}
 }
@@ -0,0 +1,69 @@
+package dynamicQueries
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+)
+
+// executeDynamicQuery runs a saved query with its parameters bound and wrapped
+// with the given options, returning the requested page of results along with
+// the total number of rows that matched the filters. The total is only counted
+// when paging. It runs as an execution limited by the role of user.
+func (r *DynamicQueriesRouter) executeDynamicQuery(ctx context.Context, user postgres.User, dynamicQuery postgres.DynamicQuery, values map[string]string, options trino.ResultOptions) (system.DynamicQueryResult, int64, error) {
+	if err := r.validatePOPParameters(ctx, dynamicQuery.Parameters, values); err != nil {
+		return system.DynamicQueryResult{}, 0, err
+	}
+
+	ctx, execution := r.Executions.Start(ctx, trino.ExecutionInfo{
+		Description: fmt.Sprintf("Dynamic query %s", dynamicQuery.Name),
+		User:        user.Email,
+		Role:        string(user.Role),
+		Query:       dynamicQuery.Query.String,
+	})
+
+	result, total, err := trino.Execute(ctx, r.Trino, r.Policy, dynamicQuery.Query.String, dynamicQuery.Parameters, values, options)
+
+	return result, total, execution.Finish(err)
+}
+
+// validatePOPParameters checks that every POP parameter value names a known
+// point of presence.
+func (r *DynamicQueriesRouter) validatePOPParameters(ctx context.Context, parameters system.DynamicQueryParameters, values map[string]string) error {
+	pops := []string{}
+
+	for _, parameter := range parameters {
+		if parameter.Type != system.POPParameter {
+			continue
+		}
+
+		value := strings.TrimSpace(values[parameter.Name])
+
+		if value == "" {
+			continue
+		}
+
+		if len(pops) == 0 {
+			existingPOPs, err := r.Zing.GetPOPs(ctx)
+
+			if err != nil {
+				return err
+			}
+
+			for _, pop := range existingPOPs {
+				pops = append(pops, pop.String)
+			}
+		}
+
+		if !slices.Contains(pops, value) {
+			return fmt.Errorf("%w: %s: %q is not a known POP", trino.ErrInvalidParameter, parameter.Name, value)
+		}
+	}
+
+	return nil
+}
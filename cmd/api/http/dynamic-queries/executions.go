@@ -0,0 +1,253 @@
+package dynamicQueries
+
+import (
+	"errors"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+func (r *DynamicQueriesRouter) GetDynamicQueryExecutionsRoute() system.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.SuccessResponseSchema.Value,
+			).
+			WithDescription("Trino executions retrieved successfully.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"message": constants.Success,
+						"details": constants.SuccessDetails,
+						"data":    schemas.TrinoExecutionArraySchema.Value,
+					},
+					Schema: schemas.SuccessResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Unauthorized.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.UnauthorizedError,
+						"details": constants.UnauthorizedErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Internal Server Error.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	return system.Route{
+		OpenAPIMetadata: system.OpenAPIMetadata{
+			Summary:     "Get Dynamic Query Executions",
+			Description: "Endpoint to list the Trino executions running in this API instance, such as dynamic query results, exports, schedules and MCP tool calls, along with the Trino queries each one is running.",
+			Tags:        []string{"Dynamic Queries"},
+			Parameters:  nil,
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: system.GetMethod,
+		Path:   "/dynamic-queries/executions",
+		Middlewares: []fiber.Handler{
+			r.Middleware.Authorized(),
+			r.Middleware.HasRole(postgres.RoleTypeAdmin),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
+				"message": constants.Success,
+				"details": constants.SuccessDetails,
+				"data":    r.Executions.List(c.Context()),
+			})
+		},
+	}
+}
+
+func (r *DynamicQueriesRouter) KillDynamicQueryExecutionRoute() system.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.SuccessResponseSchema.Value,
+			).
+			WithDescription("Trino execution killed successfully.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"message": constants.Success,
+						"details": constants.SuccessDetails,
+					},
+					Schema: schemas.SuccessResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Bad Request.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.BadRequestError,
+						"details": constants.BadRequestErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Unauthorized.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.UnauthorizedError,
+						"details": constants.UnauthorizedErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Trino execution not found.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.NotFoundError,
+						"details": constants.NotFoundErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Internal Server Error.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	parameters := []*openapi3.ParameterRef{
+		{
+			Value: &openapi3.Parameter{
+				Name:     "id",
+				In:       "path",
+				Required: true,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{"string"},
+					},
+				},
+			},
+		},
+	}
+
+	return system.Route{
+		OpenAPIMetadata: system.OpenAPIMetadata{
+			Summary:     "Kill Dynamic Query Execution",
+			Description: "Endpoint to kill a running Trino execution. Its context is cancelled, which stops the work waiting on it and kills its queries in Trino.",
+			Tags:        []string{"Dynamic Queries"},
+			Parameters:  parameters,
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: system.DeleteMethod,
+		Path:   "/dynamic-queries/executions/{id}",
+		Middlewares: []fiber.Handler{
+			r.Middleware.Authorized(),
+			r.Middleware.HasRole(postgres.RoleTypeAdmin),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			id, err := uuid.Parse(c.Params("id"))
+
+			if err != nil {
+				log.Errorf("🔥 Invalid UUID format: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": constants.BadRequestErrorDetails,
+				})
+			}
+
+			err = r.Executions.Kill(id)
+
+			if err != nil && errors.Is(err, trino.ErrExecutionNotFound) {
+				log.Warnf("⚠️ Trino execution with ID %s not found", id)
+
+				return c.Status(fiber.StatusNotFound).JSON(&fiber.Map{
+					"error":   constants.NotFoundError,
+					"details": constants.NotFoundErrorDetails,
+				})
+			}
+
+			if err != nil {
+				log.Errorf("🔥 Error killing Trino execution: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
+				"message": constants.Success,
+				"details": constants.SuccessDetails,
+			})
+		},
+	}
+}
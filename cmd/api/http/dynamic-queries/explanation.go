@@ -0,0 +1,189 @@
+package dynamicQueries
+
+import (
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+func (r *DynamicQueriesRouter) ExplainDynamicQueryRoute() system.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.SuccessResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query explanation queued successfully.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"message": constants.Success,
+						"details": constants.SuccessDetails,
+						"data":    schemas.DynamicQuerySchema.Value,
+					},
+					Schema: schemas.SuccessResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Bad Request.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.BadRequestError,
+						"details": constants.BadRequestErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Unauthorized.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.UnauthorizedError,
+						"details": constants.UnauthorizedErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query not found.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.NotFoundError,
+						"details": constants.NotFoundErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Internal Server Error.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	parameters := []*openapi3.ParameterRef{
+		{
+			Value: &openapi3.Parameter{
+				Name:     "id",
+				In:       "path",
+				Required: true,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{"string"},
+					},
+				},
+			},
+		},
+	}
+
+	return system.Route{
+		OpenAPIMetadata: system.OpenAPIMetadata{
+			Summary:     "Explain Dynamic Query",
+			Description: "Endpoint to explain the SQL of a dynamic query again, for example after it was written by hand. The explanation is cleared and regenerated in the background, the same way it is whenever the SQL changes.",
+			Tags:        []string{"Dynamic Queries"},
+			Parameters:  parameters,
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: system.PostMethod,
+		Path:   "/dynamic-queries/{id}/explanation",
+		Middlewares: []fiber.Handler{
+			r.Middleware.Authorized(),
+			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			id, err := uuid.Parse(c.Params("id"))
+
+			if err != nil {
+				log.Errorf("🔥 Invalid UUID format: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": constants.BadRequestErrorDetails,
+				})
+			}
+
+			dynamicQuery, ok, err := r.authorizedDynamicQuery(c, id, runAccess)
+
+			if !ok {
+				return err
+			}
+
+			if !dynamicQuery.Query.Valid {
+				log.Warnf("⚠️ Dynamic Query with ID %s has no SQL to explain", id)
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": "Generate the dynamic query before explaining it.",
+				})
+			}
+
+			explainedDynamicQuery, err := r.Postgres.ResetDynamicQueryExplanation(c.Context(), dynamicQuery.ID)
+
+			if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+				log.Errorf("🔥 Error resetting dynamic query explanation: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+				log.Warnf("⚠️ Dynamic Query with ID %s not found", id)
+
+				return c.Status(fiber.StatusNotFound).JSON(&fiber.Map{
+					"error":   constants.NotFoundError,
+					"details": constants.NotFoundErrorDetails,
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
+				"message": constants.Success,
+				"details": constants.SuccessDetails,
+				"data":    explainedDynamicQuery,
+			})
+		},
+	}
+}
@@ -1,17 +1,18 @@
 package dynamicQueries
 
 import (
-	"encoding/csv"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
 	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/masking"
 	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
 	"github.com/connor-davis/zingfibre-core/internal/models/system"
 	"github.com/connor-davis/zingfibre-core/internal/postgres"
 	"github.com/getkin/kin-openapi/openapi3"
-	"github.com/goccy/go-json"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
@@ -81,6 +82,40 @@ func (r *DynamicQueriesRouter) GetDynamicQueryExportRoute() system.Route {
 			}),
 	})
 
+	responses.Set("409", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("The query was cancelled, because it was killed or the client disconnected.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.ConflictError,
+						"details": "query cancelled: killed by an administrator",
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("504", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("The query ran past the timeout for your role.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.GatewayTimeoutError,
+						"details": constants.GatewayTimeoutErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
 	responses.Set("500", &openapi3.ResponseRef{
 		Value: openapi3.NewResponse().
 			WithJSONSchema(
@@ -98,6 +133,8 @@ func (r *DynamicQueriesRouter) GetDynamicQueryExportRoute() system.Route {
 			}),
 	})
 
+	responses.Value("200").Value.Headers = cacheHeaders
+
 	parameters := []*openapi3.ParameterRef{
 		{
 			Value: &openapi3.Parameter{
@@ -113,12 +150,49 @@ func (r *DynamicQueriesRouter) GetDynamicQueryExportRoute() system.Route {
 				},
 			},
 		},
+		{
+			Value: &openapi3.Parameter{
+				Name:        "sort",
+				In:          "query",
+				Required:    false,
+				Description: "Comma separated list of Column:asc or Column:desc",
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{
+							"string",
+						},
+					},
+				},
+			},
+		},
+		{
+			Value: &openapi3.Parameter{
+				Name:        "filter",
+				In:          "query",
+				Required:    false,
+				Description: "Column:value, repeat for each filtered column",
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{
+							"array",
+						},
+						Items: &openapi3.SchemaRef{
+							Value: &openapi3.Schema{
+								Type: &openapi3.Types{
+									"string",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	return system.Route{
 		OpenAPIMetadata: system.OpenAPIMetadata{
 			Summary:     "Get Dynamic Query Export",
-			Description: "Endpoint to retrieve a dynamic query export by ID",
+			Description: "Endpoint to retrieve a dynamic query export by ID. Values for the query parameters are passed as param.<name> query parameters, with date ranges given as start,end.",
 			Tags:        []string{"Dynamic Queries"},
 			Parameters:  parameters,
 			RequestBody: nil,
@@ -129,6 +203,7 @@ func (r *DynamicQueriesRouter) GetDynamicQueryExportRoute() system.Route {
 		Middlewares: []fiber.Handler{
 			r.Middleware.Authorized(),
 			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+			r.Middleware.Masked(),
 		},
 		Handler: func(c *fiber.Ctx) error {
 			id, err := uuid.Parse(c.Params("id"))
@@ -162,47 +237,59 @@ func (r *DynamicQueriesRouter) GetDynamicQueryExportRoute() system.Route {
 				})
 			}
 
-			var dynamicQueryResultString string
+			if ok, err := r.authorize(c, dynamicQuery, runAccess); !ok {
+				return err
+			}
+
+			options := parseResultOptions(c)
+			options.PageSize = 0
 
-			row := r.Trino.QueryRow(dynamicQuery.Query.String)
+			maskingRules := c.Locals("masking").(masking.Rules)
+			lineage := trino.ColumnLineage(dynamicQuery.Query.String)
 
-			if err := row.Scan(&dynamicQueryResultString); err != nil {
-				log.Errorf("🔥 Error scanning dynamic query results: %s", err.Error())
+			if column, use, ok := maskedOption(maskingRules, lineage, options); ok {
+				log.Warnf("⚠️ Refusing dynamic query results %s on masked column %s", use, column)
 
-				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
-					"error":   constants.InternalServerError,
-					"details": constants.InternalServerErrorDetails,
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": fmt.Sprintf("%s is masked and cannot be %s.", column, use),
 				})
 			}
 
-			var dynamicQueryResult system.DynamicQueryResult
+			ctx, cancel := requestContext(c)
+			defer cancel()
 
-			if err := json.Unmarshal([]byte(dynamicQueryResultString), &dynamicQueryResult); err != nil {
-				log.Errorf("🔥 Error unmarshaling dynamic query results: %s", err.Error())
+			cached, err := r.executeCachedDynamicQuery(ctx, c.Locals("user").(postgres.User), dynamicQuery, parseParameterValues(c), options, false)
 
-				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
-					"error":   constants.InternalServerError,
-					"details": constants.InternalServerErrorDetails,
+			if err != nil && (errors.Is(err, trino.ErrUnknownColumn) || errors.Is(err, trino.ErrInvalidParameter) || errors.Is(err, trino.ErrUnsafeQuery) || errors.Is(err, trino.ErrRowLimit) || errors.Is(err, trino.ErrByteLimit)) {
+				log.Warnf("⚠️ Invalid dynamic query export options: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": err.Error(),
 				})
 			}
 
-			now := time.Now()
-
-			disposition := fmt.Sprintf(`attachment; filename="%s_report_%s.csv"`, dynamicQuery.Name, now.Format(time.DateOnly))
-
-			c.Set(fiber.HeaderContentType, "text/csv")
-			c.Set(fiber.HeaderContentDisposition, disposition)
+			if err != nil && errors.Is(err, trino.ErrQueryTimeout) {
+				log.Warnf("⚠️ Dynamic query timed out: %s", err.Error())
 
-			writer := csv.NewWriter(c.Response().BodyWriter())
+				return c.Status(fiber.StatusGatewayTimeout).JSON(&fiber.Map{
+					"error":   constants.GatewayTimeoutError,
+					"details": constants.GatewayTimeoutErrorDetails,
+				})
+			}
 
-			header := []string{}
+			if err != nil && errors.Is(err, trino.ErrQueryCancelled) {
+				log.Warnf("⚠️ Dynamic query cancelled: %s", err.Error())
 
-			for _, column := range dynamicQueryResult.Columns {
-				header = append(header, column.Label)
+				return c.Status(fiber.StatusConflict).JSON(&fiber.Map{
+					"error":   constants.ConflictError,
+					"details": err.Error(),
+				})
 			}
 
-			if err := writer.Write(header); err != nil {
-				log.Errorf("🔥 Error writing CSV header: %s", err.Error())
+			if err != nil {
+				log.Errorf("🔥 Error running dynamic query: %s", err.Error())
 
 				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
 					"error":   constants.InternalServerError,
@@ -210,30 +297,20 @@ func (r *DynamicQueriesRouter) GetDynamicQueryExportRoute() system.Route {
 				})
 			}
 
-			for _, row := range dynamicQueryResult.Data {
-				record := []string{}
-
-				for _, column := range dynamicQueryResult.Columns {
-					if row[column.Name] == nil {
-						record = append(record, "")
+			r.recordDynamicQueryRun(c.Context(), c.Locals("user").(postgres.User), dynamicQuery.ID)
 
-						continue
-					}
+			maskingRules.MaskResult(&cached.Result, lineage)
 
-					record = append(record, fmt.Sprintf("%s", row[column.Name]))
-				}
-
-				if err := writer.Write(record); err != nil {
-					log.Errorf("🔥 Error writing CSV record: %s", err.Error())
+			now := time.Now()
 
-					continue
-				}
-			}
+			disposition := fmt.Sprintf(`attachment; filename="%s_report_%s.csv"`, dynamicQuery.Name, now.Format(time.DateOnly))
 
-			defer writer.Flush()
+			setCacheHeaders(c, cached)
+			c.Set(fiber.HeaderContentType, "text/csv")
+			c.Set(fiber.HeaderContentDisposition, disposition)
 
-			if err := writer.Error(); err != nil {
-				log.Errorf("🔥 Error flushing CSV writer: %s", err.Error())
+			if err := trino.WriteCSV(c.Response().BodyWriter(), cached.Result); err != nil {
+				log.Errorf("🔥 Error writing CSV: %s", err.Error())
 
 				return c.Status(fiber.StatusInternalServerError).SendString("Failed to generate CSV")
 			}
@@ -0,0 +1,751 @@
+package dynamicQueries
+
+import (
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type DynamicQueryFolderRequest struct {
+	Name     string     `json:"name"`
+	ParentID *uuid.UUID `json:"parent_id"`
+}
+
+func (r *DynamicQueriesRouter) GetDynamicQueryFoldersRoute() system.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.SuccessResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query folders retrieved successfully.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"message": constants.Success,
+						"details": constants.SuccessDetails,
+						"data":    schemas.DynamicQueryFolderArraySchema.Value,
+					},
+					Schema: schemas.SuccessResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Unauthorized.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.UnauthorizedError,
+						"details": constants.UnauthorizedErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Internal Server Error.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	return system.Route{
+		OpenAPIMetadata: system.OpenAPIMetadata{
+			Summary:     "Get Dynamic Query Folders",
+			Description: "Endpoint to list every dynamic query folder. Folders are returned flat with their ParentID so that the tree can be built by the client.",
+			Tags:        []string{"Dynamic Queries"},
+			Parameters:  nil,
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: system.GetMethod,
+		Path:   "/dynamic-queries/folders",
+		Middlewares: []fiber.Handler{
+			r.Middleware.Authorized(),
+			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			folders, err := r.Postgres.GetDynamicQueryFolders(c.Context())
+
+			if err != nil {
+				log.Errorf("🔥 Error retrieving dynamic query folders: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
+				"message": constants.Success,
+				"details": constants.SuccessDetails,
+				"data":    folders,
+			})
+		},
+	}
+}
+
+func (r *DynamicQueriesRouter) CreateDynamicQueryFolderRoute() system.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("201", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.SuccessResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query folder created successfully.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"message": constants.Success,
+						"details": constants.SuccessDetails,
+						"data":    schemas.DynamicQueryFolderSchema.Value,
+					},
+					Schema: schemas.SuccessResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Bad Request.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.BadRequestError,
+						"details": constants.BadRequestErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Unauthorized.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.UnauthorizedError,
+						"details": constants.UnauthorizedErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("409", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Conflict.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.ConflictError,
+						"details": constants.ConflictErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Internal Server Error.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	return system.Route{
+		OpenAPIMetadata: system.OpenAPIMetadata{
+			Summary:     "Create Dynamic Query Folder",
+			Description: "Endpoint to create a dynamic query folder, optionally inside another folder. Folder names are unique within their parent folder.",
+			Tags:        []string{"Dynamic Queries"},
+			Parameters:  nil,
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: openapi3.NewRequestBody().WithJSONSchema(schemas.CreateDynamicQueryFolderSchema.Value),
+			},
+			Responses: responses,
+		},
+		Method: system.PostMethod,
+		Path:   "/dynamic-queries/folders",
+		Middlewares: []fiber.Handler{
+			r.Middleware.Authorized(),
+			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			var dynamicQueryFolderRequest DynamicQueryFolderRequest
+
+			if err := c.BodyParser(&dynamicQueryFolderRequest); err != nil {
+				log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": constants.BadRequestErrorDetails,
+				})
+			}
+
+			dynamicQueryFolderRequest.Name = strings.TrimSpace(dynamicQueryFolderRequest.Name)
+
+			if dynamicQueryFolderRequest.Name == "" {
+				log.Warnf("⚠️ Dynamic Query folder name is required")
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": "name is required.",
+				})
+			}
+
+			parentID, ok, err := r.dynamicQueryFolderID(c, dynamicQueryFolderRequest.ParentID)
+
+			if !ok {
+				return err
+			}
+
+			_, err = r.Postgres.GetDynamicQueryFolderByName(c.Context(), postgres.GetDynamicQueryFolderByNameParams{
+				ParentID: parentID,
+				Name:     dynamicQueryFolderRequest.Name,
+			})
+
+			if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+				log.Errorf("🔥 Error checking if dynamic query folder exists: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			if err == nil {
+				log.Warnf("⚠️ Dynamic Query folder %s already exists", dynamicQueryFolderRequest.Name)
+
+				return c.Status(fiber.StatusConflict).JSON(&fiber.Map{
+					"error":   constants.ConflictError,
+					"details": constants.ConflictErrorDetails,
+				})
+			}
+
+			folder, err := r.Postgres.CreateDynamicQueryFolder(c.Context(), postgres.CreateDynamicQueryFolderParams{
+				ParentID:  parentID,
+				Name:      dynamicQueryFolderRequest.Name,
+				CreatedBy: currentUserID(c),
+			})
+
+			if err != nil {
+				log.Errorf("🔥 Error creating dynamic query folder: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			return c.Status(fiber.StatusCreated).JSON(&fiber.Map{
+				"message": constants.Success,
+				"details": constants.SuccessDetails,
+				"data":    folder,
+			})
+		},
+	}
+}
+
+func (r *DynamicQueriesRouter) UpdateDynamicQueryFolderRoute() system.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.SuccessResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query folder updated successfully.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"message": constants.Success,
+						"details": constants.SuccessDetails,
+						"data":    schemas.DynamicQueryFolderSchema.Value,
+					},
+					Schema: schemas.SuccessResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Bad Request.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.BadRequestError,
+						"details": constants.BadRequestErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Unauthorized.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.UnauthorizedError,
+						"details": constants.UnauthorizedErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query folder not found.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.NotFoundError,
+						"details": constants.NotFoundErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("409", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Conflict.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.ConflictError,
+						"details": constants.ConflictErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Internal Server Error.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	parameters := []*openapi3.ParameterRef{
+		{
+			Value: &openapi3.Parameter{
+				Name:     "id",
+				In:       "path",
+				Required: true,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{"string"},
+					},
+				},
+			},
+		},
+	}
+
+	return system.Route{
+		OpenAPIMetadata: system.OpenAPIMetadata{
+			Summary:     "Update Dynamic Query Folder",
+			Description: "Endpoint to rename a dynamic query folder or move it into another folder. A folder without a parent_id is moved to the top level, and a folder cannot be moved into itself or one of its own folders.",
+			Tags:        []string{"Dynamic Queries"},
+			Parameters:  parameters,
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: openapi3.NewRequestBody().WithJSONSchema(schemas.CreateDynamicQueryFolderSchema.Value),
+			},
+			Responses: responses,
+		},
+		Method: system.PutMethod,
+		Path:   "/dynamic-queries/folders/{id}",
+		Middlewares: []fiber.Handler{
+			r.Middleware.Authorized(),
+			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			id, err := uuid.Parse(c.Params("id"))
+
+			if err != nil {
+				log.Errorf("🔥 Invalid UUID format: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": constants.BadRequestErrorDetails,
+				})
+			}
+
+			var dynamicQueryFolderRequest DynamicQueryFolderRequest
+
+			if err := c.BodyParser(&dynamicQueryFolderRequest); err != nil {
+				log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": constants.BadRequestErrorDetails,
+				})
+			}
+
+			folder, err := r.Postgres.GetDynamicQueryFolder(c.Context(), id)
+
+			if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+				log.Errorf("🔥 Error retrieving dynamic query folder: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+				log.Warnf("⚠️ Dynamic Query folder with ID %s not found", id)
+
+				return c.Status(fiber.StatusNotFound).JSON(&fiber.Map{
+					"error":   constants.NotFoundError,
+					"details": constants.NotFoundErrorDetails,
+				})
+			}
+
+			dynamicQueryFolderRequest.Name = strings.TrimSpace(dynamicQueryFolderRequest.Name)
+
+			if dynamicQueryFolderRequest.Name == "" {
+				log.Warnf("⚠️ Dynamic Query folder name is required")
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": "name is required.",
+				})
+			}
+
+			parentID, ok, err := r.dynamicQueryFolderID(c, dynamicQueryFolderRequest.ParentID)
+
+			if !ok {
+				return err
+			}
+
+			if parentID.Valid {
+				descendant, err := r.Postgres.IsDynamicQueryFolderDescendant(c.Context(), postgres.IsDynamicQueryFolderDescendantParams{
+					AncestorID: folder.ID,
+					FolderID:   parentID.Bytes,
+				})
+
+				if err != nil {
+					log.Errorf("🔥 Error checking dynamic query folder ancestry: %s", err.Error())
+
+					return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					})
+				}
+
+				if descendant {
+					log.Warnf("⚠️ Dynamic Query folder %s cannot be moved into itself", folder.ID)
+
+					return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+						"error":   constants.BadRequestError,
+						"details": "A folder cannot be moved into itself or one of its own folders.",
+					})
+				}
+			}
+
+			existingFolder, err := r.Postgres.GetDynamicQueryFolderByName(c.Context(), postgres.GetDynamicQueryFolderByNameParams{
+				ParentID: parentID,
+				Name:     dynamicQueryFolderRequest.Name,
+			})
+
+			if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+				log.Errorf("🔥 Error checking if dynamic query folder exists: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			if err == nil && existingFolder.ID != folder.ID {
+				log.Warnf("⚠️ Dynamic Query folder %s already exists", dynamicQueryFolderRequest.Name)
+
+				return c.Status(fiber.StatusConflict).JSON(&fiber.Map{
+					"error":   constants.ConflictError,
+					"details": constants.ConflictErrorDetails,
+				})
+			}
+
+			updatedFolder, err := r.Postgres.UpdateDynamicQueryFolder(c.Context(), postgres.UpdateDynamicQueryFolderParams{
+				ParentID: parentID,
+				Name:     dynamicQueryFolderRequest.Name,
+				ID:       folder.ID,
+			})
+
+			if err != nil {
+				log.Errorf("🔥 Error updating dynamic query folder: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
+				"message": constants.Success,
+				"details": constants.SuccessDetails,
+				"data":    updatedFolder,
+			})
+		},
+	}
+}
+
+func (r *DynamicQueriesRouter) DeleteDynamicQueryFolderRoute() system.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.SuccessResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query folder deleted successfully.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"message": constants.Success,
+						"details": constants.SuccessDetails,
+					},
+					Schema: schemas.SuccessResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Bad Request.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.BadRequestError,
+						"details": constants.BadRequestErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Unauthorized.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.UnauthorizedError,
+						"details": constants.UnauthorizedErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query folder not found.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.NotFoundError,
+						"details": constants.NotFoundErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Internal Server Error.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	parameters := []*openapi3.ParameterRef{
+		{
+			Value: &openapi3.Parameter{
+				Name:     "id",
+				In:       "path",
+				Required: true,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{"string"},
+					},
+				},
+			},
+		},
+	}
+
+	return system.Route{
+		OpenAPIMetadata: system.OpenAPIMetadata{
+			Summary:     "Delete Dynamic Query Folder",
+			Description: "Endpoint to delete a dynamic query folder along with the folders inside it. The dynamic queries in them are kept and taken out of their folder.",
+			Tags:        []string{"Dynamic Queries"},
+			Parameters:  parameters,
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: system.DeleteMethod,
+		Path:   "/dynamic-queries/folders/{id}",
+		Middlewares: []fiber.Handler{
+			r.Middleware.Authorized(),
+			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			id, err := uuid.Parse(c.Params("id"))
+
+			if err != nil {
+				log.Errorf("🔥 Invalid UUID format: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": constants.BadRequestErrorDetails,
+				})
+			}
+
+			_, err = r.Postgres.DeleteDynamicQueryFolder(c.Context(), id)
+
+			if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+				log.Errorf("🔥 Error deleting dynamic query folder: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+				log.Warnf("⚠️ Dynamic Query folder with ID %s not found", id)
+
+				return c.Status(fiber.StatusNotFound).JSON(&fiber.Map{
+					"error":   constants.NotFoundError,
+					"details": constants.NotFoundErrorDetails,
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
+				"message": constants.Success,
+				"details": constants.SuccessDetails,
+			})
+		},
+	}
+}
+
+// dynamicQueryFolderID checks that the folder with id exists when id is set.
+// It responds with a 400 and reports false when it does not.
+func (r *DynamicQueriesRouter) dynamicQueryFolderID(c *fiber.Ctx, id *uuid.UUID) (pgtype.UUID, bool, error) {
+	if id == nil {
+		return pgtype.UUID{}, true, nil
+	}
+
+	_, err := r.Postgres.GetDynamicQueryFolder(c.Context(), *id)
+
+	if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+		log.Errorf("🔥 Error retrieving dynamic query folder: %s", err.Error())
+
+		return pgtype.UUID{}, false, c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+			"error":   constants.InternalServerError,
+			"details": constants.InternalServerErrorDetails,
+		})
+	}
+
+	if err != nil {
+		log.Warnf("⚠️ Dynamic Query folder with ID %s not found", *id)
+
+		return pgtype.UUID{}, false, c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+			"error":   constants.BadRequestError,
+			"details": "The folder does not exist.",
+		})
+	}
+
+	return pgtype.UUID{Bytes: *id, Valid: true}, true, nil
+}
@@ -4,45 +4,28 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/connor-davis/zingfibre-core/common"
+	"github.com/connor-davis/zingfibre-core/cmd/api/jobs"
 	"github.com/connor-davis/zingfibre-core/internal/constants"
 	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
 	"github.com/connor-davis/zingfibre-core/internal/models/system"
 	"github.com/connor-davis/zingfibre-core/internal/postgres"
 	"github.com/getkin/kin-openapi/openapi3"
-	"github.com/goccy/go-json"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/openai/openai-go/v3"
-	openaiResponses "github.com/openai/openai-go/v3/responses"
 	"github.com/valyala/fasthttp"
 )
 
-type GenerateDynamicQueryOutput struct {
-	SqlQuery       string `json:"sql_query" jsonschema_description:"The SQL query to be executed for the dynamic query."`
-	ThoughtProcess string `json:"thought_process" jsonschema_description:"Your thought process"`
-}
-
-var GenerateDynamicQueryOutputSchema = map[string]any{
-	"type": "object",
-	"properties": map[string]any{
-		"sql_query": map[string]any{
-			"type":                   "string",
-			"description":            "The SQL query to be executed for the dynamic query.",
-			"jsonschema_description": "The SQL query to be executed for the dynamic query.",
-		},
-		"thought_process": map[string]any{
-			"type":        "string",
-			"description": "Your thought process",
-		},
-	},
-	"required":             []string{"sql_query", "thought_process"},
-	"additionalProperties": false,
-}
+const (
+	jobEventsPollInterval = 500 * time.Millisecond
+	jobEventsKeepAlive    = 15 * time.Second
+	jobEventsGracePeriod  = 5 * time.Second
+)
 
 func (r *DynamicQueriesRouter) GenerateDynamicQueryRoute() system.Route {
 	responses := openapi3.NewResponses()
@@ -152,7 +135,7 @@ func (r *DynamicQueriesRouter) GenerateDynamicQueryRoute() system.Route {
 	return system.Route{
 		OpenAPIMetadata: system.OpenAPIMetadata{
 			Summary:     "Generate Dynamic Query",
-			Description: "Endpoint to generate a dynamic query by ID",
+			Description: "Endpoint to generate a dynamic query by ID. Generation runs as a background job and this endpoint streams its events as server-sent events. Requests with a Last-Event-ID header resume the latest job after that event instead of starting a new one.",
 			Tags:        []string{"Dynamic Queries"},
 			Parameters:  parameters,
 			RequestBody: nil,
@@ -196,186 +179,41 @@ func (r *DynamicQueriesRouter) GenerateDynamicQueryRoute() system.Route {
 				})
 			}
 
-			log.Infof("Generating dynamic query for Query ID: %s with prompt: %s", dynamicQuery.ID, dynamicQuery.Prompt)
-
-			rawSystemPrompt := `# TrinoDB SQL Query Developer — System Prompt
-
-## Role
-You are an expert TrinoDB SQL query developer.
-
----
-
-## ⛔ RULE ZERO — THIS OVERRIDES EVERYTHING ELSE YOU KNOW ABOUT SQL
-
-**DO NOT END THE QUERY WITH A SEMICOLON.**
-
-You have been trained on SQL that uses semicolons. Forget that here. The application layer that runs your query will hard-crash if it sees a semicolon. The last character of your ~sql_query~ output MUST be ~)~ or an identifier — never ~;~.
-
-This is not a style preference. A semicolon will break production. It does not matter that standard SQL uses them. It does not matter that Trino's CLI accepts them. **This application does not.**
-
-**Before you output your final JSON, read the last character of your query. If it is ~;~, delete it.**
-
----
-
-## Workflow & Tool Usage (STRICTLY ENFORCED)
-
-You have access to tools to explore the database. You are FORBIDDEN from guessing schema structures. You must follow this exact sequence:
-
-1. **Discovery:** Call ~list-catalogs~, ~list-schemas~, and ~list-tables~. Explore multiple catalogs to find all necessary tables.
-2. **Planning & Key Discovery (Chain of Thought):** Identify how tables connect (Primary/Foreign keys, Bridge tables).
-3. **Drafting the FULL Query (Chain of Thought):** Before calling ANY testing tools, you must write out the COMPLETE, final CSV aggregation query in your thought process. Before finalising, run through the **Pre-Flight Checklist** below.
-4. **Testing Phase (HARD STOP & FULL QUERY ONLY):**
-   - You MUST call the ~test-query~ tool.
-   - **ANTI-CHEAT RULE:** You are STRICTLY FORBIDDEN from testing partial, simplified, or intermediate queries (e.g., NEVER test a basic ~SELECT ... LIMIT 5~).
-   - The query you pass to ~test-query~ MUST be the exact, complete ~WITH ... SELECT ... ARRAY_JOIN~ CSV query you drafted in Step 3.
-   - You must WAIT for the system to return the execution result. If it fails, re-run the **Pre-Flight Checklist**, draft a corrected FULL query, and test again.
-5. **Final Output Phase:** ONLY AFTER receiving a successful result from ~test-query~, output your final JSON object.
-   - The ~sql_query~ value MUST be **character-for-character identical** to the query you passed to ~test-query~. Do NOT modify, reformat, or re-type the query after testing.
-   - **Read the last character of ~sql_query~. If it is ~;~, delete it before outputting.**
-
----
-
-## 🛑 PRE-FLIGHT CHECKLIST — Run before EVERY ~test-query~ call AND before final JSON output
-
-**[ ] FATAL CHECK 1 — Semicolon Scan**
-
-Read your query from end to beginning. Find every ~;~ character. Delete all of them.
-
-The ~test-query~ tool silently strips semicolons, so your test will PASS even with a semicolon present. This creates a false sense of safety. The production API does NOT strip them — it crashes with:
-> ~mismatched input ';'. Expecting: ',', '.', 'AS', ...~
-
-This is the exact failure pattern:
-- ✅ ~test-query~ passes (semicolon stripped silently)
-- ❌ Production API crashes (semicolon not stripped)
-
-The only safe behaviour is to never include a semicolon at all.
-
-**Correct final line:**
-~~~
-FROM csv_rows
-~~~
-**Wrong final line (WILL CRASH PRODUCTION):**
-~~~
-FROM csv_rows;
-~~~
-
-**[ ] FATAL CHECK 2 — No Raw Timestamp Usage**
-> Scan every column that holds a date or timestamp value.
-> Ask: "Am I passing this column directly into ~date_format()~, into a ~JOIN~ condition, or into an ~ORDER BY~ without casting?"
-> If YES to any of those → **replace it** using the safe patterns below.
-> Direct use of timestamp columns triggers: ~Invalid value of epochMicros for precision 0~.
-
----
+			if ok, err := r.authorize(c, dynamicQuery, editAccess); !ok {
+				return err
+			}
 
-## Syntax & Formatting Rules
+			lastEventID, err := strconv.ParseInt(c.Get("Last-Event-ID"), 10, 64)
 
-- **Target Dialect:** TrinoDB. Use cross-catalog joins when necessary (~catalog.schema.table~). Do not use double quotes around catalog/schema/table *names*.
-- **Column Identifiers:** Use double quotes around column names (e.g., ~"Column Name"~).
-- **Semicolons:** There are no semicolons in this query. Not at the end. Not anywhere. The Go Trino driver crashes on them.
+			if err != nil {
+				lastEventID = 0
+			}
 
----
+			job, err := r.Postgres.GetLatestDynamicQueryJob(c.Context(), dynamicQuery.ID)
 
-## ⚠️ Data Transformation Rules — CRITICAL EPOCHMICROS CRASH PREVENTION
+			if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+				log.Errorf("🔥 Error retrieving dynamic query job: %s", err.Error())
 
-Trino has a fatal bug when evaluating timestamp columns with sub-second precision. Any direct use of such a column in ~date_format()~, ~ORDER BY~, or a ~JOIN~ will produce:
-> ~Invalid value of epochMicros for precision 0: <large number>~
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
 
-You MUST apply the following safe alternatives **every single time** you touch a date/timestamp column.
+			hasJob := err == nil
 
-**Rule A — Safe Date Formatting (dd/mm/yyyy)**
-NEVER use ~date_format()~ on a timestamp column. ALWAYS cast to VARCHAR first:
-~~~sql
-CASE
-  WHEN db1."date_col" IS NULL THEN '-'
-  ELSE substr(CAST(db1."date_col" AS VARCHAR), 9, 2) || '/' ||
-       substr(CAST(db1."date_col" AS VARCHAR), 6, 2) || '/' ||
-       substr(CAST(db1."date_col" AS VARCHAR), 1, 4)
-END
-~~~
+			if !hasJob || (jobs.IsFinished(job.Status) && lastEventID == 0) {
+				job, err = r.enqueueDynamicQueryJob(c.Context(), dynamicQuery, currentUserID(c))
 
-**Rule B — Safe Window Function Ordering**
-NEVER order a ~ROW_NUMBER()~ or any window function directly by a timestamp column. ALWAYS wrap it:
-~~~sql
-ROW_NUMBER() OVER (
-  PARTITION BY "Shared_ID"
-  ORDER BY CAST("date_col" AS VARCHAR) DESC
-)
-~~~
-
-**Rule C — No Timestamp Self-Joins or Filter Comparisons**
-NEVER join or filter on a raw timestamp column. Cast both sides to VARCHAR before comparing.
-
-**Rule D — Boolean/Tinyint Formatting**
-Convert 1/0 flags using:
-~~~sql
-CASE WHEN col = 1 THEN 'yes' ELSE 'no' END
-~~~
-
-**Rule E — Null Handling**
-Coalesce ALL final CSV column values to ~'-'~:
-~~~sql
-COALESCE(CAST("column" AS VARCHAR), '-')
-~~~
-
----
-
-## Output Format Requirements
-
-- Output a JSON object with two keys: ~thought_process~ and ~sql_query~.
-- ~thought_process~ must explicitly state: "✅ Semicolon check: none found." and "✅ Timestamp check: all cast to VARCHAR."
-- ~sql_query~ must be identical to what was passed to ~test-query~. Do not retype it.
-
----
-
-## Required SQL Template
-
-The last line is ~FROM csv_rows~ — no semicolon, nothing after it.
-
-~~~sql
-WITH ranked_data AS (
-  SELECT
-    "Shared_ID",
-    "Product_ID",
-    ROW_NUMBER() OVER(
-      PARTITION BY "Shared_ID"
-      ORDER BY CAST("Date_Column" AS VARCHAR) DESC
-    ) AS rn
-  FROM catalog_two.schema_b.table_y
-),
-latest_data AS (
-  SELECT "Shared_ID", "Product_ID" FROM ranked_data WHERE rn = 1
-),
-data_cte AS (
-  SELECT
-    db1."String_Column",
-    CASE
-      WHEN db1."Date_Column" IS NULL THEN '-'
-      ELSE substr(CAST(db1."Date_Column" AS VARCHAR), 9, 2) || '/' ||
-           substr(CAST(db1."Date_Column" AS VARCHAR), 6, 2) || '/' ||
-           substr(CAST(db1."Date_Column" AS VARCHAR), 1, 4)
-    END AS "Formatted_Date",
-    CASE WHEN db1."Is_Active" = 1 THEN 'yes' ELSE 'no' END AS "Is_Active_Text",
-    db2."Product_ID"
-  FROM catalog_one.schema_a.table_x AS db1
-  LEFT JOIN latest_data AS db2 ON db1."Shared_ID" = db2."Shared_ID"
-),
-csv_rows AS (
-  SELECT
-    format('%s,%s,%s,%s',
-      COALESCE(CAST("String_Column" AS VARCHAR), '-'),
-      COALESCE(CAST("Formatted_Date" AS VARCHAR), '-'),
-      COALESCE(CAST("Is_Active_Text" AS VARCHAR), '-'),
-      COALESCE(CAST("Product_ID" AS VARCHAR), '-')
-    ) AS row_line
-  FROM data_cte
-)
-SELECT
-  'String Column,Formatted Date,Is Active,Product ID' || chr(10) ||
-  COALESCE(ARRAY_JOIN(ARRAY_AGG(row_line), chr(10)), '') AS csv_output
-FROM csv_rows~~~
-`
+				if err != nil {
+					log.Errorf("🔥 Error queueing dynamic query job: %s", err.Error())
 
-			systemPrompt := strings.ReplaceAll(rawSystemPrompt, "~", "`")
+					return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					})
+				}
+			}
 
 			c.Set("Content-Type", "text/event-stream")
 			c.Set("Cache-Control", "no-cache")
@@ -383,147 +221,123 @@ FROM csv_rows~~~
 			c.Set("Transfer-Encoding", "chunked")
 
 			c.Status(fiber.StatusOK).Response().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
-				if _, _ = w.WriteString(": connected\n\n"); w.Flush() != nil {
-					return
-				}
+				r.streamDynamicQueryJob(w, job.ID, lastEventID)
+			}))
 
-				streamParams := openaiResponses.ResponseNewParams{
-					Model:        openai.ChatModelGPT5Mini,
-					Instructions: openai.String(systemPrompt),
-					Input: openaiResponses.ResponseNewParamsInputUnion{
-						OfString: openai.String(dynamicQuery.Prompt),
-					},
-					Text: openaiResponses.ResponseTextConfigParam{
-						Format: openaiResponses.ResponseFormatTextConfigUnionParam{
-							OfJSONSchema: &openaiResponses.ResponseFormatTextJSONSchemaConfigParam{
-								Name:        "create_dynamic_query_output",
-								Schema:      GenerateDynamicQueryOutputSchema,
-								Strict:      openai.Bool(true),
-								Description: openai.String("The output for create dynamic query"),
-							},
-						},
-					},
-					Tools: []openaiResponses.ToolUnionParam{
-						{
-							OfMcp: &openaiResponses.ToolMcpParam{
-								ServerLabel:       "zingfibre_mcp",
-								ServerDescription: openai.String("The ZingFibre MCP server that allows AI to interact with parts of the ZingFibre Reports Portal system."),
-								ServerURL:         openai.String(common.EnvString("MCP_BASE_URL", "http://localhost:6173/api/mcp")),
-								RequireApproval: openaiResponses.ToolMcpRequireApprovalUnionParam{
-									OfMcpToolApprovalFilter: &openaiResponses.ToolMcpRequireApprovalMcpToolApprovalFilterParam{
-										Never: openaiResponses.ToolMcpRequireApprovalMcpToolApprovalFilterNeverParam{
-											ToolNames: []string{
-												"list-catalogs",
-												"list-schemas",
-												"list-tables",
-												"test-query",
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				}
+			return nil
+		},
+	}
+}
 
-				if dynamicQuery.ResponseID.Valid {
-					streamParams.PreviousResponseID = openai.String(dynamicQuery.ResponseID.String)
-				}
+// enqueueDynamicQueryJob queues a generation job for dynamicQuery and marks it
+// as in progress. If another request queued one first, that job is returned.
+func (r *DynamicQueriesRouter) enqueueDynamicQueryJob(ctx context.Context, dynamicQuery postgres.DynamicQuery, createdBy pgtype.UUID) (postgres.DynamicQueryJob, error) {
+	job, err := r.Postgres.CreateDynamicQueryJob(ctx, postgres.CreateDynamicQueryJobParams{
+		DynamicQueryID: dynamicQuery.ID,
+		CreatedBy:      createdBy,
+		MessageID:      pgtype.UUID{},
+	})
 
-				stream := r.OpenAI.Responses.NewStreaming(context.Background(), streamParams)
+	if err != nil {
+		existingJob, existingErr := r.Postgres.GetLatestDynamicQueryJob(ctx, dynamicQuery.ID)
 
-				for stream.Next() {
-					current := stream.Current()
+		if existingErr != nil || jobs.IsFinished(existingJob.Status) {
+			return job, err
+		}
 
-					switch current.Type {
-					case "response.completed":
-						{
-							outputText := current.AsResponseCompleted().Response.OutputText()
+		return existingJob, nil
+	}
 
-							var output GenerateDynamicQueryOutput
+	if dynamicQuery.Status != postgres.DynamicQueryStatusInProgress {
+		if _, err := r.Postgres.SetDynamicQueryStatus(ctx, postgres.SetDynamicQueryStatusParams{
+			Status:     postgres.DynamicQueryStatusInProgress,
+			ResponseID: pgtype.Text{},
+			ID:         dynamicQuery.ID,
+		}); err != nil {
+			return job, err
+		}
+	}
 
-							if err := json.Unmarshal([]byte(outputText), &output); err != nil {
-								log.Infof("Error while unmarshaling output: %v. Closing http connection.\n", err)
+	return job, nil
+}
 
-								break
-							}
+// streamDynamicQueryJob relays the events of a job, starting after
+// lastEventID, until the job has finished or the client goes away. The job
+// itself keeps running if the client disconnects.
+func (r *DynamicQueriesRouter) streamDynamicQueryJob(w *bufio.Writer, jobID uuid.UUID, lastEventID int64) {
+	if _, _ = w.WriteString(": connected\n\n"); w.Flush() != nil {
+		return
+	}
 
-							if _, err := r.Postgres.UpdateDynamicQuery(context.Background(), postgres.UpdateDynamicQueryParams{
-								ID:         dynamicQuery.ID,
-								Name:       dynamicQuery.Name,
-								Query:      pgtype.Text{String: output.SqlQuery, Valid: true},
-								ResponseID: pgtype.Text{String: current.AsResponseCompleted().Response.ID, Valid: true},
-								Status:     postgres.DynamicQueryStatusComplete,
-								Prompt:     dynamicQuery.Prompt,
-							}); err != nil {
-								log.Infof("Error while updating dynamic query: %v. Closing http connection.\n", err)
+	ticker := time.NewTicker(jobEventsPollInterval)
 
-								break
-							}
+	defer ticker.Stop()
 
-							fmt.Fprintf(w, "event: response_completed\n")
-							fmt.Fprintf(w, "data: %s\n\n", outputText)
+	lastWrite := time.Now()
 
-							err = w.Flush()
+	var finishedAt time.Time
 
-							if err != nil {
-								log.Infof("Error while flushing: %v. Closing http connection.\n", err)
+	for range ticker.C {
+		events, err := r.Postgres.GetDynamicQueryJobEvents(context.Background(), postgres.GetDynamicQueryJobEventsParams{
+			JobID: jobID,
+			ID:    lastEventID,
+		})
 
-								continue
-							}
+		if err != nil {
+			log.Errorf("🔥 Error retrieving dynamic query job events: %s", err.Error())
 
-							continue
-						}
-					default:
-						{
-							part := current.Type
-							payload, err := json.Marshal(part)
+			return
+		}
 
-							if err != nil {
-								log.Infof("Error while marshaling payload: %v. Closing http connection.\n", err)
+		terminal := false
 
-								break
-							}
+		for _, event := range events {
+			fmt.Fprintf(w, "id: %d\n", event.ID)
+			fmt.Fprintf(w, "event: %s\n", event.Event)
+			fmt.Fprintf(w, "data: %s\n\n", event.Data)
 
-							fmt.Fprintf(w, "event: current_type\n")
-							fmt.Fprintf(w, "data: %s\n\n", payload)
+			lastEventID = event.ID
 
-							err = w.Flush()
+			switch event.Event {
+			case jobs.EventDone, jobs.EventError, jobs.EventCancelled:
+				terminal = true
+			}
+		}
 
-							if err != nil {
-								log.Infof("Error while flushing: %v. Closing http connection.\n", err)
+		if len(events) == 0 && time.Since(lastWrite) >= jobEventsKeepAlive {
+			_, _ = w.WriteString(": keep-alive\n\n")
+		}
 
-								continue
-							}
+		if len(events) > 0 || time.Since(lastWrite) >= jobEventsKeepAlive {
+			if err := w.Flush(); err != nil {
+				log.Infof("Client stopped listening to dynamic query job %s: %v", jobID, err)
 
-							continue
-						}
-					}
-				}
+				return
+			}
 
-				if stream.Err() != nil {
-					log.Errorf("Error during streaming: %v", stream.Err())
+			lastWrite = time.Now()
+		}
 
-					err = w.Flush()
+		if terminal {
+			return
+		}
 
-					if err != nil {
-						log.Infof("Error while flushing at the end: %v.\n", err)
-					}
-				} else {
-					log.Info("Dynamic query generation completed, closing connection.")
+		job, err := r.Postgres.GetDynamicQueryJob(context.Background(), jobID)
 
-					fmt.Fprintf(w, "event: done\n")
-					fmt.Fprintf(w, "data: \n\n")
+		if err != nil {
+			log.Errorf("🔥 Error retrieving dynamic query job: %s", err.Error())
 
-					err = w.Flush()
+			return
+		}
 
-					if err != nil {
-						log.Infof("Error while flushing at the end: %v.\n", err)
-					}
-				}
-			}))
+		// A finished job may still be writing its last events, so keep polling
+		// for a short while before giving up on them.
+		if jobs.IsFinished(job.Status) && finishedAt.IsZero() {
+			finishedAt = time.Now()
+		}
 
-			return nil
-		},
+		if !finishedAt.IsZero() && time.Since(finishedAt) >= jobEventsGracePeriod {
+			return
+		}
 	}
 }
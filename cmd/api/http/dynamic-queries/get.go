@@ -117,12 +117,102 @@ func (r *DynamicQueriesRouter) GetDynamicQueriesRoute() system.Route {
 				},
 			},
 		},
+		{
+			Value: &openapi3.Parameter{
+				Name:        "folder",
+				In:          "query",
+				Description: "Only list dynamic queries in this folder or the folders inside it.",
+				Required:    false,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{
+							"string",
+						},
+					},
+				},
+			},
+		},
+		{
+			Value: &openapi3.Parameter{
+				Name:        "tag",
+				In:          "query",
+				Description: "Only list dynamic queries with this tag.",
+				Required:    false,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{
+							"string",
+						},
+					},
+				},
+			},
+		},
+		{
+			Value: &openapi3.Parameter{
+				Name:        "status",
+				In:          "query",
+				Description: "Only list dynamic queries with this status.",
+				Required:    false,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{
+							"string",
+						},
+					},
+				},
+			},
+		},
+		{
+			Value: &openapi3.Parameter{
+				Name:        "owner",
+				In:          "query",
+				Description: "Only list dynamic queries created by this user.",
+				Required:    false,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{
+							"string",
+						},
+					},
+				},
+			},
+		},
+		{
+			Value: &openapi3.Parameter{
+				Name:        "favourites",
+				In:          "query",
+				Description: "Only list the current user's favourite dynamic queries.",
+				Required:    false,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{
+							"boolean",
+						},
+					},
+				},
+			},
+		},
+		{
+			Value: &openapi3.Parameter{
+				Name:        "sort",
+				In:          "query",
+				Description: "Sort by name, by when the current user last ran them (recent) or by when they were last updated (updated). Defaults to name.",
+				Required:    false,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{
+							"string",
+						},
+					},
+				},
+			},
+		},
 	}
 
 	return system.Route{
 		OpenAPIMetadata: system.OpenAPIMetadata{
 			Summary:     "Get Dynamic Queries",
-			Description: "Endpoint to retrieve a list of dynamic queries",
+			Description: "Endpoint to retrieve a list of the dynamic queries the current user can see. The search term is matched against their names and prompts using full-text search.",
 			Tags:        []string{"Dynamic Queries"},
 			Parameters:  parameters,
 			RequestBody: nil,
@@ -132,7 +222,7 @@ func (r *DynamicQueriesRouter) GetDynamicQueriesRoute() system.Route {
 		Path:   "/dynamic-queries",
 		Middlewares: []fiber.Handler{
 			r.Middleware.Authorized(),
-			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff),
+			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
 		},
 		Handler: func(c *fiber.Ctx) error {
 			page, err := strconv.Atoi(c.Query("page"))
@@ -141,7 +231,24 @@ func (r *DynamicQueriesRouter) GetDynamicQueriesRoute() system.Route {
 				page = 1
 			}
 
-			totalDynamicQueries, err := r.Postgres.GetTotalDynamicQueries(c.Context())
+			currentUser := c.Locals("user").(postgres.User)
+
+			filters, ok, err := dynamicQueryFilters(c)
+
+			if !ok {
+				return err
+			}
+
+			totalDynamicQueries, err := r.Postgres.GetTotalDynamicQueries(c.Context(), postgres.GetTotalDynamicQueriesParams{
+				SearchTerm:     c.Query("search"),
+				UserID:         currentUser.ID,
+				Role:           currentUser.Role,
+				FolderID:       filters.FolderID,
+				Tag:            filters.Tag,
+				Status:         filters.Status,
+				OwnerID:        filters.OwnerID,
+				FavouritesOnly: filters.FavouritesOnly,
+			})
 
 			if err != nil {
 				log.Errorf("🔥 Error retrieving total dynamic queries: %s", err.Error())
@@ -153,9 +260,17 @@ func (r *DynamicQueriesRouter) GetDynamicQueriesRoute() system.Route {
 			}
 
 			dynamicQueries, err := r.Postgres.GetDynamicQueries(c.Context(), postgres.GetDynamicQueriesParams{
-				Limit:      10, // Default limit
-				Offset:     (int32(page) - 1) * 10,
-				SearchTerm: c.Query("search"),
+				Limit:          10, // Default limit
+				Offset:         (int32(page) - 1) * 10,
+				SearchTerm:     c.Query("search"),
+				UserID:         currentUser.ID,
+				Role:           currentUser.Role,
+				FolderID:       filters.FolderID,
+				Tag:            filters.Tag,
+				Status:         filters.Status,
+				OwnerID:        filters.OwnerID,
+				FavouritesOnly: filters.FavouritesOnly,
+				Sort:           filters.Sort,
 			})
 
 			if err != nil {
@@ -287,7 +402,7 @@ func (r *DynamicQueriesRouter) GetDynamicQueryRoute() system.Route {
 	return system.Route{
 		OpenAPIMetadata: system.OpenAPIMetadata{
 			Summary:     "Get Dynamic Query",
-			Description: "Endpoint to retrieve a dynamic query by ID",
+			Description: "Endpoint to retrieve a dynamic query by ID, including the plain-English explanation of its SQL once it has been generated.",
 			Tags:        []string{"Dynamic Queries"},
 			Parameters:  parameters,
 			RequestBody: nil,
@@ -331,6 +446,10 @@ func (r *DynamicQueriesRouter) GetDynamicQueryRoute() system.Route {
 				})
 			}
 
+			if ok, err := r.authorize(c, dynamicQuery, viewAccess); !ok {
+				return err
+			}
+
 			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
 				"message": constants.Success,
 				"details": constants.SuccessDetails,
@@ -0,0 +1,438 @@
+package dynamicQueries
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/jobs"
+	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (r *DynamicQueriesRouter) GetDynamicQueryJobRoute() system.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.SuccessResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query job retrieved successfully.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"message": constants.Success,
+						"details": constants.SuccessDetails,
+					},
+					Schema: schemas.SuccessResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Bad Request.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.BadRequestError,
+						"details": constants.BadRequestErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Unauthorized.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.UnauthorizedError,
+						"details": constants.UnauthorizedErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query job not found.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.NotFoundError,
+						"details": constants.NotFoundErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Internal Server Error.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	parameters := []*openapi3.ParameterRef{
+		{
+			Value: &openapi3.Parameter{
+				Name:     "id",
+				In:       "path",
+				Required: true,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{
+							"string",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return system.Route{
+		OpenAPIMetadata: system.OpenAPIMetadata{
+			Summary:     "Get Dynamic Query Job",
+			Description: "Endpoint to retrieve the latest generation job of a dynamic query, including why it failed",
+			Tags:        []string{"Dynamic Queries"},
+			Parameters:  parameters,
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: system.GetMethod,
+		Path:   "/dynamic-queries/{id}/job",
+		Middlewares: []fiber.Handler{
+			r.Middleware.Authorized(),
+			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			id, err := uuid.Parse(c.Params("id"))
+
+			if err != nil {
+				log.Errorf("🔥 Invalid UUID format: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": constants.BadRequestErrorDetails,
+				})
+			}
+
+			if _, ok, err := r.authorizedDynamicQuery(c, id, viewAccess); !ok {
+				return err
+			}
+
+			job, err := r.Postgres.GetLatestDynamicQueryJob(c.Context(), id)
+
+			if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+				log.Errorf("🔥 Error retrieving dynamic query job: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+				log.Warnf("⚠️ No jobs found for Dynamic Query with ID %s", id)
+
+				return c.Status(fiber.StatusNotFound).JSON(&fiber.Map{
+					"error":   constants.NotFoundError,
+					"details": constants.NotFoundErrorDetails,
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
+				"message": constants.Success,
+				"details": constants.SuccessDetails,
+				"data":    job,
+			})
+		},
+	}
+}
+
+func (r *DynamicQueriesRouter) CancelDynamicQueryGenerationRoute() system.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.SuccessResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query generation cancelled successfully.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"message": constants.Success,
+						"details": constants.SuccessDetails,
+					},
+					Schema: schemas.SuccessResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Bad Request.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.BadRequestError,
+						"details": constants.BadRequestErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Unauthorized.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.UnauthorizedError,
+						"details": constants.UnauthorizedErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query job not found.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.NotFoundError,
+						"details": constants.NotFoundErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Internal Server Error.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	parameters := []*openapi3.ParameterRef{
+		{
+			Value: &openapi3.Parameter{
+				Name:     "id",
+				In:       "path",
+				Required: true,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{
+							"string",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return system.Route{
+		OpenAPIMetadata: system.OpenAPIMetadata{
+			Summary:     "Cancel Dynamic Query Generation",
+			Description: "Endpoint to cancel the queued or running generation job of a dynamic query",
+			Tags:        []string{"Dynamic Queries"},
+			Parameters:  parameters,
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: system.PostMethod,
+		Path:   "/dynamic-queries/{id}/generate/cancel",
+		Middlewares: []fiber.Handler{
+			r.Middleware.Authorized(),
+			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			id, err := uuid.Parse(c.Params("id"))
+
+			if err != nil {
+				log.Errorf("🔥 Invalid UUID format: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": constants.BadRequestErrorDetails,
+				})
+			}
+
+			dynamicQuery, err := r.Postgres.GetDynamicQuery(c.Context(), id)
+
+			if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+				log.Errorf("🔥 Error retrieving dynamic query: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+				log.Warnf("⚠️ Dynamic Query with ID %s not found", id)
+
+				return c.Status(fiber.StatusNotFound).JSON(&fiber.Map{
+					"error":   constants.NotFoundError,
+					"details": constants.NotFoundErrorDetails,
+				})
+			}
+
+			if ok, err := r.authorize(c, dynamicQuery, editAccess); !ok {
+				return err
+			}
+
+			job, err := r.Postgres.GetLatestDynamicQueryJob(c.Context(), id)
+
+			if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+				log.Errorf("🔥 Error retrieving dynamic query job: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			if err != nil || jobs.IsFinished(job.Status) {
+				log.Warnf("⚠️ No active job found for Dynamic Query with ID %s", id)
+
+				return c.Status(fiber.StatusNotFound).JSON(&fiber.Map{
+					"error":   constants.NotFoundError,
+					"details": constants.NotFoundErrorDetails,
+				})
+			}
+
+			reason := "cancelled"
+
+			if currentUser, ok := c.Locals("user").(postgres.User); ok {
+				reason = fmt.Sprintf("cancelled by %s", currentUser.Email)
+			}
+
+			cancelledJob, err := r.Postgres.FinishDynamicQueryJob(c.Context(), postgres.FinishDynamicQueryJobParams{
+				ID:     job.ID,
+				Status: postgres.DynamicQueryJobStatusCancelled,
+				Error:  pgtype.Text{String: reason, Valid: true},
+			})
+
+			if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+				log.Errorf("🔥 Error cancelling dynamic query job: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+				log.Warnf("⚠️ Dynamic Query job %s finished before it could be cancelled", job.ID)
+
+				return c.Status(fiber.StatusNotFound).JSON(&fiber.Map{
+					"error":   constants.NotFoundError,
+					"details": constants.NotFoundErrorDetails,
+				})
+			}
+
+			// Fall back to the last generated SQL, if there is one.
+			status := postgres.DynamicQueryStatusError
+
+			if dynamicQuery.Query.Valid {
+				status = postgres.DynamicQueryStatusComplete
+			}
+
+			// A refinement never touched the dynamic query, only its message.
+			if cancelledJob.MessageID.Valid {
+				if err := r.Postgres.FailDynamicQueryMessage(c.Context(), postgres.FailDynamicQueryMessageParams{
+					ID:    cancelledJob.MessageID.Bytes,
+					Error: pgtype.Text{String: reason, Valid: true},
+				}); err != nil {
+					log.Errorf("🔥 Error updating dynamic query message: %s", err.Error())
+
+					return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					})
+				}
+			} else if _, err := r.Postgres.SetDynamicQueryStatus(c.Context(), postgres.SetDynamicQueryStatusParams{
+				Status:     status,
+				ResponseID: pgtype.Text{},
+				ID:         dynamicQuery.ID,
+			}); err != nil {
+				log.Errorf("🔥 Error updating dynamic query: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			if _, err := r.Postgres.CreateDynamicQueryJobEvent(c.Context(), postgres.CreateDynamicQueryJobEventParams{
+				JobID: cancelledJob.ID,
+				Event: jobs.EventCancelled,
+				Data:  reason,
+			}); err != nil {
+				log.Errorf("🔥 Error recording dynamic query job event: %s", err.Error())
+			}
+
+			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
+				"message": constants.Success,
+				"details": constants.SuccessDetails,
+				"data":    cancelledJob,
+			})
+		},
+	}
+}
@@ -0,0 +1,238 @@
+package dynamicQueries
+
+import (
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type DynamicQueryPromptTemplateRequest struct {
+	PromptTemplateID *uuid.UUID `json:"prompt_template_id"`
+}
+
+// dynamicQueryPromptTemplateID checks that the prompt template with id exists.
+// A nil id selects the default prompt template. When it returns false the
+// error response has already been sent.
+func (r *DynamicQueriesRouter) dynamicQueryPromptTemplateID(c *fiber.Ctx, id *uuid.UUID) (pgtype.UUID, bool, error) {
+	if id == nil {
+		return pgtype.UUID{}, true, nil
+	}
+
+	_, err := r.Postgres.GetPromptTemplate(c.Context(), *id)
+
+	if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+		log.Errorf("🔥 Error retrieving prompt template: %s", err.Error())
+
+		return pgtype.UUID{}, false, c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+			"error":   constants.InternalServerError,
+			"details": constants.InternalServerErrorDetails,
+		})
+	}
+
+	if err != nil {
+		log.Warnf("⚠️ Prompt template with ID %s not found", *id)
+
+		return pgtype.UUID{}, false, c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+			"error":   constants.BadRequestError,
+			"details": "The prompt template does not exist.",
+		})
+	}
+
+	return pgtype.UUID{Bytes: *id, Valid: true}, true, nil
+}
+
+func (r *DynamicQueriesRouter) UpdateDynamicQueryPromptTemplateRoute() system.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.SuccessResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query prompt template updated successfully.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"message": constants.Success,
+						"details": constants.SuccessDetails,
+						"data":    schemas.DynamicQuerySchema.Value,
+					},
+					Schema: schemas.SuccessResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Bad Request.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.BadRequestError,
+						"details": constants.BadRequestErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Unauthorized.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.UnauthorizedError,
+						"details": constants.UnauthorizedErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query not found.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.NotFoundError,
+						"details": constants.NotFoundErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Internal Server Error.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	parameters := []*openapi3.ParameterRef{
+		{
+			Value: &openapi3.Parameter{
+				Name:     "id",
+				In:       "path",
+				Required: true,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{"string"},
+					},
+				},
+			},
+		},
+	}
+
+	return system.Route{
+		OpenAPIMetadata: system.OpenAPIMetadata{
+			Summary:     "Update Dynamic Query Prompt Template",
+			Description: "Endpoint to select the prompt template a dynamic query is generated and refined with. A null prompt_template_id selects the default prompt template. The SQL is not regenerated until the next generation.",
+			Tags:        []string{"Dynamic Queries"},
+			Parameters:  parameters,
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: openapi3.NewRequestBody().WithJSONSchema(schemas.DynamicQueryPromptTemplateSchema.Value),
+			},
+			Responses: responses,
+		},
+		Method: system.PutMethod,
+		Path:   "/dynamic-queries/{id}/prompt-template",
+		Middlewares: []fiber.Handler{
+			r.Middleware.Authorized(),
+			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			id, err := uuid.Parse(c.Params("id"))
+
+			if err != nil {
+				log.Errorf("🔥 Invalid UUID format: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": constants.BadRequestErrorDetails,
+				})
+			}
+
+			_, ok, err := r.authorizedDynamicQuery(c, id, editAccess)
+
+			if !ok {
+				return err
+			}
+
+			var dynamicQueryPromptTemplateRequest DynamicQueryPromptTemplateRequest
+
+			if err := c.BodyParser(&dynamicQueryPromptTemplateRequest); err != nil {
+				log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": constants.BadRequestErrorDetails,
+				})
+			}
+
+			promptTemplateID, ok, err := r.dynamicQueryPromptTemplateID(c, dynamicQueryPromptTemplateRequest.PromptTemplateID)
+
+			if !ok {
+				return err
+			}
+
+			dynamicQuery, err := r.Postgres.UpdateDynamicQueryPromptTemplate(c.Context(), postgres.UpdateDynamicQueryPromptTemplateParams{
+				PromptTemplateID: promptTemplateID,
+				ID:               id,
+			})
+
+			if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+				log.Errorf("🔥 Error updating dynamic query prompt template: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+				log.Warnf("⚠️ Dynamic Query with ID %s not found", id)
+
+				return c.Status(fiber.StatusNotFound).JSON(&fiber.Map{
+					"error":   constants.NotFoundError,
+					"details": constants.NotFoundErrorDetails,
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
+				"message": constants.Success,
+				"details": constants.SuccessDetails,
+				"data":    dynamicQuery,
+			})
+		},
+	}
+}
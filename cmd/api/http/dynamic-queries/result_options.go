@@ -0,0 +1,101 @@
+package dynamicQueries
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/masking"
+	"github.com/gofiber/fiber/v2"
+)
+
+// parseResultOptions reads the page, pageSize, sort and filter query parameters.
+//
+// sort is a comma separated list of "Column:asc" or "Column:desc" entries and
+// filter may be repeated as "Column:value".
+func parseResultOptions(c *fiber.Ctx) trino.ResultOptions {
+	page, err := strconv.Atoi(c.Query("page"))
+
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.Query("pageSize"))
+
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+
+	options := trino.ResultOptions{
+		Page:     page,
+		PageSize: min(pageSize, 1000),
+		Sort:     []trino.ResultSort{},
+		Filters:  []trino.ResultFilter{},
+	}
+
+	for sort := range strings.SplitSeq(c.Query("sort"), ",") {
+		if strings.TrimSpace(sort) == "" {
+			continue
+		}
+
+		column, direction, _ := strings.Cut(sort, ":")
+
+		options.Sort = append(options.Sort, trino.ResultSort{
+			Column:     strings.TrimSpace(column),
+			Descending: strings.EqualFold(strings.TrimSpace(direction), "desc"),
+		})
+	}
+
+	for _, filter := range c.Context().QueryArgs().PeekMulti("filter") {
+		column, value, ok := strings.Cut(string(filter), ":")
+
+		if !ok || strings.TrimSpace(value) == "" {
+			continue
+		}
+
+		options.Filters = append(options.Filters, trino.ResultFilter{
+			Column: strings.TrimSpace(column),
+			Value:  strings.TrimSpace(value),
+		})
+	}
+
+	return options
+}
+
+// maskedOption returns the first column of a filter or sort that is masked,
+// and whether it would be filtered or sorted. Filtering on one would let a
+// user recover the masked value one guess at a time, and sorting on one would
+// order the rows by it.
+func maskedOption(rules masking.Rules, lineage map[string][]string, options trino.ResultOptions) (string, string, bool) {
+	for _, filter := range options.Filters {
+		if _, ok := rules.ResultRule(filter.Column, lineage); ok {
+			return filter.Column, "filtered", true
+		}
+	}
+
+	for _, sort := range options.Sort {
+		if _, ok := rules.ResultRule(sort.Column, lineage); ok {
+			return sort.Column, "sorted", true
+		}
+	}
+
+	return "", "", false
+}
+
+// parseParameterValues reads the "param.<name>" query parameters used to fill
+// in the placeholders of a parameterised query.
+func parseParameterValues(c *fiber.Ctx) map[string]string {
+	values := map[string]string{}
+
+	for key, value := range c.Queries() {
+		name, ok := strings.CutPrefix(key, "param.")
+
+		if !ok || name == "" {
+			continue
+		}
+
+		values[name] = value
+	}
+
+	return values
+}
@@ -1,9 +1,14 @@
 package dynamicQueries
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"strings"
 
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
 	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/masking"
 	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
 	"github.com/connor-davis/zingfibre-core/internal/models/system"
 	"github.com/connor-davis/zingfibre-core/internal/postgres"
@@ -27,6 +32,21 @@ func (r *DynamicQueriesRouter) GetDynamicQueryResultsRoute() system.Route {
 					Example: map[string]any{
 						"message": constants.Success,
 						"details": constants.SuccessDetails,
+						"pages":   1,
+						"data": system.DynamicQueryResult{
+							Columns: []system.DynamicQueryResultColumn{
+								{
+									Name:  "Full Name",
+									Type:  "VARCHAR",
+									Label: "Full Name",
+								},
+							},
+							Data: []map[string]any{
+								{
+									"Full Name": "John Doe",
+								},
+							},
+						},
 					},
 					Schema: schemas.SuccessResponseSchema,
 				},
@@ -84,6 +104,40 @@ func (r *DynamicQueriesRouter) GetDynamicQueryResultsRoute() system.Route {
 			}),
 	})
 
+	responses.Set("409", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("The query was cancelled, because it was killed or the client disconnected.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.ConflictError,
+						"details": "query cancelled: killed by an administrator",
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("504", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("The query ran past the timeout for your role.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.GatewayTimeoutError,
+						"details": constants.GatewayTimeoutErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
 	responses.Set("500", &openapi3.ResponseRef{
 		Value: openapi3.NewResponse().
 			WithJSONSchema(
@@ -101,6 +155,8 @@ func (r *DynamicQueriesRouter) GetDynamicQueryResultsRoute() system.Route {
 			}),
 	})
 
+	responses.Value("200").Value.Headers = cacheHeaders
+
 	parameters := []*openapi3.ParameterRef{
 		{
 			Value: &openapi3.Parameter{
@@ -116,12 +172,77 @@ func (r *DynamicQueriesRouter) GetDynamicQueryResultsRoute() system.Route {
 				},
 			},
 		},
+		{
+			Value: &openapi3.Parameter{
+				Name:     "page",
+				In:       "query",
+				Required: false,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{
+							"integer",
+						},
+					},
+				},
+			},
+		},
+		{
+			Value: &openapi3.Parameter{
+				Name:     "pageSize",
+				In:       "query",
+				Required: false,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{
+							"integer",
+						},
+					},
+				},
+			},
+		},
+		{
+			Value: &openapi3.Parameter{
+				Name:        "sort",
+				In:          "query",
+				Required:    false,
+				Description: "Comma separated list of Column:asc or Column:desc",
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{
+							"string",
+						},
+					},
+				},
+			},
+		},
+		{
+			Value: &openapi3.Parameter{
+				Name:        "filter",
+				In:          "query",
+				Required:    false,
+				Description: "Column:value, repeat for each filtered column",
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{
+							"array",
+						},
+						Items: &openapi3.SchemaRef{
+							Value: &openapi3.Schema{
+								Type: &openapi3.Types{
+									"string",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	return system.Route{
 		OpenAPIMetadata: system.OpenAPIMetadata{
 			Summary:     "Get Dynamic Query Results",
-			Description: "Endpoint to retrieve a dynamic query results by ID",
+			Description: "Endpoint to retrieve a dynamic query results by ID. Values for the query parameters are passed as param.<name> query parameters, with date ranges given as start,end.",
 			Tags:        []string{"Dynamic Queries"},
 			Parameters:  parameters,
 			RequestBody: nil,
@@ -132,61 +253,150 @@ func (r *DynamicQueriesRouter) GetDynamicQueryResultsRoute() system.Route {
 		Middlewares: []fiber.Handler{
 			r.Middleware.Authorized(),
 			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+			r.Middleware.Masked(),
 		},
 		Handler: func(c *fiber.Ctx) error {
-			id, err := uuid.Parse(c.Params("id"))
+			return r.getDynamicQueryResults(c, false)
+		},
+	}
+}
 
-			if err != nil {
-				log.Errorf("🔥 Invalid UUID format: %s", err.Error())
+func (r *DynamicQueriesRouter) RefreshDynamicQueryResultsRoute() system.Route {
+	route := r.GetDynamicQueryResultsRoute()
 
-				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
-					"error":   constants.BadRequestError,
-					"details": constants.BadRequestErrorDetails,
-				})
-			}
+	route.OpenAPIMetadata.Summary = "Refresh Dynamic Query Results"
+	route.OpenAPIMetadata.Description = "Endpoint to discard every cached result of a dynamic query and run it again. It takes the same query parameters and returns the same response as the results endpoint."
+	route.Method = system.PostMethod
+	route.Path = "/dynamic-queries/{id}/results/refresh"
+	route.Middlewares = []fiber.Handler{
+		r.Middleware.Authorized(),
+		r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff),
+		r.Middleware.Masked(),
+	}
+	route.Handler = func(c *fiber.Ctx) error {
+		return r.getDynamicQueryResults(c, true)
+	}
 
-			dynamicQuery, err := r.Postgres.GetDynamicQuery(c.Context(), id)
+	return route
+}
 
-			if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
-				log.Errorf("🔥 Error retrieving dynamic query: %s", err.Error())
+// getDynamicQueryResults serves a page of dynamic query results, from the
+// result cache unless refresh is set, in which case the cache is cleared for
+// the query first.
+func (r *DynamicQueriesRouter) getDynamicQueryResults(c *fiber.Ctx, refresh bool) error {
+	id, err := uuid.Parse(c.Params("id"))
 
-				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
-					"error":   constants.InternalServerError,
-					"details": constants.InternalServerErrorDetails,
-				})
-			}
+	if err != nil {
+		log.Errorf("🔥 Invalid UUID format: %s", err.Error())
 
-			if err != nil && strings.Contains(err.Error(), "no rows in result set") {
-				log.Warnf("⚠️ Dynamic Query with ID %s not found", id)
+		return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+			"error":   constants.BadRequestError,
+			"details": constants.BadRequestErrorDetails,
+		})
+	}
 
-				return c.Status(fiber.StatusNotFound).JSON(&fiber.Map{
-					"error":   constants.NotFoundError,
-					"details": constants.NotFoundErrorDetails,
-				})
-			}
+	dynamicQuery, err := r.Postgres.GetDynamicQuery(c.Context(), id)
 
-			query := strings.TrimSuffix(strings.TrimSuffix(dynamicQuery.Query.String, "\n"), ";")
+	if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+		log.Errorf("🔥 Error retrieving dynamic query: %s", err.Error())
 
-			log.Infof("Running query: %s", query)
+		return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+			"error":   constants.InternalServerError,
+			"details": constants.InternalServerErrorDetails,
+		})
+	}
 
-			var dynamicQueryResult string
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		log.Warnf("⚠️ Dynamic Query with ID %s not found", id)
 
-			row := r.Trino.QueryRow(query)
+		return c.Status(fiber.StatusNotFound).JSON(&fiber.Map{
+			"error":   constants.NotFoundError,
+			"details": constants.NotFoundErrorDetails,
+		})
+	}
 
-			if err := row.Scan(&dynamicQueryResult); err != nil {
-				log.Errorf("🔥 Error scanning dynamic query results: %s", err.Error())
+	if ok, err := r.authorize(c, dynamicQuery, runAccess); !ok {
+		return err
+	}
 
-				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
-					"error":   constants.InternalServerError,
-					"details": constants.InternalServerErrorDetails,
-				})
-			}
+	options := parseResultOptions(c)
 
-			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
-				"message": constants.Success,
-				"details": constants.SuccessDetails,
-				"data":    dynamicQueryResult,
+	maskingRules := c.Locals("masking").(masking.Rules)
+	lineage := trino.ColumnLineage(dynamicQuery.Query.String)
+
+	if column, use, ok := maskedOption(maskingRules, lineage, options); ok {
+		log.Warnf("⚠️ Refusing dynamic query results %s on masked column %s", use, column)
+
+		return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+			"error":   constants.BadRequestError,
+			"details": fmt.Sprintf("%s is masked and cannot be %s.", column, use),
+		})
+	}
+
+	if refresh {
+		if err := r.Postgres.DeleteDynamicQueryResultCaches(c.Context(), dynamicQuery.ID); err != nil {
+			log.Errorf("🔥 Error clearing cached dynamic query results: %s", err.Error())
+
+			return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+				"error":   constants.InternalServerError,
+				"details": constants.InternalServerErrorDetails,
 			})
-		},
+		}
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	cached, err := r.executeCachedDynamicQuery(ctx, c.Locals("user").(postgres.User), dynamicQuery, parseParameterValues(c), options, refresh)
+
+	if err != nil && (errors.Is(err, trino.ErrUnknownColumn) || errors.Is(err, trino.ErrInvalidParameter) || errors.Is(err, trino.ErrUnsafeQuery) || errors.Is(err, trino.ErrRowLimit) || errors.Is(err, trino.ErrByteLimit)) {
+		log.Warnf("⚠️ Invalid dynamic query result options: %s", err.Error())
+
+		return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+			"error":   constants.BadRequestError,
+			"details": err.Error(),
+		})
+	}
+
+	if err != nil && errors.Is(err, trino.ErrQueryTimeout) {
+		log.Warnf("⚠️ Dynamic query timed out: %s", err.Error())
+
+		return c.Status(fiber.StatusGatewayTimeout).JSON(&fiber.Map{
+			"error":   constants.GatewayTimeoutError,
+			"details": constants.GatewayTimeoutErrorDetails,
+		})
+	}
+
+	if err != nil && errors.Is(err, trino.ErrQueryCancelled) {
+		log.Warnf("⚠️ Dynamic query cancelled: %s", err.Error())
+
+		return c.Status(fiber.StatusConflict).JSON(&fiber.Map{
+			"error":   constants.ConflictError,
+			"details": err.Error(),
+		})
+	}
+
+	if err != nil {
+		log.Errorf("🔥 Error running dynamic query: %s", err.Error())
+
+		return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+			"error":   constants.InternalServerError,
+			"details": constants.InternalServerErrorDetails,
+		})
 	}
+
+	r.recordDynamicQueryRun(c.Context(), c.Locals("user").(postgres.User), dynamicQuery.ID)
+
+	maskingRules.MaskResult(&cached.Result, lineage)
+
+	pages := int32(math.Ceil(float64(cached.Total) / float64(options.PageSize)))
+
+	setCacheHeaders(c, cached)
+
+	return c.Status(fiber.StatusOK).JSON(&fiber.Map{
+		"message": constants.Success,
+		"details": constants.SuccessDetails,
+		"data":    cached.Result,
+		"pages":   pages,
+	})
 }
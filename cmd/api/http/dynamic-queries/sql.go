@@ -0,0 +1,546 @@
+package dynamicQueries
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/jobs"
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/masking"
+	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type DynamicQuerySqlRequest struct {
+	SqlQuery   string                        `json:"sql_query"`
+	Parameters system.DynamicQueryParameters `json:"parameters"`
+	// Analyze runs EXPLAIN ANALYZE as well, which executes the query.
+	Analyze bool `json:"analyze"`
+}
+
+// DynamicQuerySqlUpdate is a dynamic query saved with hand-written SQL along
+// with the dry run that validated it.
+type DynamicQuerySqlUpdate struct {
+	DynamicQuery postgres.DynamicQuery
+	DryRun       system.DynamicQueryDryRun
+}
+
+// dryRunDynamicQuery dry runs the SQL in request for dynamicQuery as an
+// execution limited by the role of the current user, masking the preview.
+// When it returns false the error response has already been sent.
+func (r *DynamicQueriesRouter) dryRunDynamicQuery(c *fiber.Ctx, dynamicQuery postgres.DynamicQuery, request DynamicQuerySqlRequest) (system.DynamicQueryDryRun, bool, error) {
+	currentUser := c.Locals("user").(postgres.User)
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	ctx, execution := r.Executions.Start(ctx, trino.ExecutionInfo{
+		Description: fmt.Sprintf("Dry run of dynamic query %s", dynamicQuery.Name),
+		User:        currentUser.Email,
+		Role:        string(currentUser.Role),
+		Query:       request.SqlQuery,
+	})
+
+	dryRun, err := trino.DryRun(ctx, r.Trino, r.Policy, request.SqlQuery, request.Parameters, request.Analyze)
+
+	err = execution.Finish(err)
+
+	if err != nil && (errors.Is(err, trino.ErrInvalidQuery) || errors.Is(err, trino.ErrInvalidParameter) || errors.Is(err, trino.ErrUnsafeQuery) || errors.Is(err, trino.ErrRowLimit) || errors.Is(err, trino.ErrByteLimit)) {
+		log.Warnf("⚠️ Invalid dynamic query SQL: %s", err.Error())
+
+		return dryRun, false, c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+			"error":   constants.BadRequestError,
+			"details": err.Error(),
+		})
+	}
+
+	if err != nil && errors.Is(err, trino.ErrQueryTimeout) {
+		log.Warnf("⚠️ Dynamic query dry run timed out: %s", err.Error())
+
+		return dryRun, false, c.Status(fiber.StatusGatewayTimeout).JSON(&fiber.Map{
+			"error":   constants.GatewayTimeoutError,
+			"details": constants.GatewayTimeoutErrorDetails,
+		})
+	}
+
+	if err != nil && errors.Is(err, trino.ErrQueryCancelled) {
+		log.Warnf("⚠️ Dynamic query dry run cancelled: %s", err.Error())
+
+		return dryRun, false, c.Status(fiber.StatusConflict).JSON(&fiber.Map{
+			"error":   constants.ConflictError,
+			"details": err.Error(),
+		})
+	}
+
+	if err != nil {
+		log.Errorf("🔥 Error dry running dynamic query: %s", err.Error())
+
+		return dryRun, false, c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+			"error":   constants.InternalServerError,
+			"details": constants.InternalServerErrorDetails,
+		})
+	}
+
+	c.Locals("masking").(masking.Rules).MaskResult(&dryRun.Preview, trino.ColumnLineage(request.SqlQuery))
+
+	return dryRun, true, nil
+}
+
+// parseDynamicQuerySqlRequest parses and trims the request body. When it
+// returns false the error response has already been sent.
+func parseDynamicQuerySqlRequest(c *fiber.Ctx) (DynamicQuerySqlRequest, bool, error) {
+	var request DynamicQuerySqlRequest
+
+	if err := c.BodyParser(&request); err != nil {
+		log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+		return request, false, c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+			"error":   constants.BadRequestError,
+			"details": constants.BadRequestErrorDetails,
+		})
+	}
+
+	request.SqlQuery = strings.TrimSpace(request.SqlQuery)
+
+	if request.SqlQuery == "" {
+		log.Warn("⚠️ Dynamic Query SQL is required")
+
+		return request, false, c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+			"error":   constants.BadRequestError,
+			"details": "The SQL query is required.",
+		})
+	}
+
+	if request.Parameters == nil {
+		request.Parameters = system.DynamicQueryParameters{}
+	}
+
+	return request, true, nil
+}
+
+func (r *DynamicQueriesRouter) DryRunDynamicQuerySqlRoute() system.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.SuccessResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query SQL dry run successfully.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"message": constants.Success,
+						"details": constants.SuccessDetails,
+						"data":    schemas.DynamicQueryDryRunSchema.Value,
+					},
+					Schema: schemas.SuccessResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Bad Request.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.BadRequestError,
+						"details": constants.BadRequestErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Unauthorized.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.UnauthorizedError,
+						"details": constants.UnauthorizedErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query not found.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.NotFoundError,
+						"details": constants.NotFoundErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("409", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Conflict.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.ConflictError,
+						"details": constants.ConflictErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Internal Server Error.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	parameters := []*openapi3.ParameterRef{
+		{
+			Value: &openapi3.Parameter{
+				Name:     "id",
+				In:       "path",
+				Required: true,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{"string"},
+					},
+				},
+			},
+		},
+	}
+
+	return system.Route{
+		OpenAPIMetadata: system.OpenAPIMetadata{
+			Summary:     "Dry Run Dynamic Query SQL",
+			Description: "Endpoint to check hand-written SQL for a dynamic query without saving it. The SQL is validated, planned by Trino with EXPLAIN using the parameter defaults, or a value of each parameter's type where there is none, and optionally run with EXPLAIN ANALYZE. The response holds the plan and its cost estimate, the columns the SQL returns and a masked preview of its first 20 rows.",
+			Tags:        []string{"Dynamic Queries"},
+			Parameters:  parameters,
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: openapi3.NewRequestBody().WithJSONSchema(schemas.DynamicQuerySqlSchema.Value),
+			},
+			Responses: responses,
+		},
+		Method: system.PostMethod,
+		Path:   "/dynamic-queries/{id}/sql/dry-run",
+		Middlewares: []fiber.Handler{
+			r.Middleware.Authorized(),
+			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+			r.Middleware.Masked(),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			id, err := uuid.Parse(c.Params("id"))
+
+			if err != nil {
+				log.Errorf("🔥 Invalid UUID format: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": constants.BadRequestErrorDetails,
+				})
+			}
+
+			request, ok, err := parseDynamicQuerySqlRequest(c)
+
+			if !ok {
+				return err
+			}
+
+			dynamicQuery, ok, err := r.authorizedDynamicQuery(c, id, editAccess)
+
+			if !ok {
+				return err
+			}
+
+			dryRun, ok, err := r.dryRunDynamicQuery(c, dynamicQuery, request)
+
+			if !ok {
+				return err
+			}
+
+			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
+				"message": constants.Success,
+				"details": constants.SuccessDetails,
+				"data":    dryRun,
+			})
+		},
+	}
+}
+
+func (r *DynamicQueriesRouter) UpdateDynamicQuerySqlRoute() system.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.SuccessResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query SQL updated successfully.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"message": constants.Success,
+						"details": constants.SuccessDetails,
+						"data":    schemas.DynamicQuerySqlUpdateSchema.Value,
+					},
+					Schema: schemas.SuccessResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Bad Request.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.BadRequestError,
+						"details": constants.BadRequestErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Unauthorized.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.UnauthorizedError,
+						"details": constants.UnauthorizedErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Dynamic Query not found.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.NotFoundError,
+						"details": constants.NotFoundErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("409", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Conflict.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.ConflictError,
+						"details": constants.ConflictErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Internal Server Error.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	parameters := []*openapi3.ParameterRef{
+		{
+			Value: &openapi3.Parameter{
+				Name:     "id",
+				In:       "path",
+				Required: true,
+				Schema: &openapi3.SchemaRef{
+					Value: &openapi3.Schema{
+						Type: &openapi3.Types{"string"},
+					},
+				},
+			},
+		},
+	}
+
+	return system.Route{
+		OpenAPIMetadata: system.OpenAPIMetadata{
+			Summary:     "Update Dynamic Query SQL",
+			Description: "Endpoint to replace the SQL of a dynamic query with hand-written SQL. The SQL is dry run first, exactly as the dry run endpoint does, and only saved when that succeeds. The dynamic query is then complete, a new version is recorded and any open refinement proposals are discarded.",
+			Tags:        []string{"Dynamic Queries"},
+			Parameters:  parameters,
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: openapi3.NewRequestBody().WithJSONSchema(schemas.DynamicQuerySqlSchema.Value),
+			},
+			Responses: responses,
+		},
+		Method: system.PutMethod,
+		Path:   "/dynamic-queries/{id}/sql",
+		Middlewares: []fiber.Handler{
+			r.Middleware.Authorized(),
+			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+			r.Middleware.Masked(),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			id, err := uuid.Parse(c.Params("id"))
+
+			if err != nil {
+				log.Errorf("🔥 Invalid UUID format: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": constants.BadRequestErrorDetails,
+				})
+			}
+
+			request, ok, err := parseDynamicQuerySqlRequest(c)
+
+			if !ok {
+				return err
+			}
+
+			dynamicQuery, ok, err := r.authorizedDynamicQuery(c, id, editAccess)
+
+			if !ok {
+				return err
+			}
+
+			// A running generation would overwrite the SQL when it finishes.
+			job, err := r.Postgres.GetLatestDynamicQueryJob(c.Context(), dynamicQuery.ID)
+
+			if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+				log.Errorf("🔥 Error retrieving dynamic query job: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			if err == nil && !jobs.IsFinished(job.Status) {
+				log.Warnf("⚠️ Dynamic Query with ID %s has an active job", id)
+
+				return c.Status(fiber.StatusConflict).JSON(&fiber.Map{
+					"error":   constants.ConflictError,
+					"details": constants.ConflictErrorDetails,
+				})
+			}
+
+			dryRun, ok, err := r.dryRunDynamicQuery(c, dynamicQuery, request)
+
+			if !ok {
+				return err
+			}
+
+			// The previous response no longer describes the SQL, so generation
+			// starts a new conversation from here.
+			updatedDynamicQuery, err := r.Postgres.UpdateDynamicQuery(c.Context(), postgres.UpdateDynamicQueryParams{
+				ID:         dynamicQuery.ID,
+				Name:       dynamicQuery.Name,
+				Query:      pgtype.Text{String: request.SqlQuery, Valid: true},
+				ResponseID: pgtype.Text{},
+				Status:     postgres.DynamicQueryStatusComplete,
+				Prompt:     dynamicQuery.Prompt,
+				Parameters: request.Parameters,
+			})
+
+			if err != nil {
+				log.Errorf("🔥 Error updating dynamic query: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			if err := r.Postgres.DiscardProposedDynamicQueryMessages(c.Context(), dynamicQuery.ID); err != nil {
+				log.Errorf("🔥 Error discarding dynamic query messages: %s", err.Error())
+			}
+
+			if err := recordDynamicQueryVersion(c.Context(), r.Postgres, updatedDynamicQuery, currentUserID(c)); err != nil {
+				log.Errorf("🔥 Error recording dynamic query version: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			// Cached results belong to the SQL that was replaced.
+			if err := r.Postgres.DeleteDynamicQueryResultCaches(c.Context(), updatedDynamicQuery.ID); err != nil {
+				log.Errorf("🔥 Error clearing cached dynamic query results: %s", err.Error())
+			}
+
+			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
+				"message": constants.Success,
+				"details": constants.SuccessDetails,
+				"data": DynamicQuerySqlUpdate{
+					DynamicQuery: updatedDynamicQuery,
+					DryRun:       dryRun,
+				},
+			})
+		},
+	}
+}
@@ -1,8 +1,13 @@
 package dynamicQueries
 
 import (
+	"fmt"
+	"reflect"
+	"slices"
 	"strings"
 
+	"github.com/connor-davis/zingfibre-core/cmd/api/jobs"
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
 	"github.com/connor-davis/zingfibre-core/internal/constants"
 	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
 	"github.com/connor-davis/zingfibre-core/internal/models/system"
@@ -11,12 +16,19 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type UpdateDynamicQueryRequest struct {
-	Name   string                      `json:"name"`
-	Prompt string                      `json:"prompt"`
-	Status postgres.DynamicQueryStatus `json:"status"`
+	Name       string                         `json:"name"`
+	Prompt     string                         `json:"prompt"`
+	Parameters *system.DynamicQueryParameters `json:"parameters"`
+	// Visibility may only be changed by the owner or an admin.
+	Visibility *postgres.DynamicQueryVisibility `json:"visibility"`
+	// FolderID moves the query into a folder, or out of its folder when it
+	// is empty.
+	FolderID *string   `json:"folder_id"`
+	Tags     *[]string `json:"tags"`
 }
 
 func (r *DynamicQueriesRouter) UpdateDynamicQueryRoute() system.Route {
@@ -137,7 +149,7 @@ func (r *DynamicQueriesRouter) UpdateDynamicQueryRoute() system.Route {
 		Path:   "/dynamic-queries/{id}",
 		Middlewares: []fiber.Handler{
 			r.Middleware.Authorized(),
-			r.Middleware.HasRole(postgres.RoleTypeAdmin),
+			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
 		},
 		Handler: func(c *fiber.Ctx) error {
 			var updateDynamicQueryRequest UpdateDynamicQueryRequest
@@ -182,13 +194,132 @@ func (r *DynamicQueriesRouter) UpdateDynamicQueryRoute() system.Route {
 				})
 			}
 
-			updatedDynamicQuery, err := r.Postgres.UpdateDynamicQuery(c.Context(), postgres.UpdateDynamicQueryParams{
+			if ok, err := r.authorize(c, dynamicQuery, editAccess); !ok {
+				return err
+			}
+
+			// A running generation would overwrite the parameters when it
+			// finishes.
+			job, err := r.Postgres.GetLatestDynamicQueryJob(c.Context(), dynamicQuery.ID)
+
+			if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+				log.Errorf("🔥 Error retrieving dynamic query job: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			if err == nil && !jobs.IsFinished(job.Status) {
+				log.Warnf("⚠️ Dynamic Query with ID %s has an active job", id)
+
+				return c.Status(fiber.StatusConflict).JSON(&fiber.Map{
+					"error":   constants.ConflictError,
+					"details": constants.ConflictErrorDetails,
+				})
+			}
+
+			visibilityChanged := updateDynamicQueryRequest.Visibility != nil && *updateDynamicQueryRequest.Visibility != dynamicQuery.Visibility
+
+			if visibilityChanged && !validVisibility(*updateDynamicQueryRequest.Visibility) {
+				log.Warnf("⚠️ Invalid dynamic query visibility %s", *updateDynamicQueryRequest.Visibility)
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": "visibility must be private, shared or public.",
+				})
+			}
+
+			if visibilityChanged {
+				if ok, err := r.authorize(c, dynamicQuery, ownerAccess); !ok {
+					return err
+				}
+			}
+
+			folderID := dynamicQuery.FolderID
+
+			if updateDynamicQueryRequest.FolderID != nil {
+				folderID = pgtype.UUID{}
+
+				if *updateDynamicQueryRequest.FolderID != "" {
+					id, err := uuid.Parse(*updateDynamicQueryRequest.FolderID)
+
+					if err != nil {
+						log.Errorf("🔥 Invalid UUID format: %s", err.Error())
+
+						return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+							"error":   constants.BadRequestError,
+							"details": constants.BadRequestErrorDetails,
+						})
+					}
+
+					existingFolderID, ok, err := r.dynamicQueryFolderID(c, &id)
+
+					if !ok {
+						return err
+					}
+
+					folderID = existingFolderID
+				}
+			}
+
+			tags := dynamicQuery.Tags
+
+			if updateDynamicQueryRequest.Tags != nil {
+				tags = normalizeTags(*updateDynamicQueryRequest.Tags)
+
+				if len(tags) > maxDynamicQueryTags {
+					log.Warnf("⚠️ Dynamic Query has %d tags", len(tags))
+
+					return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+						"error":   constants.BadRequestError,
+						"details": fmt.Sprintf("A dynamic query may have at most %d tags.", maxDynamicQueryTags),
+					})
+				}
+			}
+
+			parameters := dynamicQuery.Parameters
+
+			if updateDynamicQueryRequest.Parameters != nil {
+				parameters = *updateDynamicQueryRequest.Parameters
+
+				if err := trino.ValidateParameters(dynamicQuery.Query.String, parameters); err != nil {
+					log.Warnf("⚠️ Invalid dynamic query parameters: %s", err.Error())
+
+					return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+						"error":   constants.BadRequestError,
+						"details": err.Error(),
+					})
+				}
+			}
+
+			// Every change, its version and clearing the cache either all
+			// happen or none do.
+			tx, err := r.Pool.Begin(c.Context())
+
+			if err != nil {
+				log.Errorf("🔥 Error starting transaction: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			defer tx.Rollback(c.Context())
+
+			queries := r.Postgres.WithTx(tx)
+
+			// The status follows the SQL and jobs, which this does not change.
+			updatedDynamicQuery, err := queries.UpdateDynamicQuery(c.Context(), postgres.UpdateDynamicQueryParams{
 				ID:         dynamicQuery.ID,
 				Name:       updateDynamicQueryRequest.Name,
 				Prompt:     updateDynamicQueryRequest.Prompt,
-				Status:     updateDynamicQueryRequest.Status,
+				Status:     dynamicQuery.Status,
 				Query:      dynamicQuery.Query,
 				ResponseID: dynamicQuery.ResponseID,
+				Parameters: parameters,
 			})
 
 			if err != nil {
@@ -200,6 +331,71 @@ func (r *DynamicQueriesRouter) UpdateDynamicQueryRoute() system.Route {
 				})
 			}
 
+			if visibilityChanged {
+				updatedDynamicQuery, err = queries.UpdateDynamicQueryVisibility(c.Context(), postgres.UpdateDynamicQueryVisibilityParams{
+					Visibility: *updateDynamicQueryRequest.Visibility,
+					ID:         dynamicQuery.ID,
+				})
+
+				if err != nil {
+					log.Errorf("🔥 Error updating dynamic query visibility: %s", err.Error())
+
+					return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					})
+				}
+			}
+
+			if folderID != dynamicQuery.FolderID || !slices.Equal(tags, dynamicQuery.Tags) {
+				updatedDynamicQuery, err = queries.UpdateDynamicQueryFolderAndTags(c.Context(), postgres.UpdateDynamicQueryFolderAndTagsParams{
+					FolderID: folderID,
+					Tags:     tags,
+					ID:       dynamicQuery.ID,
+				})
+
+				if err != nil {
+					log.Errorf("🔥 Error updating dynamic query folder and tags: %s", err.Error())
+
+					return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					})
+				}
+			}
+
+			// Cached results were run with the old parameter defaults.
+			if !reflect.DeepEqual(updatedDynamicQuery.Parameters, dynamicQuery.Parameters) {
+				if err := queries.DeleteDynamicQueryResultCaches(c.Context(), dynamicQuery.ID); err != nil {
+					log.Errorf("🔥 Error clearing cached dynamic query results: %s", err.Error())
+
+					return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					})
+				}
+			}
+
+			if updatedDynamicQuery.Prompt != dynamicQuery.Prompt || !reflect.DeepEqual(updatedDynamicQuery.Parameters, dynamicQuery.Parameters) {
+				if err := recordDynamicQueryVersion(c.Context(), queries, updatedDynamicQuery, currentUserID(c)); err != nil {
+					log.Errorf("🔥 Error recording dynamic query version: %s", err.Error())
+
+					return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					})
+				}
+			}
+
+			if err := tx.Commit(c.Context()); err != nil {
+				log.Errorf("🔥 Error committing transaction: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
 			return c.Status(fiber.StatusCreated).JSON(&fiber.Map{
 				"message": constants.Success,
 				"details": constants.SuccessDetails,
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/masking"
 	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
 	"github.com/connor-davis/zingfibre-core/internal/models/system"
 	"github.com/connor-davis/zingfibre-core/internal/postgres"
@@ -92,6 +93,7 @@ func (r *ExportsRouter) CustomersRoute() system.Route {
 		Middlewares: []fiber.Handler{
 			r.Middleware.Authorized(),
 			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+			r.Middleware.Masked(),
 		},
 		Handler: func(c *fiber.Ctx) error {
 			poi := c.Query("poi")
@@ -118,6 +120,8 @@ func (r *ExportsRouter) CustomersRoute() system.Route {
 
 			header := []string{"Full Name", "Email", "Phone Number", "Radius Username"}
 
+			maskingRules := c.Locals("masking").(masking.Rules)
+
 			if err := writer.Write(header); err != nil {
 				log.Errorf("🔥 Error writing CSV header: %s", err.Error())
 
@@ -135,6 +139,8 @@ func (r *ExportsRouter) CustomersRoute() system.Route {
 					customer.RadiusUsername.String,
 				}
 
+				maskingRules.MaskRecord(header, record)
+
 				if err := writer.Write(record); err != nil {
 					log.Errorf("🔥 Error writing CSV record: %s", err.Error())
 
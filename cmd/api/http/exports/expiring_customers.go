@@ -8,6 +8,7 @@ import (
 
 	"github.com/ahmetb/go-linq/v3"
 	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/masking"
 	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
 	"github.com/connor-davis/zingfibre-core/internal/models/system"
 	"github.com/connor-davis/zingfibre-core/internal/mysql/radius"
@@ -96,6 +97,7 @@ func (r *ExportsRouter) ExpiringCustomersRoute() system.Route {
 		Middlewares: []fiber.Handler{
 			r.Middleware.Authorized(),
 			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+			r.Middleware.Masked(),
 		},
 		Handler: func(c *fiber.Ctx) error {
 			poi := c.Query("poi")
@@ -170,6 +172,8 @@ func (r *ExportsRouter) ExpiringCustomersRoute() system.Route {
 
 			header := []string{"Expires On", "Full Name", "Email", "Phone Number", "Radius Username", "Last Purchase Duration", "Last Purchase Speed", "Address"}
 
+			maskingRules := c.Locals("masking").(masking.Rules)
+
 			if err := writer.Write(header); err != nil {
 				log.Errorf("🔥 Error writing CSV header: %s", err.Error())
 
@@ -193,6 +197,8 @@ func (r *ExportsRouter) ExpiringCustomersRoute() system.Route {
 					expiringCustomer.Address,
 				}
 
+				maskingRules.MaskRecord(header, record)
+
 				if err := writer.Write(record); err != nil {
 					log.Errorf("🔥 Error writing CSV record: %s", err.Error())
 
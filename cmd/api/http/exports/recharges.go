@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/masking"
 	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
 	"github.com/connor-davis/zingfibre-core/internal/models/system"
 	"github.com/connor-davis/zingfibre-core/internal/mysql/zing"
@@ -124,6 +125,7 @@ func (r *ExportsRouter) RechargesRoute() system.Route {
 		Middlewares: []fiber.Handler{
 			r.Middleware.Authorized(),
 			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+			r.Middleware.Masked(),
 		},
 		Handler: func(c *fiber.Ctx) error {
 			poi := c.Query("poi")
@@ -178,6 +180,8 @@ func (r *ExportsRouter) RechargesRoute() system.Route {
 
 			header := []string{"Created On", "Email", "Full Name", "Item Name", "Amount", "Method", "Successful", "Service ID", "Build Name", "Build Type"}
 
+			maskingRules := c.Locals("masking").(masking.Rules)
+
 			if err := writer.Write(header); err != nil {
 				log.Errorf("🔥 Error writing CSV header: %s", err.Error())
 
@@ -209,6 +213,8 @@ func (r *ExportsRouter) RechargesRoute() system.Route {
 					recharge.BuildType.String,
 				}
 
+				maskingRules.MaskRecord(header, record)
+
 				if err := writer.Write(record); err != nil {
 					log.Errorf("🔥 Error writing CSV record: %s", err.Error())
 
@@ -310,6 +316,7 @@ func (r *ExportsRouter) RechargesSummaryRoute() system.Route {
 		Middlewares: []fiber.Handler{
 			r.Middleware.Authorized(),
 			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+			r.Middleware.Masked(),
 		},
 		Handler: func(c *fiber.Ctx) error {
 			poi := c.Query("poi")
@@ -336,6 +343,8 @@ func (r *ExportsRouter) RechargesSummaryRoute() system.Route {
 
 			header := []string{"Created On", "Email", "Full Name", "Item Name", "Amount", "Method", "Successful", "Service ID", "Build Name", "Build Type"}
 
+			maskingRules := c.Locals("masking").(masking.Rules)
+
 			if err := writer.Write(header); err != nil {
 				log.Errorf("🔥 Error writing CSV header: %s", err.Error())
 
@@ -367,6 +376,8 @@ func (r *ExportsRouter) RechargesSummaryRoute() system.Route {
 					rechargeSummary.BuildType.String,
 				}
 
+				maskingRules.MaskRecord(header, record)
+
 				if err := writer.Write(record); err != nil {
 					log.Errorf("🔥 Error writing CSV record: %s", err.Error())
 
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/masking"
 	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
 	"github.com/connor-davis/zingfibre-core/internal/models/system"
 	"github.com/connor-davis/zingfibre-core/internal/mysql/zing"
@@ -94,6 +95,7 @@ func (r *ExportsRouter) SummaryRoute() system.Route {
 		Middlewares: []fiber.Handler{
 			r.Middleware.Authorized(),
 			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+			r.Middleware.Masked(),
 		},
 		Handler: func(c *fiber.Ctx) error {
 			poi := c.Query("poi")
@@ -135,6 +137,8 @@ func (r *ExportsRouter) SummaryRoute() system.Route {
 
 			header := []string{"Created On", "Item Name", "Radius Username", "Method", "Amount", "Service ID", "Build Name", "Build Type"}
 
+			maskingRules := c.Locals("masking").(masking.Rules)
+
 			if err := writer.Write(header); err != nil {
 				log.Errorf("🔥 Error writing CSV header: %s", err.Error())
 
@@ -156,6 +160,8 @@ func (r *ExportsRouter) SummaryRoute() system.Route {
 					summary.BuildType.String,
 				}
 
+				maskingRules.MaskRecord(header, record)
+
 				if err := writer.Write(record); err != nil {
 					log.Errorf("🔥 Error writing CSV record: %s", err.Error())
 
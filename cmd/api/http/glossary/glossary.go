@@ -0,0 +1,69 @@
+package glossary
+
+import (
+	"context"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/http/middleware"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/google/uuid"
+)
+
+type GlossaryRouter struct {
+	Postgres   *postgres.Queries
+	Middleware *middleware.Middleware
+}
+
+type GlossaryTermRequest struct {
+	Term       string `json:"term"`
+	Definition string `json:"definition"`
+}
+
+func NewGlossaryRouter(postgres *postgres.Queries, middleware *middleware.Middleware) *GlossaryRouter {
+	return &GlossaryRouter{
+		Postgres:   postgres,
+		Middleware: middleware,
+	}
+}
+
+func (r *GlossaryRouter) RegisterRoutes() []system.Route {
+	return []system.Route{
+		r.GetGlossaryTermsRoute(),
+		r.CreateGlossaryTermRoute(),
+		r.UpdateGlossaryTermRoute(),
+		r.DeleteGlossaryTermRoute(),
+	}
+}
+
+// validate trims the term and definition and returns a description of the
+// first problem with the request, or an empty string if there is none.
+func (request *GlossaryTermRequest) validate() string {
+	request.Term = strings.TrimSpace(request.Term)
+	request.Definition = strings.TrimSpace(request.Definition)
+
+	switch {
+	case request.Term == "":
+		return "The term is required."
+	case request.Definition == "":
+		return "The definition is required."
+	}
+
+	return ""
+}
+
+// conflicts reports whether a glossary term other than id already defines
+// term. Terms are compared case insensitively.
+func (r *GlossaryRouter) conflicts(ctx context.Context, term string, id uuid.UUID) (bool, error) {
+	glossaryTerm, err := r.Postgres.GetGlossaryTermByTerm(ctx, term)
+
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return glossaryTerm.ID != id, nil
+}
@@ -4,16 +4,23 @@ import (
 	"database/sql"
 	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/connor-davis/zingfibre-core/cmd/api/http/analytics"
 	"github.com/connor-davis/zingfibre-core/cmd/api/http/authentication"
 	dynamicQueries "github.com/connor-davis/zingfibre-core/cmd/api/http/dynamic-queries"
 	"github.com/connor-davis/zingfibre-core/cmd/api/http/exports"
+	"github.com/connor-davis/zingfibre-core/cmd/api/http/glossary"
+	maskingRules "github.com/connor-davis/zingfibre-core/cmd/api/http/masking-rules"
+	mcpTokens "github.com/connor-davis/zingfibre-core/cmd/api/http/mcp-tokens"
 	"github.com/connor-davis/zingfibre-core/cmd/api/http/middleware"
 	"github.com/connor-davis/zingfibre-core/cmd/api/http/pops"
+	promptTemplates "github.com/connor-davis/zingfibre-core/cmd/api/http/prompt-templates"
 	"github.com/connor-davis/zingfibre-core/cmd/api/http/reports"
 	"github.com/connor-davis/zingfibre-core/cmd/api/http/users"
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
 	"github.com/connor-davis/zingfibre-core/common"
+	"github.com/connor-davis/zingfibre-core/internal/ai"
 	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
 	"github.com/connor-davis/zingfibre-core/internal/models/system"
 	"github.com/connor-davis/zingfibre-core/internal/mysql/radius"
@@ -22,6 +29,7 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/session"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type HttpRouter struct {
@@ -34,7 +42,7 @@ type HttpRouter struct {
 	Trino      *sql.DB
 }
 
-func NewHttpRouter(postgres *postgres.Queries, zing *zing.Queries, radius *radius.Queries, middleware *middleware.Middleware, sessions *session.Store, trino *sql.DB) *HttpRouter {
+func NewHttpRouter(postgres *postgres.Queries, pool *pgxpool.Pool, zing *zing.Queries, radius *radius.Queries, middleware *middleware.Middleware, sessions *session.Store, trinoDb *sql.DB, policy trino.Policy, promptData ai.PromptData, cacheTTL time.Duration, executions *trino.Executions) *HttpRouter {
 	authentication := authentication.NewAuthenticationRouter(postgres, middleware, sessions)
 	authenticationRoutes := authentication.RegisterRoutes()
 
@@ -53,9 +61,21 @@ func NewHttpRouter(postgres *postgres.Queries, zing *zing.Queries, radius *radiu
 	exports := exports.NewExportsRouter(zing, radius, middleware, sessions)
 	exportsRoutes := exports.RegisterRoutes()
 
-	dynamicQueries := dynamicQueries.NewDynamicQueriesRouter(postgres, zing, radius, middleware, sessions, trino)
+	dynamicQueries := dynamicQueries.NewDynamicQueriesRouter(postgres, pool, zing, radius, middleware, sessions, trinoDb, policy, cacheTTL, executions)
 	dynamicQueriesRoutes := dynamicQueries.RegisterRoutes()
 
+	mcpTokens := mcpTokens.NewMcpTokensRouter(postgres, middleware, policy)
+	mcpTokensRoutes := mcpTokens.RegisterRoutes()
+
+	maskingRules := maskingRules.NewMaskingRulesRouter(postgres, middleware)
+	maskingRulesRoutes := maskingRules.RegisterRoutes()
+
+	promptTemplates := promptTemplates.NewPromptTemplatesRouter(postgres, middleware, promptData)
+	promptTemplatesRoutes := promptTemplates.RegisterRoutes()
+
+	glossary := glossary.NewGlossaryRouter(postgres, middleware)
+	glossaryRoutes := glossary.RegisterRoutes()
+
 	routes := []system.Route{}
 
 	routes = append(routes, authenticationRoutes...)
@@ -65,13 +85,17 @@ func NewHttpRouter(postgres *postgres.Queries, zing *zing.Queries, radius *radiu
 	routes = append(routes, reportsRoutes...)
 	routes = append(routes, exportsRoutes...)
 	routes = append(routes, dynamicQueriesRoutes...)
+	routes = append(routes, mcpTokensRoutes...)
+	routes = append(routes, maskingRulesRoutes...)
+	routes = append(routes, promptTemplatesRoutes...)
+	routes = append(routes, glossaryRoutes...)
 
 	return &HttpRouter{
 		Routes:     routes,
 		Postgres:   postgres,
 		Middleware: middleware,
 		Sessions:   sessions,
-		Trino:      trino,
+		Trino:      trinoDb,
 	}
 }
 
@@ -200,31 +224,61 @@ func (h *HttpRouter) InitializeOpenAPI() *openapi3.T {
 		Paths: paths,
 		Components: &openapi3.Components{
 			Schemas: openapi3.Schemas{
-				"User":                    schemas.UserSchema,
-				"CreateUser":              schemas.CreateUserSchema,
-				"UpdateUser":              schemas.UpdateUserSchema,
-				"PointOfPresence":         schemas.PointOfPresenceSchema,
-				"PointsOfPresence":        schemas.PointsOfPresenceSchema,
-				"DynamicQuery":            schemas.DynamicQuerySchema,
-				"CreateDynamicQuery":      schemas.CreateDynamicQuerySchema,
-				"UpdateDynamicQuery":      schemas.UpdateDynamicQuerySchema,
-				"DynamicQueryResult":      schemas.DynamicQueryResultsSchema,
-				"LoginRequest":            schemas.LoginRequestSchema,
-				"PasswordReset":           schemas.PasswordResetSchema,
-				"SuccessResponse":         schemas.SuccessResponseSchema,
-				"ErrorResponse":           schemas.ErrorResponseSchema,
-				"RechargeTypeCounts":      schemas.RechargeTypeCountsSchema,
-				"ReportCustomer":          schemas.ReportCustomerSchema,
-				"ReportCustomers":         schemas.ReportCustomersSchema,
-				"ReportExpiringCustomer":  schemas.ReportExpiringCustomerSchema,
-				"ReportExpiringCustomers": schemas.ReportExpiringCustomersSchema,
-				"ReportRecharge":          schemas.ReportRechargeSchema,
-				"ReportRecharges":         schemas.ReportRechargesSchema,
-				"ReportRechargeSummary":   schemas.ReportRechargeSummarySchema,
-				"ReportRechargeSummaries": schemas.ReportRechargeSummariesSchema,
-				"ReportSummary":           schemas.ReportSummarySchema,
-				"ReportSummaries":         schemas.ReportSummariesSchema,
-				"MonthlyStatistics":       schemas.MonthlyStatisticsSchema,
+				"User":                       schemas.UserSchema,
+				"CreateUser":                 schemas.CreateUserSchema,
+				"UpdateUser":                 schemas.UpdateUserSchema,
+				"PointOfPresence":            schemas.PointOfPresenceSchema,
+				"PointsOfPresence":           schemas.PointsOfPresenceSchema,
+				"DynamicQuery":               schemas.DynamicQuerySchema,
+				"CreateDynamicQuery":         schemas.CreateDynamicQuerySchema,
+				"UpdateDynamicQuery":         schemas.UpdateDynamicQuerySchema,
+				"DynamicQueryResult":         schemas.DynamicQueryResultsSchema,
+				"DynamicQueryParameter":      schemas.DynamicQueryParameterSchema,
+				"DynamicQueryExplanation":    schemas.DynamicQueryExplanationSchema,
+				"DynamicQueryDryRun":         schemas.DynamicQueryDryRunSchema,
+				"DynamicQuerySql":            schemas.DynamicQuerySqlSchema,
+				"DynamicQuerySqlUpdate":      schemas.DynamicQuerySqlUpdateSchema,
+				"DynamicQueryVersion":        schemas.DynamicQueryVersionSchema,
+				"DynamicQueryVersionDiff":    schemas.DynamicQueryVersionDiffSchema,
+				"DynamicQueryJob":            schemas.DynamicQueryJobSchema,
+				"DynamicQueryRun":            schemas.DynamicQueryRunSchema,
+				"DynamicQueryMessage":        schemas.DynamicQueryMessageSchema,
+				"CreateDynamicQueryMessage":  schemas.CreateDynamicQueryMessageSchema,
+				"DynamicQuerySchedule":       schemas.DynamicQueryScheduleSchema,
+				"UpdateDynamicQuerySchedule": schemas.UpdateDynamicQueryScheduleSchema,
+				"DynamicQueryScheduleRun":    schemas.DynamicQueryScheduleRunSchema,
+				"TrinoExecution":             schemas.TrinoExecutionSchema,
+				"DynamicQueryShare":          schemas.DynamicQueryShareSchema,
+				"CreateDynamicQueryShare":    schemas.CreateDynamicQueryShareSchema,
+				"DynamicQueryFolder":         schemas.DynamicQueryFolderSchema,
+				"CreateDynamicQueryFolder":   schemas.CreateDynamicQueryFolderSchema,
+				"RecentDynamicQuery":         schemas.RecentDynamicQuerySchema,
+				"McpToken":                   schemas.McpTokenSchema,
+				"CreateMcpToken":             schemas.CreateMcpTokenSchema,
+				"CreatedMcpToken":            schemas.CreatedMcpTokenSchema,
+				"MaskingRule":                schemas.MaskingRuleSchema,
+				"GlossaryTerm":               schemas.GlossaryTermSchema,
+				"PromptTemplate":             schemas.PromptTemplateSchema,
+				"CreatePromptTemplate":       schemas.CreatePromptTemplateSchema,
+				"PromptTemplateVersion":      schemas.PromptTemplateVersionSchema,
+				"PreviewPromptTemplate":      schemas.PreviewPromptTemplateSchema,
+				"DynamicQueryPromptTemplate": schemas.DynamicQueryPromptTemplateSchema,
+				"LoginRequest":               schemas.LoginRequestSchema,
+				"PasswordReset":              schemas.PasswordResetSchema,
+				"SuccessResponse":            schemas.SuccessResponseSchema,
+				"ErrorResponse":              schemas.ErrorResponseSchema,
+				"RechargeTypeCounts":         schemas.RechargeTypeCountsSchema,
+				"ReportCustomer":             schemas.ReportCustomerSchema,
+				"ReportCustomers":            schemas.ReportCustomersSchema,
+				"ReportExpiringCustomer":     schemas.ReportExpiringCustomerSchema,
+				"ReportExpiringCustomers":    schemas.ReportExpiringCustomersSchema,
+				"ReportRecharge":             schemas.ReportRechargeSchema,
+				"ReportRecharges":            schemas.ReportRechargesSchema,
+				"ReportRechargeSummary":      schemas.ReportRechargeSummarySchema,
+				"ReportRechargeSummaries":    schemas.ReportRechargeSummariesSchema,
+				"ReportSummary":              schemas.ReportSummarySchema,
+				"ReportSummaries":            schemas.ReportSummariesSchema,
+				"MonthlyStatistics":          schemas.MonthlyStatisticsSchema,
 			},
 		},
 	}
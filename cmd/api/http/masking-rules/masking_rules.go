@@ -0,0 +1,94 @@
+package maskingRules
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/http/middleware"
+	"github.com/connor-davis/zingfibre-core/internal/masking"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/google/uuid"
+)
+
+type MaskingRulesRouter struct {
+	Postgres   *postgres.Queries
+	Middleware *middleware.Middleware
+}
+
+type MaskingRuleRequest struct {
+	Role              postgres.RoleType        `json:"role"`
+	ColumnName        string                   `json:"column_name"`
+	Strategy          postgres.MaskingStrategy `json:"strategy"`
+	VisibleCharacters int32                    `json:"visible_characters"`
+}
+
+func NewMaskingRulesRouter(postgres *postgres.Queries, middleware *middleware.Middleware) *MaskingRulesRouter {
+	return &MaskingRulesRouter{
+		Postgres:   postgres,
+		Middleware: middleware,
+	}
+}
+
+func (r *MaskingRulesRouter) RegisterRoutes() []system.Route {
+	return []system.Route{
+		r.GetMaskingRulesRoute(),
+		r.CreateMaskingRuleRoute(),
+		r.UpdateMaskingRuleRoute(),
+		r.DeleteMaskingRuleRoute(),
+	}
+}
+
+// validate trims the column name and returns a description of the first
+// problem with the request, or an empty string if there is none.
+func (request *MaskingRuleRequest) validate() string {
+	request.ColumnName = strings.TrimSpace(request.ColumnName)
+
+	roles := []postgres.RoleType{
+		postgres.RoleTypeAdmin,
+		postgres.RoleTypeStaff,
+		postgres.RoleTypeUser,
+	}
+
+	strategies := []postgres.MaskingStrategy{
+		postgres.MaskingStrategyRedact,
+		postgres.MaskingStrategyShowFirst,
+		postgres.MaskingStrategyShowLast,
+		postgres.MaskingStrategyEmail,
+		postgres.MaskingStrategyHash,
+	}
+
+	switch {
+	case !slices.Contains(roles, request.Role):
+		return fmt.Sprintf("The role %q is not valid.", request.Role)
+	case masking.Normalize(request.ColumnName) == "":
+		return "The column name is required."
+	case !slices.Contains(strategies, request.Strategy):
+		return fmt.Sprintf("The strategy %q is not valid.", request.Strategy)
+	case request.VisibleCharacters < 0:
+		return "The visible characters cannot be negative."
+	}
+
+	return ""
+}
+
+// conflicts reports whether another rule for the request's role already
+// covers its column. Column names are compared the way masking matches them,
+// so Phone Number and PhoneNumber conflict.
+func (r *MaskingRulesRouter) conflicts(ctx context.Context, request MaskingRuleRequest, id uuid.UUID) (bool, error) {
+	rules, err := r.Postgres.GetMaskingRulesByRole(ctx, request.Role)
+
+	if err != nil {
+		return false, err
+	}
+
+	for _, rule := range rules {
+		if rule.ID != id && masking.Normalize(rule.ColumnName) == masking.Normalize(request.ColumnName) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
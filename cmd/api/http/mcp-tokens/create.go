@@ -0,0 +1,296 @@
+package mcpTokens
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/http/middleware"
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CreateMcpTokenRequest struct {
+	Name     string            `json:"name"`
+	Tools    []string          `json:"tools"`
+	Catalogs []string          `json:"catalogs"`
+	Role     postgres.RoleType `json:"role"`
+	Pops     []string          `json:"pops"`
+}
+
+// CreatedMcpToken is returned once, when the token is created. The token is
+// not stored and cannot be retrieved again.
+type CreatedMcpToken struct {
+	McpToken
+	Token string
+}
+
+func (r *McpTokensRouter) CreateMcpTokenRoute() system.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("201", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.SuccessResponseSchema.Value,
+			).
+			WithDescription("MCP token created successfully.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"message": constants.Success,
+						"details": constants.SuccessDetails,
+						"data": map[string]any{
+							"Name":  "Claude Desktop",
+							"Token": "zmcp_...",
+						},
+					},
+					Schema: schemas.SuccessResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Bad Request.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.BadRequestError,
+						"details": constants.BadRequestErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Unauthorized.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.UnauthorizedError,
+						"details": constants.UnauthorizedErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("MCP token not found.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.NotFoundError,
+						"details": constants.NotFoundErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchema(
+				schemas.ErrorResponseSchema.Value,
+			).
+			WithDescription("Internal Server Error.").
+			WithContent(openapi3.Content{
+				"application/json": &openapi3.MediaType{
+					Example: map[string]any{
+						"error":   constants.InternalServerError,
+						"details": constants.InternalServerErrorDetails,
+					},
+					Schema: schemas.ErrorResponseSchema,
+				},
+			}),
+	})
+
+	return system.Route{
+		OpenAPIMetadata: system.OpenAPIMetadata{
+			Summary:     "Create MCP Token",
+			Description: "Endpoint to issue a token that can call the MCP server. Empty tools or catalogs allow every tool or catalog, and unknown tool or catalog names are rejected. The token is only returned in this response.",
+			Tags:        []string{"MCP Tokens"},
+			Parameters:  nil,
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: openapi3.NewRequestBody().WithJSONSchema(schemas.CreateMcpTokenSchema.Value),
+			},
+			Responses: responses,
+		},
+		Method: system.PostMethod,
+		Path:   "/mcp-tokens",
+		Middlewares: []fiber.Handler{
+			r.Middleware.Authorized(),
+			r.Middleware.HasRole(postgres.RoleTypeAdmin),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			var createMcpTokenRequest CreateMcpTokenRequest
+
+			if err := c.BodyParser(&createMcpTokenRequest); err != nil {
+				log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": constants.BadRequestErrorDetails,
+				})
+			}
+
+			name := strings.TrimSpace(createMcpTokenRequest.Name)
+
+			if name == "" {
+				log.Warn("⚠️ MCP token name is required")
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": "The MCP token name is required.",
+				})
+			}
+
+			if createMcpTokenRequest.Role == "" {
+				createMcpTokenRequest.Role = postgres.RoleTypeUser
+			}
+
+			if !slices.Contains([]postgres.RoleType{postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser}, createMcpTokenRequest.Role) {
+				log.Warnf("⚠️ Invalid MCP token role: %s", createMcpTokenRequest.Role)
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": fmt.Sprintf("The role %q is not valid.", createMcpTokenRequest.Role),
+				})
+			}
+
+			toolNames := trino.ToolNames()
+			tools, invalid := scopeList(createMcpTokenRequest.Tools, toolNames)
+
+			if invalid != nil {
+				log.Warnf("⚠️ Invalid MCP token tool: %s", *invalid)
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   constants.BadRequestError,
+					"details": fmt.Sprintf("The tool %q is not valid, use one of %s.", *invalid, strings.Join(toolNames, ", ")),
+				})
+			}
+
+			// Every catalog is allowed when the policy does not list them.
+			catalogs := trimList(createMcpTokenRequest.Catalogs)
+
+			if len(r.Policy.Catalogs) > 0 {
+				catalogs, invalid = scopeList(createMcpTokenRequest.Catalogs, r.Policy.Catalogs)
+
+				if invalid != nil {
+					log.Warnf("⚠️ Invalid MCP token catalog: %s", *invalid)
+
+					return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+						"error":   constants.BadRequestError,
+						"details": fmt.Sprintf("The catalog %q is not valid, use one of %s.", *invalid, strings.Join(r.Policy.Catalogs, ", ")),
+					})
+				}
+			}
+
+			token, hash, prefix, err := middleware.NewMcpToken()
+
+			if err != nil {
+				log.Errorf("🔥 Error generating MCP token: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			currentUser := c.Locals("user").(postgres.User)
+
+			mcpToken, err := r.Postgres.CreateMcpToken(c.Context(), postgres.CreateMcpTokenParams{
+				Name:        name,
+				TokenHash:   hash,
+				TokenPrefix: prefix,
+				Tools:       tools,
+				Catalogs:    catalogs,
+				Role:        createMcpTokenRequest.Role,
+				Pops:        trimList(createMcpTokenRequest.Pops),
+				CreatedBy:   pgtype.UUID{Bytes: currentUser.ID, Valid: true},
+			})
+
+			if err != nil {
+				log.Errorf("🔥 Error creating MCP token: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   constants.InternalServerError,
+					"details": constants.InternalServerErrorDetails,
+				})
+			}
+
+			return c.Status(fiber.StatusCreated).JSON(&fiber.Map{
+				"message": constants.Success,
+				"details": constants.SuccessDetails,
+				"data": CreatedMcpToken{
+					McpToken: McpToken{
+						ID:             mcpToken.ID,
+						Name:           mcpToken.Name,
+						TokenPrefix:    mcpToken.TokenPrefix,
+						Tools:          mcpToken.Tools,
+						Catalogs:       mcpToken.Catalogs,
+						Role:           mcpToken.Role,
+						Pops:           mcpToken.Pops,
+						CreatedBy:      mcpToken.CreatedBy,
+						CreatedByEmail: pgtype.Text{String: currentUser.Email, Valid: true},
+						ExpiresAt:      mcpToken.ExpiresAt,
+						CreatedAt:      mcpToken.CreatedAt,
+					},
+					Token: token,
+				},
+			})
+		},
+	}
+}
+
+// scopeList returns values trimmed and lowercased, or the first value that is
+// not in valid. A blank value is invalid rather than dropped, since a list
+// left empty would allow every tool or catalog.
+func scopeList(values []string, valid []string) ([]string, *string) {
+	list := []string{}
+
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+
+		if !slices.Contains(valid, value) {
+			return nil, &value
+		}
+
+		if !slices.Contains(list, value) {
+			list = append(list, value)
+		}
+	}
+
+	return list, nil
+}
+
+func trimList(values []string) []string {
+	list := []string{}
+
+	for _, value := range values {
+		if value = strings.ToLower(strings.TrimSpace(value)); value != "" {
+			list = append(list, value)
+		}
+	}
+
+	return list
+}
@@ -0,0 +1,49 @@
+package mcpTokens
+
+import (
+	"github.com/connor-davis/zingfibre-core/cmd/api/http/middleware"
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type McpTokensRouter struct {
+	Postgres   *postgres.Queries
+	Middleware *middleware.Middleware
+	Policy     trino.Policy
+}
+
+// McpToken is an MCP token as returned by the API, without its hash.
+type McpToken struct {
+	ID             uuid.UUID
+	Name           string
+	TokenPrefix    string
+	Tools          []string
+	Catalogs       []string
+	Role           postgres.RoleType
+	Pops           []string
+	CreatedBy      pgtype.UUID
+	CreatedByEmail pgtype.Text
+	LastUsedAt     pgtype.Timestamp
+	RevokedAt      pgtype.Timestamp
+	ExpiresAt      pgtype.Timestamptz
+	CreatedAt      pgtype.Timestamp
+}
+
+func NewMcpTokensRouter(postgres *postgres.Queries, middleware *middleware.Middleware, policy trino.Policy) *McpTokensRouter {
+	return &McpTokensRouter{
+		Postgres:   postgres,
+		Middleware: middleware,
+		Policy:     policy,
+	}
+}
+
+func (r *McpTokensRouter) RegisterRoutes() []system.Route {
+	return []system.Route{
+		r.GetMcpTokensRoute(),
+		r.CreateMcpTokenRoute(),
+		r.RevokeMcpTokenRoute(),
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/masking"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// Masked loads the masking rules for the role of the current user into the
+// "masking" local. It must run after Authorized.
+func (m *Middleware) Masked() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		currentUser := c.Locals("user").(postgres.User)
+
+		rules, err := m.Postgres.GetMaskingRulesByRole(c.Context(), currentUser.Role)
+
+		if err != nil {
+			log.Errorf("🔥 Error retrieving masking rules: %s", err.Error())
+
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   constants.InternalServerError,
+				"details": constants.InternalServerErrorDetails,
+			})
+		}
+
+		c.Locals("masking", masking.New(rules))
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/modelcontextprotocol/go-sdk/auth"
+)
+
+const mcpTokenPrefix = "zmcp_"
+
+// NewMcpToken returns a new random MCP token along with the hash and the
+// prefix stored for it. Only the hash is kept, so the token itself cannot be
+// shown again.
+func NewMcpToken() (string, string, string, error) {
+	secret := make([]byte, 32)
+
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", "", err
+	}
+
+	token := mcpTokenPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	return token, HashMcpToken(token), token[:len(mcpTokenPrefix)+6], nil
+}
+
+// HashMcpToken returns the hash an MCP token is stored and looked up under.
+func HashMcpToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// VerifyMcpToken checks a bearer token sent to /api/mcp against the tokens
+// that have not been revoked and carries its scopes into the MCP request.
+func (m *Middleware) VerifyMcpToken(ctx context.Context, token string, request *http.Request) (*auth.TokenInfo, error) {
+	mcpToken, err := m.Postgres.GetActiveMcpTokenByHash(ctx, HashMcpToken(token))
+
+	if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+		log.Errorf("🔥 Error retrieving MCP token: %s", err.Error())
+
+		return nil, err
+	}
+
+	if err != nil {
+		log.Warn("⚠️ Unauthorized MCP request: unknown or revoked token")
+
+		return nil, auth.ErrInvalidToken
+	}
+
+	if err := m.Postgres.TouchMcpToken(ctx, mcpToken.ID); err != nil {
+		log.Errorf("🔥 Error recording MCP token use: %s", err.Error())
+	}
+
+	// Tokens are looked up on every request, which only finds those that are
+	// neither revoked nor past their expiry, so either stops a token working
+	// straight away. The expiration only bounds how long this lookup is
+	// trusted.
+	expiration := time.Now().Add(time.Minute)
+
+	if mcpToken.ExpiresAt.Valid && mcpToken.ExpiresAt.Time.Before(expiration) {
+		expiration = mcpToken.ExpiresAt.Time
+	}
+
+	return &auth.TokenInfo{
+		Scopes:     trino.TokenScopes(mcpToken.Tools, mcpToken.Catalogs, mcpToken.Pops, mcpToken.Role),
+		Expiration: expiration,
+		Extra: map[string]any{
+			"id":   mcpToken.ID.String(),
+			"name": mcpToken.Name,
+		},
+	}, nil
+}
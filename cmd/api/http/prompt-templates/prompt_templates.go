@@ -0,0 +1,115 @@
+package promptTemplates
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/http/middleware"
+	"github.com/connor-davis/zingfibre-core/internal/ai"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type PromptTemplatesRouter struct {
+	Postgres   *postgres.Queries
+	Middleware *middleware.Middleware
+	PromptData ai.PromptData
+}
+
+type PromptTemplateRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Body        string `json:"body"`
+}
+
+func NewPromptTemplatesRouter(postgres *postgres.Queries, middleware *middleware.Middleware, promptData ai.PromptData) *PromptTemplatesRouter {
+	return &PromptTemplatesRouter{
+		Postgres:   postgres,
+		Middleware: middleware,
+		PromptData: promptData,
+	}
+}
+
+// RegisterRoutes returns the routes with fixed paths before the routes they
+// would otherwise be matched as an ID by.
+func (r *PromptTemplatesRouter) RegisterRoutes() []system.Route {
+	return []system.Route{
+		r.GetPromptTemplatesRoute(),
+		r.GetBuiltInPromptTemplateRoute(),
+		r.PreviewPromptTemplateRoute(),
+		r.ClearDefaultPromptTemplateRoute(),
+		r.GetPromptTemplateRoute(),
+		r.CreatePromptTemplateRoute(),
+		r.UpdatePromptTemplateRoute(),
+		r.DeletePromptTemplateRoute(),
+		r.SetDefaultPromptTemplateRoute(),
+		r.GetPromptTemplateVersionsRoute(),
+		r.RestorePromptTemplateVersionRoute(),
+	}
+}
+
+// validate trims the name and description and returns a description of the
+// first problem with the request, or an empty string if there is none. The
+// body is rendered with the data generation uses so that a template that
+// fails to parse or execute is never saved.
+func (r *PromptTemplatesRouter) validate(request *PromptTemplateRequest) string {
+	request.Name = strings.TrimSpace(request.Name)
+	request.Description = strings.TrimSpace(request.Description)
+
+	if request.Name == "" {
+		return "The name is required."
+	}
+
+	if strings.TrimSpace(request.Body) == "" {
+		return "The body is required."
+	}
+
+	if _, err := ai.RenderPrompt(request.Body, r.PromptData); err != nil {
+		return fmt.Sprintf("The body is not a valid prompt template: %s", err.Error())
+	}
+
+	return ""
+}
+
+// conflicts reports whether a prompt template other than id already has name.
+// Names are compared case insensitively.
+func (r *PromptTemplatesRouter) conflicts(ctx context.Context, name string, id uuid.UUID) (bool, error) {
+	promptTemplate, err := r.Postgres.GetPromptTemplateByName(ctx, name)
+
+	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return promptTemplate.ID != id, nil
+}
+
+// recordVersion stores the current body of promptTemplate as its current
+// version.
+func (r *PromptTemplatesRouter) recordVersion(ctx context.Context, promptTemplate postgres.PromptTemplate, createdBy pgtype.UUID) error {
+	_, err := r.Postgres.CreatePromptTemplateVersion(ctx, postgres.CreatePromptTemplateVersionParams{
+		PromptTemplateID: promptTemplate.ID,
+		Version:          promptTemplate.Version,
+		Body:             promptTemplate.Body,
+		CreatedBy:        createdBy,
+	})
+
+	return err
+}
+
+func currentUserID(c *fiber.Ctx) pgtype.UUID {
+	currentUser, ok := c.Locals("user").(postgres.User)
+
+	if !ok {
+		return pgtype.UUID{Valid: false}
+	}
+
+	return pgtype.UUID{Bytes: currentUser.ID, Valid: true}
+}
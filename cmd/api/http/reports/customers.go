@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/masking"
 	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
 	"github.com/connor-davis/zingfibre-core/internal/models/system"
 	"github.com/connor-davis/zingfibre-core/internal/mysql/zing"
@@ -148,11 +149,16 @@ func (r *ReportsRouter) CustomersRoute() system.Route {
 		Middlewares: []fiber.Handler{
 			r.Middleware.Authorized(),
 			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+			r.Middleware.Masked(),
 		},
 		Handler: func(c *fiber.Ctx) error {
 			poi := c.Query("poi")
 			search := c.Query("search")
 
+			if ok, err := maskedOption(c, "searched", search, "FullName", "FirstName", "Surname", "Email", "PhoneNumber", "RadiusUsername"); !ok {
+				return err
+			}
+
 			page := c.Query("page")
 			pageSize := c.Query("pageSize")
 
@@ -207,6 +213,8 @@ func (r *ReportsRouter) CustomersRoute() system.Route {
 				})
 			}
 
+			c.Locals("masking").(masking.Rules).MaskRows(data)
+
 			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
 				"message": constants.Success,
 				"details": constants.SuccessDetails,
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ahmetb/go-linq/v3"
 	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/masking"
 	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
 	"github.com/connor-davis/zingfibre-core/internal/models/system"
 	"github.com/connor-davis/zingfibre-core/internal/mysql/radius"
@@ -171,12 +172,23 @@ func (r *ReportsRouter) ExpiringCustomersRoute() system.Route {
 		Middlewares: []fiber.Handler{
 			r.Middleware.Authorized(),
 			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+			r.Middleware.Masked(),
 		},
 		Handler: func(c *fiber.Ctx) error {
 			poi := c.Query("poi")
 			search := c.Query("search")
+
+			if ok, err := maskedOption(c, "searched", search, "FullName", "Email", "PhoneNumber", "RadiusUsername", "LastPurchaseDuration", "LastPurchaseSpeed", "Address", "Expiration"); !ok {
+				return err
+			}
+
 			sort := c.Query("sort")
 
+			// A sort such as email_asc names the column it orders by.
+			if ok, err := maskedOption(c, "sorted", sort, strings.TrimSuffix(strings.TrimSuffix(sort, "_asc"), "_desc")); !ok {
+				return err
+			}
+
 			page := c.Query("page")
 			pageSize := c.Query("pageSize")
 
@@ -395,6 +407,8 @@ func (r *ReportsRouter) ExpiringCustomersRoute() system.Route {
 				Take(pageSizeInt).
 				Results()
 
+			c.Locals("masking").(masking.Rules).MaskRows(data)
+
 			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
 				"message": constants.Success,
 				"details": constants.SuccessDetails,
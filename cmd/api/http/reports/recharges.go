@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/masking"
 	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
 	"github.com/connor-davis/zingfibre-core/internal/models/system"
 	"github.com/connor-davis/zingfibre-core/internal/mysql/zing"
@@ -186,11 +187,16 @@ func (r *ReportsRouter) RechargesRoute() system.Route {
 		Middlewares: []fiber.Handler{
 			r.Middleware.Authorized(),
 			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+			r.Middleware.Masked(),
 		},
 		Handler: func(c *fiber.Ctx) error {
 			poi := c.Query("poi")
 			search := c.Query("search")
 
+			if ok, err := maskedOption(c, "searched", search, "FullName", "FirstName", "Surname", "Email", "Amount", "PaymentAmount", "ServiceId", "BuildName", "BuildType"); !ok {
+				return err
+			}
+
 			startDate := c.Query("startDate")
 			endDate := c.Query("endDate")
 
@@ -292,6 +298,8 @@ func (r *ReportsRouter) RechargesRoute() system.Route {
 				})
 			}
 
+			c.Locals("masking").(masking.Rules).MaskRows(data)
+
 			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
 				"message": constants.Success,
 				"details": constants.SuccessDetails,
@@ -443,11 +451,16 @@ func (r *ReportsRouter) RechargesSummaryRoute() system.Route {
 		Middlewares: []fiber.Handler{
 			r.Middleware.Authorized(),
 			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+			r.Middleware.Masked(),
 		},
 		Handler: func(c *fiber.Ctx) error {
 			poi := c.Query("poi")
 			search := c.Query("search")
 
+			if ok, err := maskedOption(c, "searched", search, "FullName", "FirstName", "Surname", "Email", "Amount", "PaymentAmount", "ServiceId", "BuildName", "BuildType"); !ok {
+				return err
+			}
+
 			page := c.Query("page")
 			pageSize := c.Query("pageSize")
 
@@ -520,6 +533,8 @@ func (r *ReportsRouter) RechargesSummaryRoute() system.Route {
 				})
 			}
 
+			c.Locals("masking").(masking.Rules).MaskRows(data)
+
 			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
 				"message": constants.Success,
 				"details": constants.SuccessDetails,
@@ -1,10 +1,17 @@
 package reports
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/connor-davis/zingfibre-core/cmd/api/http/middleware"
+	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/masking"
 	"github.com/connor-davis/zingfibre-core/internal/models/system"
 	"github.com/connor-davis/zingfibre-core/internal/mysql/radius"
 	"github.com/connor-davis/zingfibre-core/internal/mysql/zing"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
 	"github.com/gofiber/fiber/v2/middleware/session"
 )
 
@@ -39,3 +46,27 @@ func (r *ReportsRouter) RegisterRoutes() []system.Route {
 		summaryRoute,
 	}
 }
+
+// maskedOption refuses a search or sort, named by use, over columns when the
+// role of the current user masks any of them, since the rows it returns or
+// their order would reveal the values that are masked. value is the search
+// or sort that was asked for. When it returns false the error response has
+// already been sent.
+func maskedOption(c *fiber.Ctx, use string, value string, columns ...string) (bool, error) {
+	if strings.TrimSpace(value) == "" {
+		return true, nil
+	}
+
+	rule, ok := c.Locals("masking").(masking.Rules).Rule(columns...)
+
+	if !ok {
+		return true, nil
+	}
+
+	log.Warnf("⚠️ Refusing report %s on masked column %s", use, rule.ColumnName)
+
+	return false, c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+		"error":   constants.BadRequestError,
+		"details": fmt.Sprintf("%s is masked and cannot be %s.", rule.ColumnName, use),
+	})
+}
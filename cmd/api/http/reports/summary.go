@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/connor-davis/zingfibre-core/internal/constants"
+	"github.com/connor-davis/zingfibre-core/internal/masking"
 	"github.com/connor-davis/zingfibre-core/internal/models/schemas"
 	"github.com/connor-davis/zingfibre-core/internal/models/system"
 	"github.com/connor-davis/zingfibre-core/internal/mysql/zing"
@@ -168,11 +169,16 @@ func (r *ReportsRouter) SummaryRoute() system.Route {
 		Middlewares: []fiber.Handler{
 			r.Middleware.Authorized(),
 			r.Middleware.HasAnyRole(postgres.RoleTypeAdmin, postgres.RoleTypeStaff, postgres.RoleTypeUser),
+			r.Middleware.Masked(),
 		},
 		Handler: func(c *fiber.Ctx) error {
 			poi := c.Query("poi")
 			search := c.Query("search")
 
+			if ok, err := maskedOption(c, "searched", search, "ItemName", "RadiusUsername", "Amount", "PaymentAmount", "ServiceId", "BuildName", "BuildType"); !ok {
+				return err
+			}
+
 			months := c.Query("months")
 
 			monthsInt, err := strconv.Atoi(months)
@@ -249,6 +255,8 @@ func (r *ReportsRouter) SummaryRoute() system.Route {
 				})
 			}
 
+			c.Locals("masking").(masking.Rules).MaskRows(data)
+
 			return c.Status(fiber.StatusOK).JSON(&fiber.Map{
 				"message": constants.Success,
 				"details": constants.SuccessDetails,
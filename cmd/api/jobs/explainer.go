@@ -0,0 +1,80 @@
+package jobs
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// explainTimeout bounds how long the model may take to explain one query.
+const explainTimeout = time.Minute
+
+// explain keeps the explanation of every dynamic query in step with its SQL.
+// Changing the SQL clears the explanation, which queues the dynamic query to
+// be explained again here.
+func (j *jobs) explain(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		dynamicQuery, err := j.postgres.ClaimDynamicQueryExplanation(ctx, staleAfter.Seconds())
+
+		if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+			log.Errorf("🔥 Error claiming dynamic query explanation: %s", err.Error())
+
+			continue
+		}
+
+		if err != nil {
+			continue
+		}
+
+		j.explainDynamicQuery(ctx, dynamicQuery)
+	}
+}
+
+func (j *jobs) explainDynamicQuery(parent context.Context, dynamicQuery postgres.DynamicQuery) {
+	ctx, cancel := context.WithTimeout(parent, explainTimeout)
+
+	defer cancel()
+
+	explanation, err := j.ai.ExplainDynamicQuery(ctx, dynamicQuery)
+
+	if parent.Err() != nil {
+		return
+	}
+
+	params := postgres.UpdateDynamicQueryExplanationParams{
+		Explanation: &explanation,
+		ID:          dynamicQuery.ID,
+		Query:       dynamicQuery.Query,
+	}
+
+	if err != nil {
+		log.Warnf("⚠️ Dynamic query %s could not be explained: %s", dynamicQuery.ID, err.Error())
+
+		params.Explanation = nil
+		params.ExplanationError = pgtype.Text{String: err.Error(), Valid: true}
+	}
+
+	// The update is skipped if the SQL changed in the meantime, in which case
+	// the new SQL is explained on a later tick.
+	if err := j.postgres.UpdateDynamicQueryExplanation(context.Background(), params); err != nil {
+		log.Errorf("🔥 Error updating dynamic query explanation: %s", err.Error())
+
+		return
+	}
+
+	log.Infof("✅ Dynamic query %s explained", dynamicQuery.ID)
+}
@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/ai"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// Events written to dynamic_query_job_events and relayed to subscribers.
+const (
+	EventProgress  = "current_type"
+	EventCompleted = "response_completed"
+	EventError     = "error"
+	EventCancelled = "cancelled"
+	EventDone      = "done"
+)
+
+const (
+	pollInterval      = time.Second
+	heartbeatInterval = 5 * time.Second
+	reapInterval      = 30 * time.Second
+	staleAfter        = 2 * time.Minute
+)
+
+type Jobs interface {
+	Start(ctx context.Context)
+}
+
+type jobs struct {
+	postgres   *postgres.Queries
+	ai         ai.AI
+	policy     trino.Policy
+	promptData ai.PromptData
+	workers    int
+}
+
+func New(postgres *postgres.Queries, generator ai.AI, policy trino.Policy, promptData ai.PromptData, workers int) Jobs {
+	return &jobs{
+		postgres:   postgres,
+		ai:         generator,
+		policy:     policy,
+		promptData: promptData,
+		workers:    max(workers, 1),
+	}
+}
+
+// Start launches the worker pool, the stale job reaper and the explainer. They
+// run until ctx is cancelled.
+func (j *jobs) Start(ctx context.Context) {
+	log.Infof("✅ Starting %d dynamic query generation workers", j.workers)
+
+	for range j.workers {
+		go j.work(ctx)
+	}
+
+	go j.reap(ctx)
+	go j.explain(ctx)
+}
+
+// IsFinished reports whether a job has reached a terminal status.
+func IsFinished(status postgres.DynamicQueryJobStatus) bool {
+	switch status {
+	case postgres.DynamicQueryJobStatusComplete, postgres.DynamicQueryJobStatusError, postgres.DynamicQueryJobStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,62 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/internal/ai"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// systemPrompt renders the prompt template selected for dynamicQuery, the
+// default prompt template when none is selected, or the built-in template
+// when there is no default either, with the current glossary. The template
+// and version used are recorded on job.
+func (j *jobs) systemPrompt(ctx context.Context, job postgres.DynamicQueryJob, dynamicQuery postgres.DynamicQuery) (string, error) {
+	var promptTemplate postgres.PromptTemplate
+	var err error
+
+	if dynamicQuery.PromptTemplateID.Valid {
+		promptTemplate, err = j.postgres.GetPromptTemplate(ctx, dynamicQuery.PromptTemplateID.Bytes)
+	} else {
+		promptTemplate, err = j.postgres.GetDefaultPromptTemplate(ctx)
+	}
+
+	if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+		return "", fmt.Errorf("unable to load the prompt template: %w", err)
+	}
+
+	body := ai.DefaultPromptTemplate
+	promptTemplateID := pgtype.UUID{}
+	promptTemplateVersion := pgtype.Int4{}
+
+	if err == nil {
+		body = promptTemplate.Body
+		promptTemplateID = pgtype.UUID{Bytes: promptTemplate.ID, Valid: true}
+		promptTemplateVersion = pgtype.Int4{Int32: promptTemplate.Version, Valid: true}
+	}
+
+	promptData := j.promptData
+
+	if promptData.Glossary, err = ai.LoadGlossary(ctx, j.postgres); err != nil {
+		return "", fmt.Errorf("unable to load the glossary: %w", err)
+	}
+
+	systemPrompt, err := ai.RenderPrompt(body, promptData)
+
+	if err != nil {
+		return "", fmt.Errorf("unable to render the prompt template: %w", err)
+	}
+
+	if err := j.postgres.RecordDynamicQueryJobPromptTemplate(ctx, postgres.RecordDynamicQueryJobPromptTemplateParams{
+		ID:                    job.ID,
+		PromptTemplateID:      promptTemplateID,
+		PromptTemplateVersion: promptTemplateVersion,
+	}); err != nil {
+		return "", fmt.Errorf("unable to record the prompt template: %w", err)
+	}
+
+	return systemPrompt, nil
+}
@@ -0,0 +1,71 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// reap periodically fails running jobs whose worker has stopped sending
+// heartbeats, for example because the API was restarted mid generation.
+func (j *jobs) reap(ctx context.Context) {
+	ticker := time.NewTicker(reapInterval)
+
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		reason := fmt.Sprintf("the generation worker stopped responding for more than %s", staleAfter)
+
+		staleJobs, err := j.postgres.FailStaleDynamicQueryJobs(ctx, postgres.FailStaleDynamicQueryJobsParams{
+			Error:        pgtype.Text{String: reason, Valid: true},
+			StaleSeconds: staleAfter.Seconds(),
+		})
+
+		if err != nil {
+			log.Errorf("🔥 Error failing stale dynamic query jobs: %s", err.Error())
+
+			continue
+		}
+
+		for _, job := range staleJobs {
+			log.Warnf("⚠️ Dynamic query job %s is stale: %s", job.ID, reason)
+
+			if job.MessageID.Valid {
+				if err := j.postgres.FailDynamicQueryMessage(ctx, postgres.FailDynamicQueryMessageParams{
+					ID:    job.MessageID.Bytes,
+					Error: pgtype.Text{String: reason, Valid: true},
+				}); err != nil {
+					log.Errorf("🔥 Error updating dynamic query message: %s", err.Error())
+				}
+
+				j.emit(job.ID, EventError, reason)
+
+				continue
+			}
+
+			dynamicQuery, err := j.postgres.GetDynamicQuery(ctx, job.DynamicQueryID)
+
+			if err == nil && dynamicQuery.Status == postgres.DynamicQueryStatusInProgress {
+				if _, err := j.postgres.SetDynamicQueryStatus(ctx, postgres.SetDynamicQueryStatusParams{
+					Status:     postgres.DynamicQueryStatusError,
+					ResponseID: pgtype.Text{},
+					ID:         dynamicQuery.ID,
+				}); err != nil {
+					log.Errorf("🔥 Error updating dynamic query: %s", err.Error())
+				}
+			}
+
+			j.emit(job.ID, EventError, reason)
+		}
+	}
+}
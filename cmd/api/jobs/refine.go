@@ -0,0 +1,120 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/ai"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// refine generates the revision asked for by the message that job was queued
+// for. The revision builds on the proposal of the parent message, or on the
+// live dynamic query when there is none, and is stored on the message as a
+// proposal. It only replaces the live dynamic query once it is accepted.
+func (j *jobs) refine(ctx context.Context, job postgres.DynamicQueryJob, dynamicQuery postgres.DynamicQuery, systemPrompt string) {
+	message, err := j.postgres.GetDynamicQueryMessage(ctx, postgres.GetDynamicQueryMessageParams{
+		ID:             job.MessageID.Bytes,
+		DynamicQueryID: dynamicQuery.ID,
+	})
+
+	if err != nil {
+		j.fail(job, dynamicQuery, pgtype.Text{}, fmt.Sprintf("unable to load the dynamic query message: %s", err.Error()))
+
+		return
+	}
+
+	sqlQuery := dynamicQuery.Query
+	parameters := dynamicQuery.Parameters
+	responseID := dynamicQuery.ResponseID
+
+	if message.ParentID.Valid {
+		parent, err := j.postgres.GetDynamicQueryMessage(ctx, postgres.GetDynamicQueryMessageParams{
+			ID:             message.ParentID.Bytes,
+			DynamicQueryID: dynamicQuery.ID,
+		})
+
+		if err == nil && parent.SqlQuery.Valid {
+			sqlQuery = parent.SqlQuery
+			parameters = parent.Parameters
+			responseID = parent.ResponseID
+		}
+	}
+
+	log.Infof("Refining dynamic query for Query ID: %s with message: %s", dynamicQuery.ID, message.Content)
+
+	refinement := dynamicQuery
+	refinement.Prompt = ai.RefinementPrompt(dynamicQuery.Prompt, sqlQuery.String, parameters, message.Content)
+	refinement.ResponseID = responseID
+
+	output, err := j.ai.GenerateDynamicQuery(ctx, refinement, systemPrompt, j.progress(job.ID))
+
+	j.recordResult(job.ID, output)
+
+	if ctx.Err() != nil {
+		log.Warnf("⚠️ Dynamic query job %s stopped before it finished", job.ID)
+
+		return
+	}
+
+	if output.ResponseID != "" {
+		responseID = pgtype.Text{String: output.ResponseID, Valid: true}
+	}
+
+	if err != nil {
+		j.fail(job, dynamicQuery, responseID, err.Error())
+
+		return
+	}
+
+	if err := trino.ValidateQuery(output.SqlQuery, j.policy); err != nil {
+		j.fail(job, dynamicQuery, responseID, err.Error())
+
+		return
+	}
+
+	if err := trino.ValidateParameters(output.SqlQuery, output.Parameters); err != nil {
+		j.fail(job, dynamicQuery, responseID, err.Error())
+
+		return
+	}
+
+	if _, err := j.postgres.FinishDynamicQueryJob(context.Background(), postgres.FinishDynamicQueryJobParams{
+		ID:     job.ID,
+		Status: postgres.DynamicQueryJobStatusComplete,
+		Error:  pgtype.Text{},
+	}); err != nil {
+		log.Warnf("⚠️ Dynamic query job %s could not be completed: %s", job.ID, err.Error())
+
+		return
+	}
+
+	if _, err := j.postgres.ProposeDynamicQueryMessage(context.Background(), postgres.ProposeDynamicQueryMessageParams{
+		ID:             message.ID,
+		SqlQuery:       pgtype.Text{String: output.SqlQuery, Valid: true},
+		Parameters:     output.Parameters,
+		ThoughtProcess: pgtype.Text{String: output.ThoughtProcess, Valid: output.ThoughtProcess != ""},
+		ResponseID:     responseID,
+	}); err != nil {
+		log.Errorf("🔥 Error updating dynamic query message: %s", err.Error())
+
+		j.emit(job.ID, EventError, "unable to save the proposed revision")
+
+		return
+	}
+
+	payload, err := json.Marshal(output)
+
+	if err != nil {
+		log.Errorf("🔥 Error marshaling dynamic query output: %s", err.Error())
+	}
+
+	j.emit(job.ID, EventCompleted, string(payload))
+	j.emit(job.ID, EventDone, "")
+
+	log.Infof("✅ Dynamic query job %s proposed a revision", job.ID)
+}
@@ -0,0 +1,295 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/ai"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (j *jobs) work(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		job, err := j.postgres.ClaimDynamicQueryJob(ctx)
+
+		if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+			log.Errorf("🔥 Error claiming dynamic query job: %s", err.Error())
+
+			continue
+		}
+
+		if err != nil {
+			continue
+		}
+
+		j.run(ctx, job)
+	}
+}
+
+func (j *jobs) run(parent context.Context, job postgres.DynamicQueryJob) {
+	ctx, cancel := context.WithCancel(parent)
+
+	defer cancel()
+
+	go j.heartbeat(ctx, cancel, job.ID)
+
+	dynamicQuery, err := j.postgres.GetDynamicQuery(ctx, job.DynamicQueryID)
+
+	if err != nil {
+		j.fail(job, dynamicQuery, pgtype.Text{}, fmt.Sprintf("unable to load the dynamic query: %s", err.Error()))
+
+		return
+	}
+
+	systemPrompt, err := j.systemPrompt(ctx, job, dynamicQuery)
+
+	if err != nil {
+		j.fail(job, dynamicQuery, pgtype.Text{}, err.Error())
+
+		return
+	}
+
+	if job.MessageID.Valid {
+		j.refine(ctx, job, dynamicQuery, systemPrompt)
+
+		return
+	}
+
+	log.Infof("Generating dynamic query for Query ID: %s with prompt: %s", dynamicQuery.ID, dynamicQuery.Prompt)
+
+	output, err := j.ai.GenerateDynamicQuery(ctx, dynamicQuery, systemPrompt, j.progress(job.ID))
+
+	j.recordResult(job.ID, output)
+
+	if ctx.Err() != nil {
+		log.Warnf("⚠️ Dynamic query job %s stopped before it finished", job.ID)
+
+		return
+	}
+
+	responseID := dynamicQuery.ResponseID
+
+	if output.ResponseID != "" {
+		responseID = pgtype.Text{String: output.ResponseID, Valid: true}
+	}
+
+	if err != nil {
+		j.fail(job, dynamicQuery, responseID, err.Error())
+
+		return
+	}
+
+	if err := trino.ValidateQuery(output.SqlQuery, j.policy); err != nil {
+		j.fail(job, dynamicQuery, responseID, err.Error())
+
+		return
+	}
+
+	if err := trino.ValidateParameters(output.SqlQuery, output.Parameters); err != nil {
+		j.fail(job, dynamicQuery, responseID, err.Error())
+
+		return
+	}
+
+	// Finishing the job first means a job that was cancelled or reaped in the
+	// meantime never overwrites the dynamic query.
+	if _, err := j.postgres.FinishDynamicQueryJob(context.Background(), postgres.FinishDynamicQueryJobParams{
+		ID:     job.ID,
+		Status: postgres.DynamicQueryJobStatusComplete,
+		Error:  pgtype.Text{},
+	}); err != nil {
+		log.Warnf("⚠️ Dynamic query job %s could not be completed: %s", job.ID, err.Error())
+
+		return
+	}
+
+	// Only the generated columns are written, so a rename or prompt edit
+	// made while the job ran is kept.
+	generatedDynamicQuery, err := j.postgres.SetDynamicQueryGenerated(context.Background(), postgres.SetDynamicQueryGeneratedParams{
+		Query:      pgtype.Text{String: output.SqlQuery, Valid: true},
+		ResponseID: responseID,
+		Status:     postgres.DynamicQueryStatusComplete,
+		Parameters: output.Parameters,
+		ID:         dynamicQuery.ID,
+	})
+
+	if err != nil {
+		log.Errorf("🔥 Error updating dynamic query: %s", err.Error())
+
+		j.emit(job.ID, EventError, "unable to save the generated dynamic query")
+
+		return
+	}
+
+	if _, err := j.postgres.CreateDynamicQueryVersion(context.Background(), postgres.CreateDynamicQueryVersionParams{
+		DynamicQueryID: generatedDynamicQuery.ID,
+		Query:          generatedDynamicQuery.Query,
+		Prompt:         generatedDynamicQuery.Prompt,
+		ResponseID:     generatedDynamicQuery.ResponseID,
+		Parameters:     generatedDynamicQuery.Parameters,
+		CreatedBy:      job.CreatedBy,
+	}); err != nil {
+		log.Errorf("🔥 Error recording dynamic query version: %s", err.Error())
+	}
+
+	// Cached results belong to the previous SQL.
+	if err := j.postgres.DeleteDynamicQueryResultCaches(context.Background(), generatedDynamicQuery.ID); err != nil {
+		log.Errorf("🔥 Error clearing cached dynamic query results: %s", err.Error())
+	}
+
+	payload, err := json.Marshal(output)
+
+	if err != nil {
+		log.Errorf("🔥 Error marshaling dynamic query output: %s", err.Error())
+	}
+
+	j.emit(job.ID, EventCompleted, string(payload))
+	j.emit(job.ID, EventDone, "")
+
+	log.Infof("✅ Dynamic query job %s completed", job.ID)
+}
+
+// progress records the tool calls of a job and relays its progress to
+// subscribers.
+func (j *jobs) progress(id uuid.UUID) func(ai.Progress) {
+	return func(progress ai.Progress) {
+		if progress.ToolCall != nil {
+			j.recordToolCall(id, *progress.ToolCall)
+		}
+
+		payload, err := json.Marshal(progress.Type)
+
+		if err != nil {
+			return
+		}
+
+		j.emit(id, EventProgress, string(payload))
+	}
+}
+
+// heartbeat keeps the job marked as alive and cancels ctx as soon as the job
+// is no longer running, which is how cancellation reaches the worker.
+func (j *jobs) heartbeat(ctx context.Context, cancel context.CancelFunc, id uuid.UUID) {
+	ticker := time.NewTicker(heartbeatInterval)
+
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		_, err := j.postgres.HeartbeatDynamicQueryJob(ctx, id)
+
+		if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+			log.Warnf("⚠️ Dynamic query job %s is no longer running, stopping it", id)
+
+			cancel()
+
+			return
+		}
+
+		if err != nil && ctx.Err() == nil {
+			log.Errorf("🔥 Error recording dynamic query job heartbeat: %s", err.Error())
+		}
+	}
+}
+
+// fail marks the job and its dynamic query as errored and tells subscribers
+// why. A refinement only marks its message as errored, the dynamic query is
+// left as it was.
+func (j *jobs) fail(job postgres.DynamicQueryJob, dynamicQuery postgres.DynamicQuery, responseID pgtype.Text, reason string) {
+	log.Errorf("🔥 Dynamic query job %s failed: %s", job.ID, reason)
+
+	if _, err := j.postgres.FinishDynamicQueryJob(context.Background(), postgres.FinishDynamicQueryJobParams{
+		ID:     job.ID,
+		Status: postgres.DynamicQueryJobStatusError,
+		Error:  pgtype.Text{String: reason, Valid: true},
+	}); err != nil {
+		log.Warnf("⚠️ Dynamic query job %s could not be marked as failed: %s", job.ID, err.Error())
+
+		return
+	}
+
+	if job.MessageID.Valid {
+		if err := j.postgres.FailDynamicQueryMessage(context.Background(), postgres.FailDynamicQueryMessageParams{
+			ID:    job.MessageID.Bytes,
+			Error: pgtype.Text{String: reason, Valid: true},
+		}); err != nil {
+			log.Errorf("🔥 Error updating dynamic query message: %s", err.Error())
+		}
+	} else if dynamicQuery.ID != uuid.Nil {
+		if _, err := j.postgres.SetDynamicQueryStatus(context.Background(), postgres.SetDynamicQueryStatusParams{
+			Status:     postgres.DynamicQueryStatusError,
+			ResponseID: responseID,
+			ID:         dynamicQuery.ID,
+		}); err != nil {
+			log.Errorf("🔥 Error updating dynamic query: %s", err.Error())
+		}
+	}
+
+	j.emit(job.ID, EventError, reason)
+}
+
+// recordToolCall stores a finished tool call in the transcript of the job.
+func (j *jobs) recordToolCall(id uuid.UUID, toolCall ai.ToolCall) {
+	if _, err := j.postgres.CreateDynamicQueryJobToolCall(context.Background(), postgres.CreateDynamicQueryJobToolCallParams{
+		JobID:     id,
+		Name:      toolCall.Name,
+		Arguments: toolCall.Arguments,
+		Output:    pgtype.Text{String: toolCall.Output, Valid: toolCall.Output != ""},
+		Error:     pgtype.Text{String: toolCall.Error, Valid: toolCall.Error != ""},
+		LatencyMs: toolCall.Latency.Milliseconds(),
+		StartedAt: pgtype.Timestamp{Time: toolCall.StartedAt, Valid: true},
+	}); err != nil {
+		log.Errorf("🔥 Error recording dynamic query job tool call: %s", err.Error())
+	}
+}
+
+// recordResult stores whatever the model returned, even when the run goes on
+// to fail, so that the transcript shows what was produced.
+func (j *jobs) recordResult(id uuid.UUID, output ai.GenerateDynamicQueryOutput) {
+	if _, err := j.postgres.RecordDynamicQueryJobResult(context.Background(), postgres.RecordDynamicQueryJobResultParams{
+		ID:             id,
+		ThoughtProcess: pgtype.Text{String: output.ThoughtProcess, Valid: output.ThoughtProcess != ""},
+		SqlQuery:       pgtype.Text{String: output.SqlQuery, Valid: output.SqlQuery != ""},
+		Parameters:     output.Parameters,
+		ResponseID:     pgtype.Text{String: output.ResponseID, Valid: output.ResponseID != ""},
+		InputTokens:    output.Usage.InputTokens,
+		OutputTokens:   output.Usage.OutputTokens,
+		TotalTokens:    output.Usage.TotalTokens,
+	}); err != nil {
+		log.Errorf("🔥 Error recording dynamic query job result: %s", err.Error())
+	}
+}
+
+// emit records an event for the job so that current and future subscribers
+// receive it in order.
+func (j *jobs) emit(id uuid.UUID, event string, data string) {
+	if _, err := j.postgres.CreateDynamicQueryJobEvent(context.Background(), postgres.CreateDynamicQueryJobEventParams{
+		JobID: id,
+		Event: event,
+		Data:  data,
+	}); err != nil {
+		log.Errorf("🔥 Error recording dynamic query job event: %s", err.Error())
+	}
+}
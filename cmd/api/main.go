@@ -5,13 +5,21 @@ import (
 	"database/sql"
 	"fmt"
 	netHttp "net/http"
+	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/MarceloPetrucio/go-scalar-api-reference"
 	"github.com/connor-davis/zingfibre-core/cmd/api/http"
 	"github.com/connor-davis/zingfibre-core/cmd/api/http/middleware"
+	"github.com/connor-davis/zingfibre-core/cmd/api/jobs"
+	"github.com/connor-davis/zingfibre-core/cmd/api/reports"
+	"github.com/connor-davis/zingfibre-core/cmd/api/schedules"
 	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
 	"github.com/connor-davis/zingfibre-core/common"
+	"github.com/connor-davis/zingfibre-core/internal/ai"
+	"github.com/connor-davis/zingfibre-core/internal/mail"
 	"github.com/connor-davis/zingfibre-core/internal/mysql/radius"
 	"github.com/connor-davis/zingfibre-core/internal/mysql/zing"
 	"github.com/connor-davis/zingfibre-core/internal/postgres"
@@ -22,7 +30,9 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/modelcontextprotocol/go-sdk/auth"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"golang.org/x/crypto/bcrypt"
 
@@ -30,6 +40,16 @@ import (
 	_ "github.com/trinodb/trino-go-client/trino"
 )
 
+// generationMcpTokenName names the MCP token each instance issues on startup
+// for dynamic query generation, followed by the instance it belongs to, so
+// that a restart only replaces the token of its own instance.
+const generationMcpTokenName = "Dynamic query generation"
+
+// generationMcpTokenTTL is how long the generation MCP token stays valid
+// without being renewed, so the token of a stopped instance expires on its
+// own. A running instance renews it every quarter of that.
+const generationMcpTokenTTL = time.Hour
+
 func main() {
 	context := context.Background()
 
@@ -84,7 +104,7 @@ func main() {
 	log.Info("✅ Connected to PostgreSQL successfully")
 	log.Info("🔃 Connecting to TrinoDB database...")
 
-	trinoDsn := "http://user@trino:8080"
+	trinoDsn := common.EnvString("TRINO_DSN", "http://user@trino:8080")
 	trinoDb, err := sql.Open("trino", trinoDsn)
 
 	if err != nil {
@@ -135,6 +155,128 @@ func main() {
 		log.Infof("✅ Admin user already exists: %s", existingAdmin.Email)
 	}
 
+	generationWorkers, err := strconv.Atoi(common.EnvString("GENERATION_WORKERS", "2"))
+
+	if err != nil {
+		log.Warnf("⚠️ Invalid GENERATION_WORKERS value, defaulting to 2: %s", err.Error())
+
+		generationWorkers = 2
+	}
+
+	aiConfig, err := ai.ConfigFromEnv()
+
+	if err != nil {
+		log.Errorf("🔥 Invalid AI configuration: %s", err.Error())
+
+		return
+	}
+
+	if aiConfig.MCPToken == "" {
+		log.Info("🔃 Issuing the MCP token used for dynamic query generation...")
+
+		instance := common.EnvString("INSTANCE_ID", "")
+
+		if instance == "" {
+			if instance, err = os.Hostname(); err != nil {
+				log.Errorf("🔥 Error reading the hostname for the generation MCP token, set INSTANCE_ID instead: %s", err.Error())
+
+				return
+			}
+		}
+
+		tokenName := fmt.Sprintf("%s (%s)", generationMcpTokenName, instance)
+
+		if err := postgresQueries.RevokeSystemMcpTokens(context, tokenName); err != nil {
+			log.Errorf("🔥 Error revoking earlier generation MCP tokens: %s", err.Error())
+
+			return
+		}
+
+		token, hash, prefix, err := middleware.NewMcpToken()
+
+		if err != nil {
+			log.Errorf("🔥 Error generating the generation MCP token: %s", err.Error())
+
+			return
+		}
+
+		mcpToken, err := postgresQueries.CreateMcpToken(context, postgres.CreateMcpTokenParams{
+			Name:        tokenName,
+			TokenHash:   hash,
+			TokenPrefix: prefix,
+			Tools:       ai.GenerationTools,
+			Catalogs:    []string{},
+			Role:        postgres.RoleTypeUser,
+			Pops:        []string{},
+			CreatedBy:   pgtype.UUID{},
+			ExpiresAt:   pgtype.Timestamptz{Time: time.Now().Add(generationMcpTokenTTL), Valid: true},
+		})
+
+		if err != nil {
+			log.Errorf("🔥 Error creating the generation MCP token: %s", err.Error())
+
+			return
+		}
+
+		go func() {
+			ticker := time.NewTicker(generationMcpTokenTTL / 4)
+
+			defer ticker.Stop()
+
+			for range ticker.C {
+				if err := postgresQueries.ExtendMcpToken(context, postgres.ExtendMcpTokenParams{
+					TtlSeconds: generationMcpTokenTTL.Seconds(),
+					ID:         mcpToken.ID,
+				}); err != nil {
+					log.Errorf("🔥 Error renewing the generation MCP token: %s", err.Error())
+				}
+			}
+		}()
+
+		aiConfig.MCPToken = token
+
+		log.Info("✅ Generation MCP token issued successfully")
+	}
+
+	generator, err := ai.New(aiConfig)
+
+	if err != nil {
+		log.Errorf("🔥 Failed to create the %s AI provider: %s", aiConfig.Provider, err.Error())
+
+		return
+	}
+
+	log.Infof("✅ Using the %s AI provider", aiConfig.Provider)
+
+	policy, err := trino.PolicyFromEnv()
+
+	if err != nil {
+		log.Errorf("🔥 Invalid SQL policy configuration: %s", err.Error())
+
+		return
+	}
+
+	limits, err := trino.LimitsFromEnv()
+
+	if err != nil {
+		log.Errorf("🔥 Invalid Trino limit configuration: %s", err.Error())
+
+		return
+	}
+
+	executions := trino.NewExecutions(trinoDb, limits)
+
+	promptData := ai.PromptData{
+		Catalogs:   policy.Catalogs,
+		DateFormat: aiConfig.DateFormat,
+	}
+
+	jobs.New(postgresQueries, generator, policy, promptData, generationWorkers).Start(context)
+
+	mailer := mail.New(mail.ConfigFromEnv())
+
+	schedules.New(postgresQueries, postgresPool, trinoDb, policy, executions, mailer, common.EnvString("SCHEDULE_DIRECTORY", "")).Start(context)
+
 	app := fiber.New(fiber.Config{
 		AppName:      "Zingfibre Reporting API",
 		ServerHeader: "Zingfibre-API",
@@ -152,9 +294,17 @@ func main() {
 		Format: "${time} ${status} - ${latency} ${method} ${url}\n",
 	}))
 
+	resultCacheTTL, err := time.ParseDuration(common.EnvString("RESULT_CACHE_TTL", "15m"))
+
+	if err != nil {
+		log.Warnf("⚠️ Invalid RESULT_CACHE_TTL value, defaulting to 15m: %s", err.Error())
+
+		resultCacheTTL = 15 * time.Minute
+	}
+
 	middleware := middleware.NewMiddleware(postgresQueries, sessions)
 
-	httpRouter := http.NewHttpRouter(postgresQueries, zingQueries, radiusQueries, middleware, sessions, trinoDb)
+	httpRouter := http.NewHttpRouter(postgresQueries, postgresPool, zingQueries, radiusQueries, middleware, sessions, trinoDb, policy, promptData, resultCacheTTL, executions)
 
 	openapiSpecification := httpRouter.InitializeOpenAPI()
 
@@ -163,18 +313,56 @@ func main() {
 	server := mcp.NewServer(&mcp.Implementation{Name: "zing-mcp", Version: "v1.0.0"}, nil)
 
 	// Register Trino tool
-	trino := trino.New(trinoDb)
+	trinoTools := trino.New(trinoDb, postgresQueries, policy, executions, promptData)
+
+	trino.AddTool(server, &mcp.Tool{Name: "list-catalogs", Description: "Get a list of catalogs using TrinoDB."}, trinoTools.ListCatalogs)
+	trino.AddTool(server, &mcp.Tool{Name: "list-schemas", Description: "Get a list of schemas for a given catalog using TrinoDB."}, trinoTools.ListSchemas)
+	trino.AddTool(server, &mcp.Tool{Name: "list-tables", Description: "Get a list of tables for a given catalog and schema using TrinoDB."}, trinoTools.ListTables)
+	trino.AddTool(server, &mcp.Tool{Name: "test-query", Description: "Test a SQL query using TrinoDB."}, trinoTools.TestQuery)
+	trino.AddTool(server, &mcp.Tool{Name: "describe-table", Description: "Describe the columns of a table with their types, nullability and comments using TrinoDB."}, trinoTools.DescribeTable)
+	trino.AddTool(server, &mcp.Tool{Name: "sample-rows", Description: "Get a few rows of a table, with personal information masked, using TrinoDB."}, trinoTools.SampleRows)
+	trino.AddTool(server, &mcp.Tool{Name: "profile-column", Description: "Profile a column of a table with its distinct count, null ratio, minimum, maximum and most common values using TrinoDB."}, trinoTools.ProfileColumn)
+	trino.AddTool(server, &mcp.Tool{Name: "suggest-joins", Description: "Suggest how to join a table to other Zing and Radius tables."}, trinoTools.SuggestJoins)
+
+	reportTools := reports.New(zingQueries, radiusQueries, postgresQueries)
+
+	trino.AddTool(server, &mcp.Tool{Name: "report-recharges", Description: "Get a page of the recharges between two days, optionally for a POP."}, reportTools.Recharges)
+	trino.AddTool(server, &mcp.Tool{Name: "report-expiring-customers", Description: "Get a page of the customers whose access expires soonest, optionally for a POP."}, reportTools.ExpiringCustomers)
+	trino.AddTool(server, &mcp.Tool{Name: "report-customers", Description: "Get a page of the customers with their Radius usernames and last purchases, optionally for a POP."}, reportTools.Customers)
+	trino.AddTool(server, &mcp.Tool{Name: "analytics-monthly-revenue", Description: "Get this month's revenue, its growth on last month and this month's unique purchasers, optionally for a POP."}, reportTools.MonthlyRevenue)
+	trino.AddTool(server, &mcp.Tool{Name: "report-recharge-type-counts", Description: "Count the recharges of each product per week or month, optionally for a POP."}, reportTools.RechargeTypeCounts)
+
+	tableSchemaResources, err := trino.TableSchemaResources(policy)
 
-	mcp.AddTool(server, &mcp.Tool{Name: "list-catalogs", Description: "Get a list of catalogs using TrinoDB."}, trino.ListCatalogs)
-	mcp.AddTool(server, &mcp.Tool{Name: "list-schemas", Description: "Get a list of schemas for a given catalog using TrinoDB."}, trino.ListSchemas)
-	mcp.AddTool(server, &mcp.Tool{Name: "list-tables", Description: "Get a list of tables for a given catalog and schema using TrinoDB."}, trino.ListTables)
-	mcp.AddTool(server, &mcp.Tool{Name: "test-query", Description: "Test a SQL query using TrinoDB."}, trino.TestQuery)
+	if err != nil {
+		log.Errorf("🔥 Error loading the table schemas: %s", err.Error())
+
+		return
+	}
+
+	for _, resource := range tableSchemaResources {
+		server.AddResource(resource, trinoTools.ReadTableSchema)
+	}
+
+	server.AddResourceTemplate(&mcp.ResourceTemplate{Name: "table-schema", URITemplate: trino.TableSchemaURITemplate, Description: "The MySQL DDL of a Zing or Radius table.", MIMEType: "application/sql"}, trinoTools.ReadTableSchema)
+	server.AddResource(&mcp.Resource{Name: "relationships", URI: trino.RelationshipsURI, Description: "A diagram of how the Zing and Radius tables join.", MIMEType: "text/markdown"}, trinoTools.ReadRelationships)
+	server.AddResource(&mcp.Resource{Name: "glossary", URI: trino.GlossaryURI, Description: "The business glossary, explaining terms such as POP, ServiceID, build type and intro package.", MIMEType: "text/markdown"}, trinoTools.ReadGlossary)
+
+	promptHandlers := map[string]mcp.PromptHandler{
+		"generate-dynamic-query": trinoTools.GenerateDynamicQueryPrompt,
+		"explore-table":          trinoTools.ExploreTablePrompt,
+		"pop-summary":            trinoTools.PopSummaryPrompt,
+	}
+
+	for _, prompt := range trino.Prompts {
+		server.AddPrompt(prompt, promptHandlers[prompt.Name])
+	}
 
 	handler := mcp.NewStreamableHTTPHandler(func(req *netHttp.Request) *mcp.Server {
 		return server
 	}, nil)
 
-	api.All("/mcp/*", adaptor.HTTPHandler(handler))
+	api.All("/mcp/*", adaptor.HTTPHandler(auth.RequireBearerToken(middleware.VerifyMcpToken, nil)(handler)))
 
 	api.Get("/api-spec", func(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusOK).JSON(openapiSpecification)
@@ -0,0 +1,78 @@
+package reports
+
+import (
+	"context"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/mysql/zing"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type CustomersParams struct {
+	PopParams
+	PageParams
+	Search string `json:"search,omitempty" jsonschema:"Only customers whose name, email, phone number or Radius username contains this text."`
+}
+
+func (r *reports) Customers(ctx context.Context, request *mcp.CallToolRequest, params CustomersParams) (*mcp.CallToolResult, any, error) {
+	log.Info("Reporting customers...")
+
+	pop, result, err := r.allow(request, params.Pop)
+
+	if result != nil {
+		return result, nil, err
+	}
+
+	if result, err := r.maskedSearch(ctx, request, params.Search, "FullName", "FirstName", "Surname", "Email", "PhoneNumber", "RadiusUsername"); result != nil {
+		return result, nil, err
+	}
+
+	limit, offset := params.limits()
+
+	total, err := r.zing.GetReportsTotalCustomersForPop(ctx, zing.GetReportsTotalCustomersForPopParams{
+		Pop:    pop,
+		Search: params.Search,
+	})
+
+	if err != nil {
+		log.Errorf("🔥 Error fetching total customers from Zing: %s", err.Error())
+
+		return trino.ToolError("Error retrieving the customers", err)
+	}
+
+	customers, err := r.zing.GetReportsCustomersForPop(ctx, zing.GetReportsCustomersForPopParams{
+		Pop:    pop,
+		Search: params.Search,
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+
+	if err != nil {
+		log.Errorf("🔥 Error fetching customers from Zing: %s", err.Error())
+
+		return trino.ToolError("Error retrieving the customers", err)
+	}
+
+	rules, err := r.maskingRules(ctx, request)
+
+	if err != nil {
+		return trino.ToolError("Error loading the masking rules", err)
+	}
+
+	data := []system.ReportCustomer{}
+
+	for _, customer := range customers {
+		data = append(data, system.ReportCustomer{
+			FullName:       customer.FullName,
+			Email:          customer.Email.String,
+			PhoneNumber:    customer.PhoneNumber.String,
+			RadiusUsername: customer.RadiusUsername.String,
+		})
+	}
+
+	rules.MaskRows(data)
+
+	return trino.JSONResult(newPage(params.PageParams, total, data))
+}
@@ -0,0 +1,146 @@
+package reports
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type ExpiringCustomersParams struct {
+	PopParams
+	PageParams
+	ExpiresFrom string `json:"expires_from,omitempty" jsonschema:"Only customers whose access expires on or after this day, such as 2025-01-01."`
+	ExpiresTo   string `json:"expires_to,omitempty" jsonschema:"Only customers whose access expires on or before this day, such as 2025-01-31."`
+	Search      string `json:"search,omitempty" jsonschema:"Only customers whose name, email, phone number, Radius username, last purchase or address contains this text."`
+}
+
+// ExpiringCustomers joins the Zing customers to their Radius expiration the
+// same way the /reports/expiring-customers route does, returning those that
+// expire soonest first.
+func (r *reports) ExpiringCustomers(ctx context.Context, request *mcp.CallToolRequest, params ExpiringCustomersParams) (*mcp.CallToolResult, any, error) {
+	log.Info("Reporting expiring customers...")
+
+	pop, result, err := r.allow(request, params.Pop)
+
+	if result != nil {
+		return result, nil, err
+	}
+
+	if result, err := r.maskedSearch(ctx, request, params.Search, "FullName", "Email", "PhoneNumber", "RadiusUsername", "LastPurchaseDuration", "LastPurchaseSpeed", "Address"); result != nil {
+		return result, nil, err
+	}
+
+	var expiresFrom, expiresTo time.Time
+
+	if params.ExpiresFrom != "" {
+		if expiresFrom, err = parseDate("expires_from", params.ExpiresFrom, false); err != nil {
+			return trino.ToolError("The expiry range is invalid", err)
+		}
+	}
+
+	if params.ExpiresTo != "" {
+		if expiresTo, err = parseDate("expires_to", params.ExpiresTo, true); err != nil {
+			return trino.ToolError("The expiry range is invalid", err)
+		}
+	}
+
+	expiringCustomersRadius, err := r.radius.GetReportsExpiringCustomers(ctx)
+
+	if err != nil {
+		log.Errorf("🔥 Error fetching expiring customers from Radius: %s", err.Error())
+
+		return trino.ToolError("Error retrieving the expiring customers", err)
+	}
+
+	expiringCustomersZing, err := r.zing.GetReportsExpiringCustomers(ctx)
+
+	if err != nil {
+		log.Errorf("🔥 Error fetching expiring customers from Zing: %s", err.Error())
+
+		return trino.ToolError("Error retrieving the expiring customers", err)
+	}
+
+	expirations := map[string]time.Time{}
+
+	for _, customer := range expiringCustomersRadius {
+		if customer.Expiration.Valid {
+			expirations[strings.ToLower(customer.Username)] = customer.Expiration.Time
+		}
+	}
+
+	type expiringCustomer struct {
+		customer   system.ReportExpiringCustomer
+		expiration time.Time
+	}
+
+	search := strings.ToLower(params.Search)
+	matches := []expiringCustomer{}
+
+	for _, customer := range expiringCustomersZing {
+		expiration, ok := expirations[strings.ToLower(customer.RadiusUsername.String)]
+
+		if !ok || (pop != "" && strings.ToLower(strings.TrimSpace(customer.Pop.String)) != pop) {
+			continue
+		}
+
+		if (!expiresFrom.IsZero() && expiration.Before(expiresFrom)) || (!expiresTo.IsZero() && expiration.After(expiresTo)) {
+			continue
+		}
+
+		reportCustomer := system.ReportExpiringCustomer{
+			FullName:             customer.FullName,
+			Email:                customer.Email.String,
+			PhoneNumber:          customer.PhoneNumber.String,
+			RadiusUsername:       customer.RadiusUsername.String,
+			LastPurchaseDuration: customer.LastPurchaseDuration.String,
+			LastPurchaseSpeed:    customer.LastPurchaseSpeed.String,
+			Expiration:           expiration.Format(time.RFC3339),
+			Address:              customer.Address.String,
+			POP:                  customer.Pop.String,
+		}
+
+		searchable := []string{
+			reportCustomer.FullName,
+			reportCustomer.Email,
+			reportCustomer.PhoneNumber,
+			reportCustomer.RadiusUsername,
+			reportCustomer.LastPurchaseDuration,
+			reportCustomer.LastPurchaseSpeed,
+			reportCustomer.Address,
+		}
+
+		if search != "" && !slices.ContainsFunc(searchable, func(value string) bool { return strings.Contains(strings.ToLower(value), search) }) {
+			continue
+		}
+
+		matches = append(matches, expiringCustomer{customer: reportCustomer, expiration: expiration})
+	}
+
+	slices.SortFunc(matches, func(a expiringCustomer, b expiringCustomer) int {
+		return cmp.Or(a.expiration.Compare(b.expiration), strings.Compare(a.customer.FullName, b.customer.FullName))
+	})
+
+	limit, offset := params.limits()
+	data := []system.ReportExpiringCustomer{}
+
+	for _, match := range matches[min(offset, len(matches)):min(offset+limit, len(matches))] {
+		data = append(data, match.customer)
+	}
+
+	rules, err := r.maskingRules(ctx, request)
+
+	if err != nil {
+		return trino.ToolError("Error loading the masking rules", err)
+	}
+
+	rules.MaskRows(data)
+
+	return trino.JSONResult(newPage(params.PageParams, int64(len(matches)), data))
+}
@@ -0,0 +1,46 @@
+package reports
+
+import (
+	"context"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// MonthlyRevenue returns this month's revenue, its growth on the same part of
+// last month and this month's unique purchasers, as on the
+// /analytics/monthly-statistics route.
+func (r *reports) MonthlyRevenue(ctx context.Context, request *mcp.CallToolRequest, params PopParams) (*mcp.CallToolResult, any, error) {
+	log.Info("Reporting monthly revenue...")
+
+	pop, result, err := r.allow(request, params.Pop)
+
+	if result != nil {
+		return result, nil, err
+	}
+
+	revenueStatistics, err := r.zing.GetAnalyticsMonthlyRevenueStatisticsForPop(ctx, pop)
+
+	if err != nil {
+		log.Errorf("🔥 Error fetching monthly revenue statistics: %s", err.Error())
+
+		return trino.ToolError("Error retrieving the monthly revenue", err)
+	}
+
+	uniquePurchasers, err := r.zing.GetAnalyticsMonthlyUniquePurchasersForPop(ctx, pop)
+
+	if err != nil {
+		log.Errorf("🔥 Error fetching monthly unique purchasers: %s", err.Error())
+
+		return trino.ToolError("Error retrieving the monthly revenue", err)
+	}
+
+	return trino.JSONResult(system.MonthlyStatistics{
+		Revenue:                 revenueStatistics.Revenue,
+		RevenueGrowth:           revenueStatistics.RevenueGrowthAmount,
+		RevenueGrowthPercentage: revenueStatistics.RevenueGrowthPercentage,
+		UniquePurchasers:        uniquePurchasers,
+	})
+}
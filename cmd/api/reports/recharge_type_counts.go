@@ -0,0 +1,74 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/mysql/zing"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const maxRechargeTypePeriods = 24
+
+var rechargeTypePeriods = []string{"weeks", "months"}
+
+type RechargeTypeCountsParams struct {
+	PopParams
+	Period string `json:"period" jsonschema:"Whether to count per week or per month, weeks or months."`
+	Count  int    `json:"count" jsonschema:"How many weeks or months to count back from this month, at most 24."`
+}
+
+// RechargeTypeCounts counts the recharges of each product per week or month.
+// Recharges without a product are intro packages.
+func (r *reports) RechargeTypeCounts(ctx context.Context, request *mcp.CallToolRequest, params RechargeTypeCountsParams) (*mcp.CallToolResult, any, error) {
+	log.Info("Reporting recharge type counts...")
+
+	pop, result, err := r.allow(request, params.Pop)
+
+	if result != nil {
+		return result, nil, err
+	}
+
+	if !slices.Contains(rechargeTypePeriods, params.Period) {
+		return trino.ToolError("The period is invalid", fmt.Errorf("period must be weeks or months, not %q", params.Period))
+	}
+
+	if params.Count < 1 || params.Count > maxRechargeTypePeriods {
+		return trino.ToolError("The count is invalid", fmt.Errorf("count must be between 1 and %d", maxRechargeTypePeriods))
+	}
+
+	rows, err := r.zing.GetReportsRechargeTypeCountsForPop(ctx, zing.GetReportsRechargeTypeCountsForPopParams{
+		Period: params.Period,
+		Pop:    pop,
+		Count:  params.Count,
+	})
+
+	if err != nil {
+		log.Errorf("🔥 Error retrieving recharge type counts: %s", err.Error())
+
+		return trino.ToolError("Error retrieving the recharge type counts", err)
+	}
+
+	data := []system.ReportRechargeTypeCount{}
+
+	for _, row := range rows {
+		name := row.RechargeName.String
+
+		if name == "" {
+			name = "Intro Package"
+		}
+
+		data = append(data, system.ReportRechargeTypeCount{
+			RechargeName:    name,
+			RechargeCount:   int(row.RechargeCount),
+			RechargePeriod:  text(row.RechargePeriod),
+			RechargeMaxDate: text(row.RechargeMaxDate),
+		})
+	}
+
+	return trino.JSONResult(data)
+}
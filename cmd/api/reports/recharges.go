@@ -0,0 +1,112 @@
+package reports
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/mysql/zing"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type RechargesParams struct {
+	PopParams
+	PageParams
+	StartDate string `json:"start_date" jsonschema:"The first day to include, such as 2025-01-01, or an RFC 3339 time."`
+	EndDate   string `json:"end_date" jsonschema:"The last day to include, such as 2025-01-31, or an RFC 3339 time."`
+	Search    string `json:"search,omitempty" jsonschema:"Only recharges whose customer, product or build contains this text."`
+}
+
+func (r *reports) Recharges(ctx context.Context, request *mcp.CallToolRequest, params RechargesParams) (*mcp.CallToolResult, any, error) {
+	log.Info("Reporting recharges...")
+
+	pop, result, err := r.allow(request, params.Pop)
+
+	if result != nil {
+		return result, nil, err
+	}
+
+	if result, err := r.maskedSearch(ctx, request, params.Search, "FullName", "FirstName", "Surname", "Email", "Amount", "PaymentAmount", "ServiceId", "BuildName", "BuildType"); result != nil {
+		return result, nil, err
+	}
+
+	startDate, err := parseDate("start_date", params.StartDate, false)
+
+	if err != nil {
+		return trino.ToolError("The start date is invalid", err)
+	}
+
+	endDate, err := parseDate("end_date", params.EndDate, true)
+
+	if err != nil {
+		return trino.ToolError("The end date is invalid", err)
+	}
+
+	limit, offset := params.limits()
+
+	total, err := r.zing.GetReportsTotalRechargesForPop(ctx, zing.GetReportsTotalRechargesForPopParams{
+		Pop:       pop,
+		Search:    params.Search,
+		StartDate: startDate,
+		EndDate:   endDate,
+	})
+
+	if err != nil {
+		log.Errorf("🔥 Error fetching total recharges from Zing: %s", err.Error())
+
+		return trino.ToolError("Error retrieving the recharges", err)
+	}
+
+	recharges, err := r.zing.GetReportsRechargesForPop(ctx, zing.GetReportsRechargesForPopParams{
+		Pop:       pop,
+		Search:    params.Search,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Limit:     int32(limit),
+		Offset:    int32(offset),
+	})
+
+	if err != nil {
+		log.Errorf("🔥 Error fetching recharges from Zing: %s", err.Error())
+
+		return trino.ToolError("Error retrieving the recharges", err)
+	}
+
+	rules, err := r.maskingRules(ctx, request)
+
+	if err != nil {
+		return trino.ToolError("Error loading the masking rules", err)
+	}
+
+	data := []system.ReportRecharge{}
+
+	for _, recharge := range recharges {
+		amount, err := strconv.ParseFloat(recharge.Amount.String, 64)
+
+		if err != nil {
+			log.Errorf("🔥 Error parsing amount for recharge: %s", err.Error())
+
+			continue
+		}
+
+		data = append(data, system.ReportRecharge{
+			DateCreated: recharge.DateCreated.Format(time.RFC3339),
+			Email:       recharge.Email.String,
+			FullName:    recharge.FullName,
+			ItemName:    text(recharge.ItemName),
+			Amount:      amount,
+			Method:      recharge.Method.String,
+			Successful:  recharge.Successful,
+			ServiceId:   recharge.ServiceID.Int64,
+			BuildName:   recharge.BuildName.String,
+			BuildType:   recharge.BuildType.String,
+		})
+	}
+
+	rules.MaskRows(data)
+
+	return trino.JSONResult(newPage(params.PageParams, total, data))
+}
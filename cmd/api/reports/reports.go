@@ -0,0 +1,200 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/masking"
+	"github.com/connor-davis/zingfibre-core/internal/mysql/radius"
+	"github.com/connor-davis/zingfibre-core/internal/mysql/zing"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	defaultPageSize = 25
+	maxPageSize     = 100
+)
+
+// reportRoles are the roles that may read the reports and analytics, the
+// same as on the /reports and /analytics routes.
+var reportRoles = []postgres.RoleType{
+	postgres.RoleTypeAdmin,
+	postgres.RoleTypeStaff,
+	postgres.RoleTypeUser,
+}
+
+// Reports are MCP tools that answer questions from the curated reports and
+// analytics rather than from SQL written by the model.
+type Reports interface {
+	Recharges(context context.Context, request *mcp.CallToolRequest, params RechargesParams) (*mcp.CallToolResult, any, error)
+	ExpiringCustomers(context context.Context, request *mcp.CallToolRequest, params ExpiringCustomersParams) (*mcp.CallToolResult, any, error)
+	Customers(context context.Context, request *mcp.CallToolRequest, params CustomersParams) (*mcp.CallToolResult, any, error)
+	MonthlyRevenue(context context.Context, request *mcp.CallToolRequest, params PopParams) (*mcp.CallToolResult, any, error)
+	RechargeTypeCounts(context context.Context, request *mcp.CallToolRequest, params RechargeTypeCountsParams) (*mcp.CallToolResult, any, error)
+}
+
+type reports struct {
+	zing     *zing.Queries
+	radius   *radius.Queries
+	postgres *postgres.Queries
+}
+
+func New(zing *zing.Queries, radius *radius.Queries, postgres *postgres.Queries) Reports {
+	return &reports{
+		zing:     zing,
+		radius:   radius,
+		postgres: postgres,
+	}
+}
+
+// PopParams filters a report by POP.
+type PopParams struct {
+	Pop string `json:"pop,omitempty" jsonschema:"The name of the POP to report on. Every POP the token may read when empty."`
+}
+
+// PageParams pages through a report.
+type PageParams struct {
+	Page     int `json:"page,omitempty" jsonschema:"The page to return, starting at 1."`
+	PageSize int `json:"page_size,omitempty" jsonschema:"The rows per page, 25 by default and at most 100."`
+}
+
+// limits returns the LIMIT and OFFSET for the page.
+func (p PageParams) limits() (int, int) {
+	page, pageSize := max(p.Page, 1), p.PageSize
+
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	pageSize = min(pageSize, maxPageSize)
+
+	return pageSize, (page - 1) * pageSize
+}
+
+// Page is a page of report rows.
+type Page[T any] struct {
+	Total int64 `json:"total"`
+	Page  int   `json:"page"`
+	Pages int   `json:"pages"`
+	Rows  []T   `json:"rows"`
+}
+
+func newPage[T any](params PageParams, total int64, rows []T) Page[T] {
+	pageSize, offset := params.limits()
+
+	return Page[T]{
+		Total: total,
+		Page:  offset/pageSize + 1,
+		Pages: int(math.Ceil(float64(total) / float64(pageSize))),
+		Rows:  rows,
+	}
+}
+
+// allow checks that the role of the MCP token behind request may read the
+// reports and returns the POP to filter them by. The report tools match the
+// POP exactly, unlike the /reports routes, so a token limited to POPs may
+// only ask for one of its POPs, and reports on its only POP when none is
+// given.
+func (r *reports) allow(request *mcp.CallToolRequest, pop string) (string, *mcp.CallToolResult, error) {
+	if role := trino.TokenRole(request); !slices.Contains(reportRoles, role) {
+		result, _, err := trino.ToolError("The report is not allowed", fmt.Errorf("%w: role %s", trino.ErrOutOfScope, role))
+
+		return "", result, err
+	}
+
+	pop = strings.ToLower(strings.TrimSpace(pop))
+	pops := trino.TokenPops(request)
+
+	if pops == nil {
+		return pop, nil, nil
+	}
+
+	if pop == "" && len(pops) == 1 {
+		return pops[0], nil, nil
+	}
+
+	if pop != "" && slices.Contains(pops, pop) {
+		return pop, nil, nil
+	}
+
+	result, _, err := trino.ToolError("The POP is not allowed", fmt.Errorf("%w: this MCP token can only report on the POPs %s", trino.ErrOutOfScope, strings.Join(pops, ", ")))
+
+	return "", result, err
+}
+
+// maskingRules returns the masking rules for the role of the MCP token behind
+// request.
+func (r *reports) maskingRules(ctx context.Context, request *mcp.CallToolRequest) (masking.Rules, error) {
+	rules, err := r.postgres.GetMaskingRulesByRole(ctx, trino.TokenRole(request))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return masking.New(rules), nil
+}
+
+// maskedSearch refuses search over columns when the role of the MCP token
+// behind request masks any of them, since the rows it returns would reveal
+// the values that are masked.
+func (r *reports) maskedSearch(ctx context.Context, request *mcp.CallToolRequest, search string, columns ...string) (*mcp.CallToolResult, error) {
+	if strings.TrimSpace(search) == "" {
+		return nil, nil
+	}
+
+	rules, err := r.maskingRules(ctx, request)
+
+	if err != nil {
+		result, _, err := trino.ToolError("Error loading the masking rules", err)
+
+		return result, err
+	}
+
+	if rule, ok := rules.Rule(columns...); ok {
+		result, _, err := trino.ToolError("The search is not allowed", fmt.Errorf("%w: %s is masked and cannot be searched", trino.ErrOutOfScope, rule.ColumnName))
+
+		return result, err
+	}
+
+	return nil, nil
+}
+
+// parseDate parses an RFC 3339 time or a plain date. A plain date is the
+// start of that day, or its end when endOfDay is set.
+func parseDate(name string, value string, endOfDay bool) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+
+	parsed, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be a date such as 2025-01-31 or an RFC 3339 time", name)
+	}
+
+	if endOfDay {
+		parsed = parsed.Add(24*time.Hour - time.Second)
+	}
+
+	return parsed, nil
+}
+
+// text returns a value that MySQL returned without a known type as text.
+func text(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(value)
+	case time.Time:
+		return value.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,59 @@
+package reports
+
+import (
+	"testing"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func testRequest(pops []string, role postgres.RoleType) *mcp.CallToolRequest {
+	return &mcp.CallToolRequest{
+		Extra: &mcp.RequestExtra{
+			TokenInfo: &auth.TokenInfo{Scopes: trino.TokenScopes(nil, nil, pops, role)},
+		},
+	}
+}
+
+func TestAllow(t *testing.T) {
+	tests := []struct {
+		name    string
+		pops    []string
+		role    postgres.RoleType
+		pop     string
+		want    string
+		allowed bool
+	}{
+		{"unscoped", nil, postgres.RoleTypeUser, "North", "north", true},
+		{"unscoped every pop", nil, postgres.RoleTypeUser, "", "", true},
+		{"exact", []string{"north"}, postgres.RoleTypeUser, "north", "north", true},
+		{"case and spaces", []string{" North "}, postgres.RoleTypeUser, "  NORTH ", "north", true},
+		{"only pop", []string{"north"}, postgres.RoleTypeUser, "", "north", true},
+		{"one of several", []string{"north", "south"}, postgres.RoleTypeUser, "south", "south", true},
+		{"several without pop", []string{"north", "south"}, postgres.RoleTypeUser, "", "", false},
+		{"longer name", []string{"north"}, postgres.RoleTypeUser, "northwest", "", false},
+		{"shorter name", []string{"north"}, postgres.RoleTypeUser, "nor", "", false},
+		{"percent wildcard", []string{"north"}, postgres.RoleTypeUser, "north%", "", false},
+		{"only percent", []string{"north"}, postgres.RoleTypeUser, "%", "", false},
+		{"underscore wildcard", []string{"north"}, postgres.RoleTypeUser, "nort_", "", false},
+		{"other pop", []string{"north"}, postgres.RoleTypeUser, "south", "", false},
+		{"empty scope", []string{" "}, postgres.RoleTypeUser, "", "", false},
+		{"role", nil, postgres.RoleType("guest"), "north", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pop, result, _ := (&reports{}).allow(testRequest(test.pops, test.role), test.pop)
+
+			if allowed := result == nil; allowed != test.allowed {
+				t.Fatalf("allow(%v, %q) allowed %v, want %v", test.pops, test.pop, allowed, test.allowed)
+			}
+
+			if test.allowed && pop != test.want {
+				t.Fatalf("allow(%v, %q) = %q, want %q", test.pops, test.pop, pop, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package schedules
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCron = errors.New("invalid cron expression")
+
+// Cron is a parsed five field cron expression: minute, hour, day of month,
+// month and day of week. Each field is kept as a bit set of the values it
+// matches.
+type Cron struct {
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+
+	// anyDay is set when either day field is a wildcard. Following cron, a
+	// day then has to match both fields, otherwise it only has to match one.
+	anyDay bool
+}
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteField     = cronField{name: "minute", min: 0, max: 59}
+	hourField       = cronField{name: "hour", min: 0, max: 23}
+	dayOfMonthField = cronField{name: "day of month", min: 1, max: 31}
+	monthField      = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Both 0 and 7 are Sunday.
+	dayOfWeekField = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseCron parses a five field cron expression such as "0 7 * * MON", or
+// one of the @yearly, @monthly, @weekly, @daily and @hourly macros. Fields
+// may hold lists, ranges, steps and month or day names.
+func ParseCron(expression string) (Cron, error) {
+	expression = strings.TrimSpace(expression)
+
+	if macro, ok := cronMacros[strings.ToLower(expression)]; ok {
+		expression = macro
+	}
+
+	fields := strings.Fields(expression)
+
+	if len(fields) != 5 {
+		return Cron{}, fmt.Errorf("%w: expected 5 fields but found %d", ErrInvalidCron, len(fields))
+	}
+
+	var cron Cron
+	var err error
+
+	if cron.minute, err = minuteField.parse(fields[0]); err != nil {
+		return Cron{}, err
+	}
+
+	if cron.hour, err = hourField.parse(fields[1]); err != nil {
+		return Cron{}, err
+	}
+
+	if cron.dayOfMonth, err = dayOfMonthField.parse(fields[2]); err != nil {
+		return Cron{}, err
+	}
+
+	if cron.month, err = monthField.parse(fields[3]); err != nil {
+		return Cron{}, err
+	}
+
+	if cron.dayOfWeek, err = dayOfWeekField.parse(fields[4]); err != nil {
+		return Cron{}, err
+	}
+
+	if cron.dayOfWeek&(1<<7) != 0 {
+		cron.dayOfWeek |= 1
+	}
+
+	cron.anyDay = strings.HasPrefix(fields[2], "*") || strings.HasPrefix(fields[4], "*")
+
+	return cron, nil
+}
+
+func (f cronField) parse(field string) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(strings.ToLower(field), ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+
+		if hasStep {
+			parsed, err := strconv.Atoi(stepPart)
+
+			if err != nil || parsed < 1 {
+				return 0, fmt.Errorf("%w: %s step %q is not a positive number", ErrInvalidCron, f.name, stepPart)
+			}
+
+			step = parsed
+		}
+
+		start, end := f.min, f.max
+
+		if rangePart != "*" {
+			startPart, endPart, isRange := strings.Cut(rangePart, "-")
+
+			var err error
+
+			if start, err = f.value(startPart); err != nil {
+				return 0, err
+			}
+
+			end = start
+
+			if isRange {
+				if end, err = f.value(endPart); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				end = f.max
+			}
+
+			if end < start {
+				return 0, fmt.Errorf("%w: %s range %q ends before it starts", ErrInvalidCron, f.name, rangePart)
+			}
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << value
+		}
+	}
+
+	return bits, nil
+}
+
+func (f cronField) value(text string) (int, error) {
+	if value, ok := f.names[text]; ok {
+		return value, nil
+	}
+
+	value, err := strconv.Atoi(text)
+
+	if err != nil || value < f.min || value > f.max {
+		return 0, fmt.Errorf("%w: %s %q is not between %d and %d", ErrInvalidCron, f.name, text, f.min, f.max)
+	}
+
+	return value, nil
+}
+
+// Next returns the first minute after after that the expression matches, in
+// the location of after. It returns the zero time if there is none within
+// five years, such as for 30 February.
+func (c Cron) Next(after time.Time) time.Time {
+	location := after.Location()
+	next := time.Date(after.Year(), after.Month(), after.Day(), after.Hour(), after.Minute()+1, 0, 0, location)
+	limit := next.AddDate(5, 0, 0)
+
+	for next.Before(limit) {
+		if c.month&(1<<uint(next.Month())) == 0 {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, location)
+
+			continue
+		}
+
+		if !c.matchesDay(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, location)
+
+			continue
+		}
+
+		if c.hour&(1<<uint(next.Hour())) == 0 {
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, location)
+
+			continue
+		}
+
+		if c.minute&(1<<uint(next.Minute())) == 0 {
+			next = next.Add(time.Minute)
+
+			continue
+		}
+
+		return next
+	}
+
+	return time.Time{}
+}
+
+func (c Cron) matchesDay(date time.Time) bool {
+	dayOfMonth := c.dayOfMonth&(1<<uint(date.Day())) != 0
+	dayOfWeek := c.dayOfWeek&(1<<uint(date.Weekday())) != 0
+
+	if c.anyDay {
+		return dayOfMonth && dayOfWeek
+	}
+
+	return dayOfMonth || dayOfWeek
+}
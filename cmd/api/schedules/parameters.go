@@ -0,0 +1,68 @@
+package schedules
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+)
+
+// relativeDate matches the relative dates that date parameters of a schedule
+// may be given, such as today, today-7 and today+1, counted in days.
+var relativeDate = regexp.MustCompile(`^today(?:([+-])(\d+))?$`)
+
+// ResolveValues returns values with every relative date in a date or date
+// range parameter, or in its default, replaced by the date it names at now.
+// It lets a schedule that runs every Monday report on the week before with
+// "today-7,today-1".
+func ResolveValues(parameters system.DynamicQueryParameters, values map[string]string, now time.Time) map[string]string {
+	resolved := map[string]string{}
+
+	for name, value := range values {
+		resolved[name] = value
+	}
+
+	for _, parameter := range parameters {
+		if parameter.Type != system.DateParameter && parameter.Type != system.DateRangeParameter {
+			continue
+		}
+
+		value := strings.TrimSpace(values[parameter.Name])
+
+		if value == "" {
+			value = parameter.Default
+		}
+
+		if value == "" {
+			continue
+		}
+
+		dates := strings.Split(value, ",")
+
+		for index, date := range dates {
+			dates[index] = resolveDate(strings.TrimSpace(date), now)
+		}
+
+		resolved[parameter.Name] = strings.Join(dates, ",")
+	}
+
+	return resolved
+}
+
+func resolveDate(value string, now time.Time) string {
+	match := relativeDate.FindStringSubmatch(strings.ToLower(value))
+
+	if match == nil {
+		return value
+	}
+
+	days, _ := strconv.Atoi(match[2])
+
+	if match[1] == "-" {
+		days = -days
+	}
+
+	return now.AddDate(0, 0, days).Format(time.DateOnly)
+}
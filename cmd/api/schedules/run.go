@@ -0,0 +1,251 @@
+package schedules
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/mail"
+	"github.com/connor-davis/zingfibre-core/internal/masking"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// run executes a schedule's dynamic query, renders the result as CSV the way
+// /dynamic-queries/{id}/export does and delivers it, recording the outcome.
+func (s *scheduler) run(parent context.Context, schedule postgres.DynamicQuerySchedule) {
+	ctx, cancel := context.WithTimeout(parent, runTimeout)
+
+	defer cancel()
+
+	run, err := s.postgres.CreateDynamicQueryScheduleRun(ctx, postgres.CreateDynamicQueryScheduleRunParams{
+		ScheduleID:     schedule.ID,
+		DynamicQueryID: schedule.DynamicQueryID,
+	})
+
+	if err != nil {
+		log.Errorf("🔥 Error recording dynamic query schedule run: %s", err.Error())
+
+		return
+	}
+
+	dynamicQuery, err := s.postgres.GetDynamicQuery(ctx, schedule.DynamicQueryID)
+
+	if err != nil {
+		s.fail(schedule, dynamicQuery, run, fmt.Sprintf("unable to load the dynamic query: %s", err.Error()))
+
+		return
+	}
+
+	log.Infof("🔃 Running scheduled dynamic query %s", dynamicQuery.ID)
+
+	location, err := time.LoadLocation(schedule.Timezone)
+
+	if err != nil {
+		location = time.UTC
+	}
+
+	now := time.Now().In(location)
+	values := ResolveValues(dynamicQuery.Parameters, schedule.Parameters, now)
+
+	creator, role := s.creator(ctx, schedule)
+
+	executionCtx, execution := s.executions.Start(ctx, trino.ExecutionInfo{
+		Description: fmt.Sprintf("Schedule for %s", dynamicQuery.Name),
+		User:        creator,
+		Role:        string(role),
+		Query:       dynamicQuery.Query.String,
+	})
+
+	result, _, err := trino.Execute(executionCtx, s.trino, s.policy, dynamicQuery.Query.String, dynamicQuery.Parameters, values, trino.ResultOptions{})
+	err = execution.Finish(err)
+
+	if err != nil {
+		s.fail(schedule, dynamicQuery, run, fmt.Sprintf("unable to run the dynamic query: %s", err.Error()))
+
+		return
+	}
+
+	maskingRules, err := s.maskingRules(ctx, role)
+
+	if err != nil {
+		s.fail(schedule, dynamicQuery, run, fmt.Sprintf("unable to load the masking rules: %s", err.Error()))
+
+		return
+	}
+
+	maskingRules.MaskResult(&result, trino.ColumnLineage(dynamicQuery.Query.String))
+
+	var csv bytes.Buffer
+
+	if err := trino.WriteCSV(&csv, result); err != nil {
+		s.fail(schedule, dynamicQuery, run, fmt.Sprintf("unable to render the CSV: %s", err.Error()))
+
+		return
+	}
+
+	fileName := fmt.Sprintf("%s_report_%s.csv", fileNamePart(dynamicQuery.Name), now.Format("2006-01-02_1504"))
+
+	deliveredTo, err := s.deliver(schedule, dynamicQuery, fileName, csv.Bytes(), len(result.Data), now)
+
+	if err != nil {
+		s.fail(schedule, dynamicQuery, run, fmt.Sprintf("unable to deliver the CSV: %s", err.Error()))
+
+		return
+	}
+
+	if _, err := s.postgres.FinishDynamicQueryScheduleRun(context.Background(), postgres.FinishDynamicQueryScheduleRunParams{
+		ID:          run.ID,
+		Status:      postgres.DynamicQueryScheduleRunStatusComplete,
+		RowCount:    int64(len(result.Data)),
+		DeliveredTo: deliveredTo,
+	}); err != nil {
+		log.Errorf("🔥 Error finishing dynamic query schedule run %s: %s", run.ID, err.Error())
+	}
+
+	log.Infof("✅ Scheduled dynamic query %s delivered %d rows to %s", dynamicQuery.ID, len(result.Data), strings.Join(deliveredTo, ", "))
+}
+
+// creator returns the email and role of the user who set up the schedule, or
+// the user role when they no longer exist. Scheduled runs are limited and
+// masked as that role.
+func (s *scheduler) creator(ctx context.Context, schedule postgres.DynamicQuerySchedule) (string, postgres.RoleType) {
+	if schedule.CreatedBy.Valid {
+		if user, err := s.postgres.GetUser(ctx, uuid.UUID(schedule.CreatedBy.Bytes)); err == nil {
+			return user.Email, user.Role
+		}
+	}
+
+	return "Scheduler", postgres.RoleTypeUser
+}
+
+// maskingRules returns the masking rules for role.
+func (s *scheduler) maskingRules(ctx context.Context, role postgres.RoleType) (masking.Rules, error) {
+	rules, err := s.postgres.GetMaskingRulesByRole(ctx, role)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return masking.New(rules), nil
+}
+
+func (s *scheduler) deliver(schedule postgres.DynamicQuerySchedule, dynamicQuery postgres.DynamicQuery, fileName string, csv []byte, rows int, now time.Time) ([]string, error) {
+	switch schedule.Delivery {
+	case postgres.DynamicQueryDeliveryEmail:
+		body := fmt.Sprintf("Attached is the %s report with %d rows, run on %s.", dynamicQuery.Name, rows, now.Format(time.DateTime))
+
+		if err := s.mailer.Send(schedule.Recipients, fmt.Sprintf("Scheduled report: %s", dynamicQuery.Name), body, mail.Attachment{
+			Name:        fileName,
+			ContentType: "text/csv",
+			Data:        csv,
+		}); err != nil {
+			return nil, err
+		}
+
+		return schedule.Recipients, nil
+	case postgres.DynamicQueryDeliveryDirectory:
+		if s.directory == "" {
+			return nil, errors.New("SCHEDULE_DIRECTORY is not set")
+		}
+
+		if err := os.MkdirAll(s.directory, 0o755); err != nil {
+			return nil, err
+		}
+
+		path := filepath.Join(s.directory, fileName)
+
+		// The file is written under a temporary name and renamed once it is
+		// complete, so anything watching the directory never reads half of it.
+		temporary, err := os.CreateTemp(s.directory, ".*.csv.tmp")
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer os.Remove(temporary.Name())
+
+		if _, err := temporary.Write(csv); err != nil {
+			temporary.Close()
+
+			return nil, err
+		}
+
+		if err := temporary.Close(); err != nil {
+			return nil, err
+		}
+
+		if err := os.Chmod(temporary.Name(), 0o644); err != nil {
+			return nil, err
+		}
+
+		if err := os.Rename(temporary.Name(), path); err != nil {
+			return nil, err
+		}
+
+		return []string{path}, nil
+	default:
+		return nil, fmt.Errorf("unknown delivery %q", schedule.Delivery)
+	}
+}
+
+// fail records a failed run and notifies the user who set up the schedule.
+func (s *scheduler) fail(schedule postgres.DynamicQuerySchedule, dynamicQuery postgres.DynamicQuery, run postgres.DynamicQueryScheduleRun, reason string) {
+	log.Errorf("🔥 Scheduled run %s of dynamic query %s failed: %s", run.ID, schedule.DynamicQueryID, reason)
+
+	ctx := context.Background()
+
+	if _, err := s.postgres.FinishDynamicQueryScheduleRun(ctx, postgres.FinishDynamicQueryScheduleRunParams{
+		ID:          run.ID,
+		Status:      postgres.DynamicQueryScheduleRunStatusError,
+		DeliveredTo: []string{},
+		Error:       pgtype.Text{String: reason, Valid: true},
+	}); err != nil {
+		log.Errorf("🔥 Error finishing dynamic query schedule run %s: %s", run.ID, err.Error())
+	}
+
+	if !schedule.CreatedBy.Valid {
+		return
+	}
+
+	user, err := s.postgres.GetUser(ctx, uuid.UUID(schedule.CreatedBy.Bytes))
+
+	if err != nil {
+		log.Warnf("⚠️ Unable to find who to notify about dynamic query schedule run %s: %s", run.ID, err.Error())
+
+		return
+	}
+
+	name := dynamicQuery.Name
+
+	if name == "" {
+		name = schedule.DynamicQueryID.String()
+	}
+
+	body := fmt.Sprintf("The scheduled run of %s on %s failed and nothing was delivered.\n\nReason: %s\nRun: %s", name, time.Now().Format(time.DateTime), reason, run.ID)
+
+	if err := s.mailer.Send([]string{user.Email}, fmt.Sprintf("Scheduled report failed: %s", name), body); err != nil {
+		log.Warnf("⚠️ Unable to send the failure notification for dynamic query schedule run %s: %s", run.ID, err.Error())
+	}
+}
+
+// fileNamePart replaces everything but letters, digits, dashes and
+// underscores in name so it can be used in a file name.
+func fileNamePart(name string) string {
+	return strings.Map(func(character rune) rune {
+		if unicode.IsLetter(character) || unicode.IsDigit(character) || character == '-' || character == '_' {
+			return character
+		}
+
+		return '_'
+	}, name)
+}
@@ -0,0 +1,228 @@
+package schedules
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/connor-davis/zingfibre-core/cmd/api/trino"
+	"github.com/connor-davis/zingfibre-core/internal/mail"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrInvalidTimezone = errors.New("invalid time zone")
+
+// DefaultTimezone is used for schedules that do not name a time zone.
+const DefaultTimezone = "Africa/Johannesburg"
+
+const (
+	pollInterval = 30 * time.Second
+	runTimeout   = 10 * time.Minute
+
+	// lockKey identifies the Postgres advisory lock held by the one API
+	// instance that runs schedules.
+	lockKey int64 = 0x7a_6d_73_63_68
+)
+
+type Scheduler interface {
+	Start(ctx context.Context)
+}
+
+type scheduler struct {
+	postgres   *postgres.Queries
+	pool       *pgxpool.Pool
+	trino      *sql.DB
+	policy     trino.Policy
+	executions *trino.Executions
+	mailer     mail.Mailer
+	directory  string
+}
+
+// New returns a scheduler that runs due dynamic query schedules and delivers
+// their CSV exports by email through mailer or into directory.
+func New(postgres *postgres.Queries, pool *pgxpool.Pool, trinoDb *sql.DB, policy trino.Policy, executions *trino.Executions, mailer mail.Mailer, directory string) Scheduler {
+	return &scheduler{
+		postgres:   postgres,
+		pool:       pool,
+		trino:      trinoDb,
+		policy:     policy,
+		executions: executions,
+		mailer:     mailer,
+		directory:  directory,
+	}
+}
+
+// Start launches the scheduler. It runs until ctx is cancelled.
+func (s *scheduler) Start(ctx context.Context) {
+	log.Info("✅ Starting the dynamic query scheduler")
+
+	go s.lead(ctx)
+}
+
+// NextRun returns the first time after after that a schedule with the given
+// cron expression is due in timezone.
+func NextRun(expression string, timezone string, after time.Time) (time.Time, error) {
+	cron, err := ParseCron(expression)
+
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	location, err := time.LoadLocation(timezone)
+
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrInvalidTimezone, timezone)
+	}
+
+	next := cron.Next(after.In(location))
+
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("%w: %q never matches a date", ErrInvalidCron, expression)
+	}
+
+	return next, nil
+}
+
+// lead keeps trying to become the instance that runs schedules and runs them
+// while it is. Leadership is a session advisory lock held on one pooled
+// connection, so it passes to another instance as soon as this one stops or
+// loses that connection.
+func (s *scheduler) lead(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+
+	defer ticker.Stop()
+
+	var leader *pgxpool.Conn
+
+	defer func() {
+		if leader != nil {
+			s.resign(leader)
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if leader != nil && leader.Ping(ctx) != nil {
+			log.Warn("⚠️ Lost the connection holding the dynamic query scheduler lock")
+
+			// Closing the connection makes sure the lock is gone before it
+			// goes back to the pool.
+			leader.Conn().Close(ctx)
+			leader.Release()
+			leader = nil
+		}
+
+		if leader == nil {
+			if leader = s.acquire(ctx); leader == nil {
+				continue
+			}
+		}
+
+		s.runDue(ctx)
+	}
+}
+
+// acquire returns a connection holding the scheduler lock, or nil if another
+// instance holds it.
+func (s *scheduler) acquire(ctx context.Context) *pgxpool.Conn {
+	connection, err := s.pool.Acquire(ctx)
+
+	if err != nil {
+		log.Errorf("🔥 Error acquiring a connection for the dynamic query scheduler: %s", err.Error())
+
+		return nil
+	}
+
+	locked, err := postgres.New(connection).TryDynamicQuerySchedulerLock(ctx, lockKey)
+
+	if err != nil {
+		log.Errorf("🔥 Error taking the dynamic query scheduler lock: %s", err.Error())
+	}
+
+	if err != nil || !locked {
+		connection.Release()
+
+		return nil
+	}
+
+	log.Info("✅ This instance now runs dynamic query schedules")
+
+	// Runs still marked as running were started by an instance that stopped
+	// before they finished.
+	if err := s.postgres.FailUnfinishedDynamicQueryScheduleRuns(ctx, pgtype.Text{String: "the scheduler stopped before the run finished", Valid: true}); err != nil {
+		log.Errorf("🔥 Error failing unfinished dynamic query schedule runs: %s", err.Error())
+	}
+
+	return connection
+}
+
+func (s *scheduler) resign(connection *pgxpool.Conn) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+
+	defer cancel()
+
+	if _, err := postgres.New(connection).ReleaseDynamicQuerySchedulerLock(ctx, lockKey); err != nil {
+		log.Warnf("⚠️ Error releasing the dynamic query scheduler lock: %s", err.Error())
+
+		connection.Conn().Close(ctx)
+	}
+
+	connection.Release()
+}
+
+func (s *scheduler) runDue(ctx context.Context) {
+	now := time.Now()
+
+	dueSchedules, err := s.postgres.GetDueDynamicQuerySchedules(ctx, timestamp(now))
+
+	if err != nil {
+		log.Errorf("🔥 Error retrieving due dynamic query schedules: %s", err.Error())
+
+		return
+	}
+
+	for _, schedule := range dueSchedules {
+		if ctx.Err() != nil {
+			return
+		}
+
+		nextRunAt, err := NextRun(schedule.Cron, schedule.Timezone, now)
+
+		if err != nil {
+			log.Errorf("🔥 Dynamic query schedule %s is invalid, retrying in a day: %s", schedule.ID, err.Error())
+
+			nextRunAt = now.Add(24 * time.Hour)
+		}
+
+		// The next run is recorded before this one starts, so a run that
+		// takes the instance down is not retried over and over.
+		if err := s.postgres.AdvanceDynamicQuerySchedule(ctx, postgres.AdvanceDynamicQueryScheduleParams{
+			NextRunAt: timestamp(nextRunAt),
+			LastRunAt: timestamp(now),
+			ID:        schedule.ID,
+		}); err != nil {
+			log.Errorf("🔥 Error advancing dynamic query schedule %s: %s", schedule.ID, err.Error())
+
+			continue
+		}
+
+		s.run(ctx, schedule)
+	}
+}
+
+// timestamp stores t in the local time zone, as NOW() does for the other
+// timestamps, whatever time zone the schedule was computed in.
+func timestamp(t time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{Time: t.Local(), Valid: true}
+}
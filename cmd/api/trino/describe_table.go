@@ -0,0 +1,98 @@
+package trino
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TableDescription is the result of the describe-table tool.
+type TableDescription struct {
+	Catalog string        `json:"catalog"`
+	Schema  string        `json:"schema"`
+	Table   string        `json:"table"`
+	Comment string        `json:"comment,omitempty"`
+	Columns []TableColumn `json:"columns"`
+}
+
+func (t *trino) DescribeTable(ctx context.Context, request *mcp.CallToolRequest, params TableParams) (*mcp.CallToolResult, any, error) {
+	log.Infof("Describing table %s.%s.%s...", params.Catalog, params.Schema, params.Table)
+
+	if result, _, err := t.allowTable(request, params); result != nil {
+		return result, nil, err
+	}
+
+	ctx, execution := t.startExecution(ctx, request, "MCP describe-table", params.name())
+
+	description, err := t.describeTable(ctx, params, t.policyFor(request))
+
+	if err := execution.Finish(err); err != nil {
+		return ToolError("Error describing the table", err)
+	}
+
+	return JSONResult(description)
+}
+
+func (t *trino) describeTable(ctx context.Context, params TableParams, policy Policy) (TableDescription, error) {
+	columns, err := t.tableColumns(ctx, params, policy)
+
+	if err != nil {
+		return TableDescription{}, err
+	}
+
+	description := TableDescription{
+		Catalog: strings.ToLower(params.Catalog),
+		Schema:  strings.ToLower(params.Schema),
+		Table:   strings.ToLower(params.Table),
+		Columns: columns,
+	}
+
+	var comment sql.NullString
+
+	err = t.db.QueryRowContext(ctx, `SELECT comment
+FROM system.metadata.table_comments
+WHERE catalog_name = ? AND schema_name = ? AND table_name = ?`, queryArgs(ctx, description.Catalog, description.Schema, description.Table)...).Scan(&comment)
+
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return description, err
+	}
+
+	description.Comment = comment.String
+
+	// information_schema.columns has no comments, SHOW COLUMNS does.
+	rows, err := t.db.QueryContext(ctx, fmt.Sprintf("SHOW COLUMNS FROM %s", params.name()), queryArgs(ctx)...)
+
+	if err != nil {
+		return description, err
+	}
+
+	defer rows.Close()
+
+	comments := map[string]string{}
+
+	for rows.Next() {
+		var name, columnType, extra string
+		var comment sql.NullString
+
+		if err := rows.Scan(&name, &columnType, &extra, &comment); err != nil {
+			return description, err
+		}
+
+		comments[name] = comment.String
+	}
+
+	if err := rows.Err(); err != nil {
+		return description, err
+	}
+
+	for index, column := range description.Columns {
+		description.Columns[index].Comment = comments[column.Name]
+	}
+
+	return description, nil
+}
@@ -0,0 +1,130 @@
+package trino
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	trinoDriver "github.com/trinodb/trino-go-client/trino"
+)
+
+var ErrInvalidQuery = errors.New("invalid query")
+
+// PreviewRows is how many rows a dry run previews.
+const PreviewRows = 20
+
+// estimatePattern matches the estimate Trino prints under each plan fragment.
+// The first match is the estimate for the output of the whole query.
+var estimatePattern = regexp.MustCompile(`Estimates: \{rows: (\S+) \(([^)]*)\), cpu: ([^,]+), memory: ([^,]+), network: ([^}]+)\}`)
+
+// DryRun checks query the way it would be checked before it is saved and asks
+// Trino to plan it with EXPLAIN, binding the parameters with
+// BindParametersForPlanning. Analyze runs EXPLAIN ANALYZE as well, which
+// executes the query. It returns the plan and its estimate, the columns the
+// query returns and a preview of its first PreviewRows rows. Queries that
+// Trino rejects fail with ErrInvalidQuery.
+func DryRun(ctx context.Context, db *sql.DB, policy Policy, query string, parameters system.DynamicQueryParameters, analyze bool) (system.DynamicQueryDryRun, error) {
+	if err := ValidateQuery(query, policy); err != nil {
+		return system.DynamicQueryDryRun{}, err
+	}
+
+	if err := ValidateParameters(query, parameters); err != nil {
+		return system.DynamicQueryDryRun{}, err
+	}
+
+	boundQuery, args, err := BindParametersForPlanning(CleanQuery(query), parameters)
+
+	if err != nil {
+		return system.DynamicQueryDryRun{}, err
+	}
+
+	dryRun := system.DynamicQueryDryRun{}
+
+	if dryRun.Plan, err = explain(ctx, db, "EXPLAIN "+boundQuery, args); err != nil {
+		return system.DynamicQueryDryRun{}, err
+	}
+
+	if match := estimatePattern.FindStringSubmatch(dryRun.Plan); match != nil {
+		dryRun.Estimate = &system.DynamicQueryEstimate{
+			Rows:    match[1],
+			Size:    match[2],
+			CPU:     strings.TrimSpace(match[3]),
+			Memory:  strings.TrimSpace(match[4]),
+			Network: strings.TrimSpace(match[5]),
+		}
+	}
+
+	if analyze {
+		if dryRun.AnalyzedPlan, err = explain(ctx, db, "EXPLAIN ANALYZE "+boundQuery, args); err != nil {
+			return system.DynamicQueryDryRun{}, err
+		}
+	}
+
+	if dryRun.Columns, err = DescribeColumns(ctx, db, boundQuery, args...); err != nil {
+		return system.DynamicQueryDryRun{}, invalidQuery(err)
+	}
+
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT * FROM (%s) AS dynamic_query_results LIMIT %d", boundQuery, PreviewRows), queryArgs(ctx, args...)...)
+
+	if err != nil {
+		return system.DynamicQueryDryRun{}, invalidQuery(err)
+	}
+
+	defer rows.Close()
+
+	if dryRun.Preview, err = ScanLimitedDynamicQueryResult(rows, limitFor(ctx)); err != nil {
+		return system.DynamicQueryDryRun{}, invalidQuery(err)
+	}
+
+	return dryRun, nil
+}
+
+// explain runs an EXPLAIN statement and returns the plan it prints.
+func explain(ctx context.Context, db *sql.DB, statement string, args []any) (string, error) {
+	rows, err := db.QueryContext(ctx, statement, queryArgs(ctx, args...)...)
+
+	if err != nil {
+		return "", invalidQuery(err)
+	}
+
+	defer rows.Close()
+
+	lines := []string{}
+
+	for rows.Next() {
+		var line string
+
+		if err := rows.Scan(&line); err != nil {
+			return "", err
+		}
+
+		lines = append(lines, line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return "", invalidQuery(err)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// invalidQuery wraps err with ErrInvalidQuery when Trino rejected the query
+// itself, for example because of a syntax error or an unknown column, and
+// leaves any other error as it is.
+func invalidQuery(err error) error {
+	var trinoErr *trinoDriver.ErrTrino
+
+	if !errors.As(err, &trinoErr) || trinoErr.ErrorType != "USER_ERROR" {
+		return err
+	}
+
+	if trinoErr.ErrorLocation.LineNumber > 0 {
+		return fmt.Errorf("%w: line %d:%d: %s", ErrInvalidQuery, trinoErr.ErrorLocation.LineNumber, trinoErr.ErrorLocation.ColumnNumber, trinoErr.Message)
+	}
+
+	return fmt.Errorf("%w: %s", ErrInvalidQuery, trinoErr.Message)
+}
@@ -0,0 +1,64 @@
+package trino
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+)
+
+// Execute runs query with its parameters bound and wrapped with the given
+// options, returning the requested page of results along with the total
+// number of rows that matched the filters. The total is only counted when
+// paging. The query is validated against policy first. When ctx was started
+// by Executions.Start its queries are tagged with the execution and the rows
+// read are capped by its limit.
+func Execute(ctx context.Context, db *sql.DB, policy Policy, query string, parameters system.DynamicQueryParameters, values map[string]string, options ResultOptions) (system.DynamicQueryResult, int64, error) {
+	if err := ValidateQuery(query, policy); err != nil {
+		return system.DynamicQueryResult{}, 0, err
+	}
+
+	boundQuery, parameterArgs, err := BindParameters(CleanQuery(query), parameters, values)
+
+	if err != nil {
+		return system.DynamicQueryResult{}, 0, err
+	}
+
+	columns, err := DescribeColumns(ctx, db, boundQuery, parameterArgs...)
+
+	if err != nil {
+		return system.DynamicQueryResult{}, 0, err
+	}
+
+	selectQuery, countQuery, filterArgs, err := BuildResultQueries(boundQuery, columns, options)
+
+	if err != nil {
+		return system.DynamicQueryResult{}, 0, err
+	}
+
+	args := append(parameterArgs, filterArgs...)
+
+	var total int64
+
+	if options.PageSize > 0 {
+		if err := db.QueryRowContext(ctx, countQuery, queryArgs(ctx, args...)...).Scan(&total); err != nil {
+			return system.DynamicQueryResult{}, 0, err
+		}
+	}
+
+	rows, err := db.QueryContext(ctx, selectQuery, queryArgs(ctx, args...)...)
+
+	if err != nil {
+		return system.DynamicQueryResult{}, 0, err
+	}
+
+	defer rows.Close()
+
+	result, err := ScanLimitedDynamicQueryResult(rows, limitFor(ctx))
+
+	if err != nil {
+		return system.DynamicQueryResult{}, 0, err
+	}
+
+	return result, total, nil
+}
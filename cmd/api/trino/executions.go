@@ -0,0 +1,340 @@
+package trino
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+var ErrExecutionNotFound = errors.New("execution not found")
+
+const (
+	// sourcePrefix starts the X-Trino-Source of every query run inside an
+	// execution, which ends with the execution ID so that its queries can be
+	// found in system.runtime.queries.
+	sourcePrefix = "zingfibre-core/"
+	sourceHeader = "X-Trino-Source"
+
+	killTimeout = 15 * time.Second
+)
+
+var queryIDPattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+var errKilled = errors.New("killed by an administrator")
+
+// ExecutionInfo describes who a Trino execution is for and what it runs.
+type ExecutionInfo struct {
+	Description string
+	User        string
+	Role        string
+	Query       string
+}
+
+// Execution is a running Trino execution. It is started with
+// Executions.Start and must be ended with Finish.
+type Execution struct {
+	ID        uuid.UUID
+	Info      ExecutionInfo
+	Limit     Limit
+	StartedAt time.Time
+
+	ctx        context.Context
+	cancel     context.CancelCauseFunc
+	stopTimer  context.CancelFunc
+	executions *Executions
+}
+
+type executionKey struct{}
+
+// Executions tracks the Trino executions running in this API instance so
+// that they can be listed and killed.
+type Executions struct {
+	db      *sql.DB
+	limits  Limits
+	mutex   sync.Mutex
+	running map[uuid.UUID]*Execution
+}
+
+func NewExecutions(db *sql.DB, limits Limits) *Executions {
+	return &Executions{
+		db:      db,
+		limits:  limits,
+		running: map[uuid.UUID]*Execution{},
+	}
+}
+
+// Limit returns the limit executions for role run with.
+func (e *Executions) Limit(role string) Limit {
+	return e.limits.For(role)
+}
+
+// Start registers an execution limited by the limit for info.Role. The
+// returned context ends when the timeout passes, when ctx ends or when the
+// execution is killed, and tags every Trino query run with it so that the
+// query can be killed in Trino too.
+func (e *Executions) Start(ctx context.Context, info ExecutionInfo) (context.Context, *Execution) {
+	execution := &Execution{
+		ID:         uuid.New(),
+		Info:       info,
+		Limit:      e.limits.For(info.Role),
+		StartedAt:  time.Now(),
+		stopTimer:  func() {},
+		executions: e,
+	}
+
+	ctx, execution.cancel = context.WithCancelCause(ctx)
+
+	if execution.Limit.Timeout > 0 {
+		ctx, execution.stopTimer = context.WithTimeoutCause(ctx, execution.Limit.Timeout, fmt.Errorf("%w after %s", ErrQueryTimeout, execution.Limit.Timeout))
+	}
+
+	execution.ctx = context.WithValue(ctx, executionKey{}, execution)
+
+	e.mutex.Lock()
+	e.running[execution.ID] = execution
+	e.mutex.Unlock()
+
+	return execution.ctx, execution
+}
+
+// Finish ends the execution with the error its work returned. When the work
+// failed because the execution was cut short, its Trino queries are killed
+// and err is replaced by ErrQueryTimeout or ErrQueryCancelled.
+func (x *Execution) Finish(err error) error {
+	x.executions.mutex.Lock()
+	delete(x.executions.running, x.ID)
+	x.executions.mutex.Unlock()
+
+	interrupted := x.ctx.Err() != nil
+	cause := context.Cause(x.ctx)
+
+	x.stopTimer()
+	x.cancel(nil)
+
+	if err == nil || !interrupted {
+		return err
+	}
+
+	go x.executions.kill(x.source())
+
+	if errors.Is(cause, ErrQueryTimeout) {
+		return cause
+	}
+
+	return fmt.Errorf("%w: %w", ErrQueryCancelled, cause)
+}
+
+func (x *Execution) source() string {
+	return sourcePrefix + x.ID.String()
+}
+
+// Kill cancels the execution with id.
+func (e *Executions) Kill(id uuid.UUID) error {
+	e.mutex.Lock()
+	execution, ok := e.running[id]
+	e.mutex.Unlock()
+
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrExecutionNotFound, id)
+	}
+
+	log.Warnf("⚠️ Killing Trino execution %s: %s", id, execution.Info.Description)
+
+	execution.cancel(errKilled)
+
+	return nil
+}
+
+// List returns the running executions, oldest first, with the Trino queries
+// each one is running.
+func (e *Executions) List(ctx context.Context) []system.TrinoExecution {
+	e.mutex.Lock()
+	running := []*Execution{}
+
+	for _, execution := range e.running {
+		running = append(running, execution)
+	}
+
+	e.mutex.Unlock()
+
+	slices.SortFunc(running, func(a *Execution, b *Execution) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+
+	queries, err := e.runtimeQueries(ctx, sourcePrefix+"%")
+
+	if err != nil {
+		log.Errorf("🔥 Error retrieving running Trino queries: %s", err.Error())
+	}
+
+	executions := []system.TrinoExecution{}
+
+	for _, execution := range running {
+		trinoExecution := system.TrinoExecution{
+			ID:           execution.ID,
+			Description:  execution.Info.Description,
+			User:         execution.Info.User,
+			Role:         execution.Info.Role,
+			Query:        execution.Info.Query,
+			StartedAt:    execution.StartedAt,
+			TrinoQueries: queries[execution.source()],
+		}
+
+		if deadline, ok := execution.ctx.Deadline(); ok {
+			trinoExecution.Deadline = &deadline
+		}
+
+		if trinoExecution.TrinoQueries == nil {
+			trinoExecution.TrinoQueries = []system.TrinoQuery{}
+		}
+
+		executions = append(executions, trinoExecution)
+	}
+
+	return executions
+}
+
+// kill kills the Trino queries tagged with source that are still running.
+// Cancelling a query's context already asks Trino to cancel it, this makes
+// sure of it for queries that were still being submitted.
+func (e *Executions) kill(source string) {
+	ctx, cancel := context.WithTimeout(context.Background(), killTimeout)
+	defer cancel()
+
+	queries, err := e.runtimeQueries(ctx, source)
+
+	if err != nil {
+		log.Errorf("🔥 Error retrieving Trino queries to kill: %s", err.Error())
+
+		return
+	}
+
+	for _, query := range queries[source] {
+		if !queryIDPattern.MatchString(query.QueryID) {
+			continue
+		}
+
+		if _, err := e.db.ExecContext(ctx, fmt.Sprintf("CALL system.runtime.kill_query(query_id => '%s', message => 'Cancelled by the Zingfibre Reporting API')", query.QueryID)); err != nil {
+			log.Errorf("🔥 Error killing Trino query %s: %s", query.QueryID, err.Error())
+
+			continue
+		}
+
+		log.Infof("✅ Killed Trino query %s", query.QueryID)
+	}
+}
+
+// runtimeQueries returns the unfinished Trino queries whose source is LIKE
+// source, keyed by source.
+func (e *Executions) runtimeQueries(ctx context.Context, source string) (map[string][]system.TrinoQuery, error) {
+	queries := map[string][]system.TrinoQuery{}
+
+	rows, err := e.db.QueryContext(ctx, `SELECT query_id, source, state FROM system.runtime.queries WHERE source LIKE ? AND state NOT IN ('FINISHED', 'FAILED') ORDER BY created`, source)
+
+	if err != nil {
+		return queries, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var queryID, querySource, state string
+
+		if err := rows.Scan(&queryID, &querySource, &state); err != nil {
+			return queries, err
+		}
+
+		queries[querySource] = append(queries[querySource], system.TrinoQuery{
+			QueryID: queryID,
+			State:   state,
+		})
+	}
+
+	return queries, rows.Err()
+}
+
+// QueryStats are the statistics Trino keeps for a query it ran.
+type QueryStats struct {
+	QueryID        string `json:"query_id"`
+	State          string `json:"state"`
+	QueuedMillis   int64  `json:"queued_time_ms"`
+	AnalysisMillis int64  `json:"analysis_time_ms"`
+	PlanningMillis int64  `json:"planning_time_ms"`
+	ElapsedMillis  int64  `json:"elapsed_time_ms"`
+	ErrorCode      string `json:"error_code,omitempty"`
+}
+
+// QueryStats returns the statistics of the Trino queries the execution has
+// run so far, oldest first. Trino only keeps finished queries for a while, so
+// it should be called before the execution is finished.
+func (x *Execution) QueryStats(ctx context.Context) ([]QueryStats, error) {
+	stats := []QueryStats{}
+
+	rows, err := x.executions.db.QueryContext(ctx, `SELECT
+    query_id,
+    state,
+    queued_time_ms,
+    analysis_time_ms,
+    planning_time_ms,
+    date_diff('millisecond', created, coalesce("end", current_timestamp)),
+    coalesce(error_code, '')
+FROM system.runtime.queries
+WHERE source = ?
+ORDER BY created`, x.source())
+
+	if err != nil {
+		return stats, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var queryStats QueryStats
+
+		if err := rows.Scan(&queryStats.QueryID, &queryStats.State, &queryStats.QueuedMillis, &queryStats.AnalysisMillis, &queryStats.PlanningMillis, &queryStats.ElapsedMillis, &queryStats.ErrorCode); err != nil {
+			return stats, err
+		}
+
+		stats = append(stats, queryStats)
+	}
+
+	return stats, rows.Err()
+}
+
+// executionFrom returns the execution that ctx was started for, if any.
+func executionFrom(ctx context.Context) (*Execution, bool) {
+	execution, ok := ctx.Value(executionKey{}).(*Execution)
+
+	return execution, ok
+}
+
+// limitFor returns the limit of the execution that ctx was started for, or
+// no limit at all.
+func limitFor(ctx context.Context) Limit {
+	if execution, ok := executionFrom(ctx); ok {
+		return execution.Limit
+	}
+
+	return Limit{}
+}
+
+// queryArgs appends the header that tags a query with the execution ctx was
+// started for to args.
+func queryArgs(ctx context.Context, args ...any) []any {
+	execution, ok := executionFrom(ctx)
+
+	if !ok {
+		return args
+	}
+
+	return append(slices.Clip(args), sql.Named(sourceHeader, execution.source()))
+}
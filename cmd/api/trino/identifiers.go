@@ -0,0 +1,101 @@
+package trino
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInvalidIdentifier = errors.New("invalid identifier")
+	ErrUnknownIdentifier = errors.New("unknown identifier")
+)
+
+// maxIdentifierLength is the longest catalog, schema, table or column name
+// accepted from an MCP tool call. MySQL names are at most 64 characters.
+const maxIdentifierLength = 128
+
+// QuoteIdentifier quotes a Trino identifier, escaping any embedded quotes.
+func QuoteIdentifier(name string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
+}
+
+// ValidateIdentifier checks that name could name a catalog, schema, table or
+// column. Identifiers are always quoted with QuoteIdentifier or bound as
+// parameters, this only turns away names that cannot exist before they reach
+// Trino. kind names what the identifier is in the error.
+func ValidateIdentifier(kind string, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%w: the %s name is empty", ErrInvalidIdentifier, kind)
+	}
+
+	if len(name) > maxIdentifierLength {
+		return fmt.Errorf("%w: the %s name is longer than %d characters", ErrInvalidIdentifier, kind, maxIdentifierLength)
+	}
+
+	if strings.ContainsFunc(name, unicode.IsControl) {
+		return fmt.Errorf("%w: the %s name contains control characters", ErrInvalidIdentifier, kind)
+	}
+
+	return nil
+}
+
+// checkCatalog checks that catalog is a valid name of a catalog that exists
+// in system.metadata.catalogs.
+func (t *trino) checkCatalog(ctx context.Context, catalog string) error {
+	if err := ValidateIdentifier("catalog", catalog); err != nil {
+		return err
+	}
+
+	return t.checkExists(ctx, fmt.Sprintf("catalog %s", catalog), `SELECT count(*)
+FROM system.metadata.catalogs
+WHERE catalog_name = ?`, strings.ToLower(catalog))
+}
+
+// checkSchema checks that catalog.schema exists, looking the schema up in the
+// information_schema of the catalog.
+func (t *trino) checkSchema(ctx context.Context, catalog string, schema string) error {
+	if err := t.checkCatalog(ctx, catalog); err != nil {
+		return err
+	}
+
+	if err := ValidateIdentifier("schema", schema); err != nil {
+		return err
+	}
+
+	return t.checkExists(ctx, fmt.Sprintf("schema %s.%s", catalog, schema), fmt.Sprintf(`SELECT count(*)
+FROM %s.information_schema.schemata
+WHERE schema_name = ?`, QuoteIdentifier(strings.ToLower(catalog))), strings.ToLower(schema))
+}
+
+// checkTableExists checks that catalog.schema.table exists, looking the table
+// up in the information_schema of the catalog.
+func (t *trino) checkTableExists(ctx context.Context, catalog string, schema string, table string) error {
+	if err := t.checkSchema(ctx, catalog, schema); err != nil {
+		return err
+	}
+
+	if err := ValidateIdentifier("table", table); err != nil {
+		return err
+	}
+
+	return t.checkExists(ctx, fmt.Sprintf("table %s.%s.%s", catalog, schema, table), fmt.Sprintf(`SELECT count(*)
+FROM %s.information_schema.tables
+WHERE table_schema = ? AND table_name = ?`, QuoteIdentifier(strings.ToLower(catalog))), strings.ToLower(schema), strings.ToLower(table))
+}
+
+func (t *trino) checkExists(ctx context.Context, name string, query string, args ...any) error {
+	var count int
+
+	if err := t.db.QueryRowContext(ctx, query, queryArgs(ctx, args...)...).Scan(&count); err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return fmt.Errorf("%w: %s does not exist", ErrUnknownIdentifier, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package trino
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/common"
+)
+
+var (
+	ErrRowLimit       = errors.New("row limit exceeded")
+	ErrByteLimit      = errors.New("byte limit exceeded")
+	ErrQueryTimeout   = errors.New("query timed out")
+	ErrQueryCancelled = errors.New("query cancelled")
+)
+
+// McpRole is the role that MCP tool calls are limited as.
+const McpRole = "mcp"
+
+// limitRoles are the roles that may override the default limit.
+var limitRoles = []string{"admin", "staff", "user", McpRole}
+
+// Limit caps a single Trino execution. A zero field leaves that cap off.
+type Limit struct {
+	Timeout  time.Duration
+	MaxRows  int
+	MaxBytes int64
+}
+
+// Limits holds the limit for each role, falling back to Default for a role
+// without one of its own.
+type Limits struct {
+	Default Limit
+	Roles   map[string]Limit
+}
+
+// For returns the limit for role.
+func (l Limits) For(role string) Limit {
+	if limit, ok := l.Roles[strings.ToLower(role)]; ok {
+		return limit
+	}
+
+	return l.Default
+}
+
+// LimitsFromEnv reads TRINO_TIMEOUT, TRINO_MAX_ROWS and TRINO_MAX_BYTES along
+// with their per role overrides, such as TRINO_TIMEOUT_ADMIN or
+// TRINO_MAX_ROWS_MCP.
+func LimitsFromEnv() (Limits, error) {
+	defaults := Limit{
+		Timeout:  2 * time.Minute,
+		MaxRows:  100000,
+		MaxBytes: 64 << 20,
+	}
+
+	limits := Limits{
+		Roles: map[string]Limit{},
+	}
+
+	limit, err := limitFromEnv("", defaults)
+
+	if err != nil {
+		return limits, err
+	}
+
+	limits.Default = limit
+
+	for _, role := range limitRoles {
+		limit, err := limitFromEnv("_"+strings.ToUpper(role), limits.Default)
+
+		if err != nil {
+			return limits, err
+		}
+
+		limits.Roles[role] = limit
+	}
+
+	return limits, nil
+}
+
+func limitFromEnv(suffix string, fallback Limit) (Limit, error) {
+	limit := fallback
+
+	if value := common.EnvString("TRINO_TIMEOUT"+suffix, ""); value != "" {
+		timeout, err := time.ParseDuration(value)
+
+		if err != nil || timeout < 0 {
+			return limit, fmt.Errorf("TRINO_TIMEOUT%s: %q is not a duration", suffix, value)
+		}
+
+		limit.Timeout = timeout
+	}
+
+	if value := common.EnvString("TRINO_MAX_ROWS"+suffix, ""); value != "" {
+		maxRows, err := strconv.Atoi(value)
+
+		if err != nil || maxRows < 0 {
+			return limit, fmt.Errorf("TRINO_MAX_ROWS%s: %q is not a row count", suffix, value)
+		}
+
+		limit.MaxRows = maxRows
+	}
+
+	if value := common.EnvString("TRINO_MAX_BYTES"+suffix, ""); value != "" {
+		maxBytes, err := strconv.ParseInt(value, 10, 64)
+
+		if err != nil || maxBytes < 0 {
+			return limit, fmt.Errorf("TRINO_MAX_BYTES%s: %q is not a byte count", suffix, value)
+		}
+
+		limit.MaxBytes = maxBytes
+	}
+
+	return limit, nil
+}
+
+// valueSize estimates how many bytes a scanned Trino value takes up.
+func valueSize(value any) int64 {
+	switch value := value.(type) {
+	case nil:
+		return 0
+	case string:
+		return int64(len(value))
+	case []byte:
+		return int64(len(value))
+	default:
+		return 8
+	}
+}
@@ -0,0 +1,360 @@
+package trino
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/internal/masking"
+)
+
+// castFunctions use AS to name a type rather than an alias.
+var castFunctions = []string{"cast", "try_cast"}
+
+// setOperators combine select lists by position, so the columns of every
+// select list after the first take the names of the first.
+var setOperators = []string{"union", "intersect", "except"}
+
+// selectListEnds end a select list at its own depth.
+var selectListEnds = []string{
+	"from", "where", "group", "having", "window", "order", "limit", "offset",
+	"fetch", "union", "intersect", "except",
+}
+
+// lineageKeywords are words that can end an expression but are never an
+// implicit alias, as in CASE ... END or INTERVAL '1' DAY, or that can come
+// before a parenthesised list without naming a table's columns.
+var lineageKeywords = []string{
+	"all", "and", "any", "array", "as", "asc", "between", "by", "case", "cast",
+	"cross", "current", "current_date", "current_time", "current_timestamp",
+	"day", "desc", "distinct", "else", "end", "escape", "except", "exists",
+	"false", "filter", "first", "following", "for", "from", "full", "group",
+	"having", "hour", "in", "inner", "interval", "intersect", "is", "join",
+	"last", "lateral", "left", "like", "limit", "localtime", "localtimestamp",
+	"map", "minute", "month", "natural", "not", "null", "nulls", "offset", "on",
+	"or", "order", "ordinality", "outer", "over", "partition", "preceding",
+	"range", "right", "row", "rows", "second", "select", "some", "table", "then",
+	"time", "timestamp", "true", "unbounded", "union", "unnest", "using",
+	"values", "when", "where", "with", "within", "year", "zone",
+}
+
+// expressionEnds are the keywords an expression can end with.
+var expressionEnds = []string{
+	"end", "null", "true", "false", "current_date", "current_time",
+	"current_timestamp", "localtime", "localtimestamp", "day", "hour", "minute",
+	"second", "month", "year",
+}
+
+var identifierPattern = regexp.MustCompile(`"((?:[^"]|"")*)"|([A-Za-z_][A-Za-z0-9_]*)`)
+
+// ColumnLineage maps every column named by the select lists of query to the
+// identifiers its expression reads, following names through subqueries and
+// WITH clauses. Keys and identifiers are lower cased. It lets a rule written
+// for a source column, such as Email, also cover "Contact Email" when the
+// query selects db1."Email" AS "Contact Email" or db1."Email" "Contact Email",
+// and the _col0 Trino names lower(db1."Email").
+//
+// When the names cannot be followed, such as through a set operation that is
+// not a plain chain of select lists or a list of column aliases, every
+// identifier in the query is mapped under masking.AnyColumn so that any rule
+// for a column the query reads masks every column of its result.
+func ColumnLineage(query string) map[string][]string {
+	lineage := map[string][]string{}
+	tokens, err := tokenize(CleanQuery(query))
+
+	if err != nil {
+		return unresolvedLineage(query)
+	}
+
+	direct := map[string][]string{}
+	// chains holds the names of the first select list of the set operation
+	// chain open at each depth, so later select lists add to them by position.
+	chains := map[int][]string{}
+	openers := []string{}
+	setOperation := map[int]bool{}
+
+	for index := 0; index < len(tokens); index++ {
+		token := tokens[index]
+		depth := len(openers)
+
+		switch {
+		case token.is("("):
+			opener := ""
+
+			if index > 0 && tokens[index-1].kind == wordToken {
+				opener = tokens[index-1].value
+			}
+
+			if setOperation[depth] || namesColumns(tokens, index, openers) {
+				return unresolvedLineage(query)
+			}
+
+			openers = append(openers, opener)
+		case token.is(")"):
+			if len(openers) > 0 {
+				delete(chains, depth)
+				delete(setOperation, depth)
+				openers = openers[:len(openers)-1]
+			}
+		case token.isKeyword(setOperators...):
+			// A chain that starts with a parenthesised select list has no
+			// names at this depth to add the later ones to.
+			if _, ok := chains[depth]; !ok {
+				return unresolvedLineage(query)
+			}
+
+			setOperation[depth] = true
+		case token.isKeyword("select"):
+			items, end := selectList(tokens, index+1)
+			names := []string{}
+
+			for position, item := range items {
+				itemNames, sources, star := selectItem(item, position)
+
+				if star && setOperation[depth] {
+					return unresolvedLineage(query)
+				}
+
+				if star {
+					names = append(names, "")
+
+					continue
+				}
+
+				names = append(names, itemNames[0])
+
+				for _, name := range itemNames {
+					direct[name] = append(direct[name], sources...)
+				}
+
+				if head, ok := chains[depth]; ok && setOperation[depth] {
+					if position >= len(head) || head[position] == "" {
+						return unresolvedLineage(query)
+					}
+
+					direct[head[position]] = append(direct[head[position]], sources...)
+				}
+			}
+
+			if _, ok := chains[depth]; !ok || !setOperation[depth] {
+				chains[depth] = names
+			}
+
+			setOperation[depth] = false
+			index = end - 1
+		}
+	}
+
+	for alias := range direct {
+		lineage[alias] = resolveLineage(alias, direct, map[string]bool{})
+	}
+
+	return lineage
+}
+
+// unresolvedLineage maps every identifier in query under masking.AnyColumn.
+func unresolvedLineage(query string) map[string][]string {
+	identifiers := []string{}
+
+	for _, match := range identifierPattern.FindAllStringSubmatch(CleanQuery(query), -1) {
+		identifier := match[2]
+
+		if match[1] != "" {
+			identifier = strings.ReplaceAll(match[1], `""`, `"`)
+		}
+
+		identifiers = append(identifiers, strings.ToLower(identifier))
+	}
+
+	return map[string][]string{masking.AnyColumn: identifiers}
+}
+
+// namesColumns reports whether the parenthesis at index lists names for the
+// columns of a table or subquery, as in AS t (a, b), FROM customers c (a, b)
+// or WITH t (a, b) AS, which renames them by position.
+func namesColumns(tokens []sqlToken, index int, openers []string) bool {
+	if index < 2 || !isIdentifier(tokens[index-1]) {
+		return false
+	}
+
+	if len(openers) > 0 && slices.Contains(castFunctions, openers[len(openers)-1]) {
+		return false
+	}
+
+	before := tokens[index-2]
+
+	if before.is(",") {
+		return isWithList(tokens, index)
+	}
+
+	return before.is(")") || before.isKeyword("as", "with") || isIdentifier(before)
+}
+
+// isWithList reports whether the parenthesis at index follows the name of a
+// common table expression, as in WITH a AS (...), b (x) AS (...).
+func isWithList(tokens []sqlToken, index int) bool {
+	end := skipGroup(tokens, index)
+
+	return end+1 < len(tokens) && tokens[end].isKeyword("as") && tokens[end+1].is("(")
+}
+
+// selectList splits the select list that starts at index into its items and
+// returns the index of the token that ends it.
+func selectList(tokens []sqlToken, index int) ([][]sqlToken, int) {
+	items := [][]sqlToken{}
+	item := []sqlToken{}
+	depth := 0
+
+	if index < len(tokens) && tokens[index].isKeyword("distinct", "all") {
+		index++
+	}
+
+	for ; index < len(tokens); index++ {
+		token := tokens[index]
+
+		if depth == 0 && (token.is(")") || token.isKeyword(selectListEnds...)) {
+			break
+		}
+
+		switch {
+		case token.is("(") || token.is("["):
+			depth++
+		case token.is(")") || token.is("]"):
+			depth--
+		case depth == 0 && token.is(","):
+			items = append(items, item)
+			item = []sqlToken{}
+
+			continue
+		}
+
+		item = append(item, token)
+	}
+
+	if len(item) > 0 {
+		items = append(items, item)
+	}
+
+	return items, index
+}
+
+// selectItem returns the names Trino may give the select list item at
+// position, the first being the one it is most likely to, and the identifiers
+// it reads. A * has no names and is reported as a star.
+func selectItem(item []sqlToken, position int) ([]string, []string, bool) {
+	if len(item) == 0 {
+		return nil, nil, false
+	}
+
+	last := item[len(item)-1]
+
+	if last.is("*") {
+		return nil, nil, true
+	}
+
+	expression := item
+	generated := fmt.Sprintf("_col%d", position)
+	names := []string{generated}
+
+	switch {
+	case len(item) >= 2 && item[len(item)-2].isKeyword("as") && isIdentifier(last):
+		expression, names = item[:len(item)-2], []string{last.value}
+	case len(item) >= 2 && implicitAlias(item):
+		// An implicit alias could also be the end of an expression this
+		// does not know, which Trino would name by position.
+		expression, names = item[:len(item)-1], []string{last.value, generated}
+	case bareColumn(item):
+		names = []string{last.value}
+	}
+
+	sources := []string{}
+	openers := []string{}
+
+	for index, token := range expression {
+		if token.is("(") {
+			opener := ""
+
+			if index > 0 && expression[index-1].kind == wordToken {
+				opener = expression[index-1].value
+			}
+
+			openers = append(openers, opener)
+
+			continue
+		}
+
+		if token.is(")") && len(openers) > 0 {
+			openers = openers[:len(openers)-1]
+
+			continue
+		}
+
+		// The type named by CAST(x AS type) is not read.
+		if index > 0 && expression[index-1].isKeyword("as") && len(openers) > 0 && slices.Contains(castFunctions, openers[len(openers)-1]) {
+			continue
+		}
+
+		if isIdentifier(token) {
+			sources = append(sources, token.value)
+		}
+	}
+
+	return names, sources, false
+}
+
+// implicitAlias reports whether the last token of item names it without AS,
+// as in db1."Email" "Contact Email" or CASE ... END status.
+func implicitAlias(item []sqlToken) bool {
+	last, before := item[len(item)-1], item[len(item)-2]
+
+	if !isIdentifier(last) {
+		return false
+	}
+
+	switch before.kind {
+	case quotedIdentifierToken, stringToken, numberToken, placeholderToken:
+		return true
+	case wordToken:
+		return isIdentifier(before) || slices.Contains(expressionEnds, before.value)
+	}
+
+	return before.is(")") || before.is("]")
+}
+
+// bareColumn reports whether item is a column reference such as db1."Email",
+// which Trino names after the column.
+func bareColumn(item []sqlToken) bool {
+	for index, token := range item {
+		if index%2 == 0 && !isIdentifier(token) {
+			return false
+		}
+
+		if index%2 == 1 && !token.is(".") {
+			return false
+		}
+	}
+
+	return len(item)%2 == 1
+}
+
+func isIdentifier(token sqlToken) bool {
+	return token.kind == quotedIdentifierToken || (token.kind == wordToken && !slices.Contains(lineageKeywords, token.value))
+}
+
+func resolveLineage(alias string, direct map[string][]string, visited map[string]bool) []string {
+	if visited[alias] {
+		return nil
+	}
+
+	visited[alias] = true
+
+	sources := []string{}
+
+	for _, source := range direct[alias] {
+		sources = append(sources, source)
+		sources = append(sources, resolveLineage(source, direct, visited)...)
+	}
+
+	return sources
+}
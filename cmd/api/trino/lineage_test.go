@@ -0,0 +1,56 @@
+package trino
+
+import (
+	"testing"
+
+	"github.com/connor-davis/zingfibre-core/internal/masking"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+)
+
+func TestColumnLineage(t *testing.T) {
+	rules := masking.New([]postgres.MaskingRule{{ColumnName: "Email"}})
+
+	tests := []struct {
+		name     string
+		query    string
+		masked   []string
+		unmasked []string
+	}{
+		{"column", `SELECT c."Email", c."Surname" FROM zing.zing.customers c`, []string{"Email"}, []string{"Surname"}},
+		{"explicit alias", `SELECT c."Email" AS "Contact Email", c."Surname" FROM zing.zing.customers c`, []string{"Contact Email"}, []string{"Surname"}},
+		{"implicit alias", `SELECT c."Email" e, c."Surname" s FROM zing.zing.customers c`, []string{"e"}, []string{"s", "_col1"}},
+		{"unquoted implicit alias", `SELECT c.email contact FROM zing.zing.customers c`, []string{"contact"}, nil},
+		{"expression", `SELECT lower(c."Email"), c."Surname" FROM zing.zing.customers c`, []string{"_col0"}, []string{"Surname", "_col1"}},
+		{"expression with implicit alias", `SELECT concat(c."Email", '') e FROM zing.zing.customers c`, []string{"e", "_col0"}, nil},
+		{"case", `SELECT CASE WHEN c."Email" IS NULL THEN 'none' ELSE c."Email" END contact FROM zing.zing.customers c`, []string{"contact"}, nil},
+		{"cast", `SELECT CAST(c."Email" AS varchar) AS contact, CAST(c.id AS varchar) AS id FROM zing.zing.customers c`, []string{"contact"}, []string{"id"}},
+		{"not", `SELECT NOT c.email FROM zing.zing.customers c`, []string{"_col0"}, nil},
+		{"subquery", `SELECT x.contact FROM (SELECT lower(c."Email") contact FROM zing.zing.customers c) x`, []string{"contact"}, nil},
+		{"cte", `WITH x AS (SELECT c."Email" e FROM zing.zing.customers c) SELECT upper(e) AS shouted, e AS plain FROM x`, []string{"shouted", "plain"}, nil},
+		{"union", `SELECT c."Surname" AS name FROM zing.zing.customers c UNION ALL SELECT c."Email" FROM zing.zing.customers c`, []string{"name"}, nil},
+		{"unrelated columns", `SELECT c.id, count(*) AS total FROM zing.zing.customers c GROUP BY c.id`, nil, []string{"id", "total"}},
+		{"column aliases", `SELECT x.a FROM (SELECT c."Email" FROM zing.zing.customers c) x (a)`, []string{"a"}, nil},
+		{"cte column aliases", `WITH x (a) AS (SELECT c."Email" FROM zing.zing.customers c) SELECT a FROM x`, []string{"a"}, nil},
+		{"parenthesised union", `SELECT c.id FROM zing.zing.customers c UNION (SELECT c."Email" FROM zing.zing.customers c)`, []string{"id"}, nil},
+		{"union of stars", `SELECT * FROM zing.zing.a UNION SELECT c."Email" FROM zing.zing.customers c`, []string{"id"}, nil},
+		{"unterminated", `SELECT c."Email FROM zing.zing.customers c`, []string{"id"}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lineage := ColumnLineage(test.query)
+
+			for _, column := range test.masked {
+				if _, ok := rules.ResultRule(column, lineage); !ok {
+					t.Errorf("%s is not masked in %q, lineage %v", column, test.query, lineage)
+				}
+			}
+
+			for _, column := range test.unmasked {
+				if _, ok := rules.ResultRule(column, lineage); ok {
+					t.Errorf("%s is masked in %q, lineage %v", column, test.query, lineage)
+				}
+			}
+		})
+	}
+}
@@ -3,31 +3,41 @@ package trino
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 type ListSchemasParams struct {
-	Catelog string `json:"catalog"`
+	Catalog string `json:"catalog"`
 }
 
 func (t *trino) ListSchemas(ctx context.Context, request *mcp.CallToolRequest, params ListSchemasParams) (*mcp.CallToolResult, any, error) {
 	log.Info("Listing schemas...")
 
-	row := t.db.QueryRow(fmt.Sprintf(`SELECT
-    ARRAY_JOIN(
-        ARRAY_AGG(schema_name),
-        ', '
-    ) AS schema_list
-FROM
-    %s.information_schema.schemata
-WHERE
-    schema_name NOT IN ('information_schema', 'system', 'pg_catalog')`, params.Catelog))
+	policy := t.policyFor(request)
 
-	var schemaList string
+	if err := ValidateIdentifier("catalog", params.Catalog); err != nil {
+		return ToolError("The catalog is invalid", err)
+	}
+
+	if !policy.AllowsCatalog(params.Catalog) {
+		return catalogNotAllowed(params.Catalog)
+	}
+
+	catalog := strings.ToLower(params.Catalog)
+
+	query := fmt.Sprintf(`SELECT schema_name
+FROM %s.information_schema.schemata
+WHERE schema_name NOT IN ('information_schema', 'system', 'pg_catalog')
+ORDER BY schema_name`, QuoteIdentifier(catalog))
 
-	if err := row.Scan(&schemaList); err != nil {
+	ctx, execution := t.startExecution(ctx, request, "MCP list-schemas", query)
+
+	schemas, err := t.listSchemas(ctx, catalog, query, policy)
+
+	if err := execution.Finish(err); err != nil {
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{
@@ -41,8 +51,39 @@ WHERE
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{
-				Text: schemaList,
+				Text: strings.Join(schemas, ", "),
 			},
 		},
 	}, nil, nil
 }
+
+// listSchemas returns the schemas of catalog that policy allows.
+func (t *trino) listSchemas(ctx context.Context, catalog string, query string, policy Policy) ([]string, error) {
+	if err := t.checkCatalog(ctx, catalog); err != nil {
+		return nil, err
+	}
+
+	rows, err := t.db.QueryContext(ctx, query, queryArgs(ctx)...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	schemas := []string{}
+
+	for rows.Next() {
+		var schema string
+
+		if err := rows.Scan(&schema); err != nil {
+			return nil, err
+		}
+
+		if policy.AllowsSchema(catalog, schema) {
+			schemas = append(schemas, schema)
+		}
+	}
+
+	return schemas, rows.Err()
+}
@@ -3,6 +3,7 @@ package trino
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -16,43 +17,36 @@ type ListTablesParams struct {
 func (t *trino) ListTables(ctx context.Context, request *mcp.CallToolRequest, params ListTablesParams) (*mcp.CallToolResult, any, error) {
 	log.Info("Listing tables...")
 
-	row := t.db.QueryRow(fmt.Sprintf(`WITH params AS (
-  SELECT '%s' AS schema_name, '%s' AS catalog_name
-),
-cols AS (
-  SELECT
-    table_schema,
-    table_name,
-    ordinal_position,
-    "column_name" || ' ' ||
-    "data_type" AS column_ddl
-  FROM %s.information_schema.columns
-  JOIN params p ON columns.table_schema = p.schema_name
-),
-tables_ddl AS (
-  SELECT
-    t.table_schema,
-    t.table_name,
-    CONCAT(
-      'CREATE TABLE ',
-      p.catalog_name, '.', t.table_schema, '.', t.table_name,
-      ' (', CHR(10), '  ',
-      ARRAY_JOIN(ARRAY_AGG(c.column_ddl ORDER BY c.ordinal_position), CONCAT(',', CHR(10), '  ')),
-      CHR(10), ')'
-    ) AS create_statement
-  FROM %s.information_schema.tables t
-  JOIN params p ON t.table_schema = p.schema_name
-  LEFT JOIN cols c
-    ON c.table_schema = t.table_schema
-    AND c.table_name = t.table_name
-  GROUP BY p.catalog_name, t.table_schema, t.table_name
-)
-SELECT ARRAY_JOIN(ARRAY_AGG(create_statement), CONCAT(CHR(10), CHR(10))) AS full_schema_sql
-FROM tables_ddl`, params.Schema, params.Catalog, params.Catalog, params.Catalog))
+	policy := t.policyFor(request)
+
+	if err := ValidateIdentifier("catalog", params.Catalog); err != nil {
+		return ToolError("The catalog is invalid", err)
+	}
+
+	if err := ValidateIdentifier("schema", params.Schema); err != nil {
+		return ToolError("The schema is invalid", err)
+	}
+
+	if !policy.AllowsCatalog(params.Catalog) {
+		return catalogNotAllowed(params.Catalog)
+	}
+
+	if !policy.AllowsSchema(params.Catalog, params.Schema) {
+		return ToolError("Error retrieving tables", fmt.Errorf("%w: schema %s.%s is not allowed", ErrOutOfScope, params.Catalog, params.Schema))
+	}
+
+	catalog, schema := strings.ToLower(params.Catalog), strings.ToLower(params.Schema)
+
+	query := fmt.Sprintf(`SELECT table_name, column_name, data_type
+FROM %s.information_schema.columns
+WHERE table_schema = ?
+ORDER BY table_name, ordinal_position`, QuoteIdentifier(catalog))
+
+	ctx, execution := t.startExecution(ctx, request, "MCP list-tables", query)
 
-	var fullSchemaSQL string
+	fullSchemaSQL, err := t.listTables(ctx, catalog, schema, query, policy)
 
-	if err := row.Scan(&fullSchemaSQL); err != nil {
+	if err := execution.Finish(err); err != nil {
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{
@@ -71,3 +65,52 @@ FROM tables_ddl`, params.Schema, params.Catalog, params.Catalog, params.Catalog)
 		},
 	}, nil, nil
 }
+
+// listTables returns a CREATE TABLE statement for every table in
+// catalog.schema that policy allows, leaving out the columns it hides.
+func (t *trino) listTables(ctx context.Context, catalog string, schema string, query string, policy Policy) (string, error) {
+	if err := t.checkSchema(ctx, catalog, schema); err != nil {
+		return "", err
+	}
+
+	rows, err := t.db.QueryContext(ctx, query, queryArgs(ctx, schema)...)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer rows.Close()
+
+	tables := []string{}
+	columns := map[string][]string{}
+
+	for rows.Next() {
+		var table, column, dataType string
+
+		if err := rows.Scan(&table, &column, &dataType); err != nil {
+			return "", err
+		}
+
+		if policy.CheckTable(catalog, schema, table) != nil || policy.HidesColumn(table, column) {
+			continue
+		}
+
+		if _, ok := columns[table]; !ok {
+			tables = append(tables, table)
+		}
+
+		columns[table] = append(columns[table], column+" "+dataType)
+	}
+
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	statements := []string{}
+
+	for _, table := range tables {
+		statements = append(statements, fmt.Sprintf("CREATE TABLE %s.%s.%s (\n  %s\n)", catalog, schema, table, strings.Join(columns[table], ",\n  ")))
+	}
+
+	return strings.Join(statements, "\n\n"), nil
+}
@@ -3,6 +3,7 @@ package trino
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -11,7 +12,7 @@ import (
 func (t *trino) ListCatalogs(ctx context.Context, request *mcp.CallToolRequest, params any) (*mcp.CallToolResult, any, error) {
 	log.Info("Listing catalogs...")
 
-	row := t.db.QueryRow(`SELECT
+	query := `SELECT
     ARRAY_JOIN(
         -- 1. Aggregate all catalog_name values into an array
         ARRAY_AGG(catalog_name),
@@ -21,11 +22,15 @@ func (t *trino) ListCatalogs(ctx context.Context, request *mcp.CallToolRequest,
 FROM
     system.metadata.catalogs
 WHERE
-    catalog_name <> 'system'`)
+    catalog_name <> 'system'`
+
+	ctx, execution := t.startExecution(ctx, request, "MCP list-catalogs", query)
 
 	var catalogList string
 
-	if err := row.Scan(&catalogList); err != nil {
+	err := t.db.QueryRowContext(ctx, query, queryArgs(ctx)...).Scan(&catalogList)
+
+	if err := execution.Finish(err); err != nil {
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{
@@ -36,10 +41,19 @@ WHERE
 		}, nil, err
 	}
 
+	policy := t.policyFor(request)
+	catalogs := []string{}
+
+	for catalog := range strings.SplitSeq(catalogList, ", ") {
+		if catalog != "" && policy.AllowsCatalog(catalog) {
+			catalogs = append(catalogs, catalog)
+		}
+	}
+
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{
-				Text: catalogList,
+				Text: strings.Join(catalogs, ", "),
 			},
 		},
 	}, nil, nil
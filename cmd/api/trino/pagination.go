@@ -0,0 +1,138 @@
+package trino
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+)
+
+var ErrUnknownColumn = errors.New("unknown result column")
+
+// unorderableTypes are the Trino types ORDER BY rejects, on their own or
+// inside an array or row.
+var unorderableTypes = []string{"map", "json", "hyperloglog", "qdigest", "tdigest", "setdigest", "geometry", "sphericalgeography"}
+
+type ResultSort struct {
+	Column     string
+	Descending bool
+}
+
+type ResultFilter struct {
+	Column string
+	Value  string
+}
+
+// ResultOptions describes how a saved query is wrapped before it is run.
+// A PageSize of zero returns every row.
+type ResultOptions struct {
+	Page     int
+	PageSize int
+	Sort     []ResultSort
+	Filters  []ResultFilter
+}
+
+// DescribeColumns returns the columns a query produces without reading any of
+// its rows.
+func DescribeColumns(ctx context.Context, db *sql.DB, query string, args ...any) ([]system.DynamicQueryResultColumn, error) {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT * FROM (%s) AS dynamic_query_results LIMIT 0", query), queryArgs(ctx, args...)...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	result, err := ScanDynamicQueryResult(rows)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Columns, nil
+}
+
+// BuildResultQueries wraps query in an outer SELECT that applies the filters,
+// sorting and paging in options. It returns the wrapped query, a matching
+// COUNT(*) query and the arguments that both of them expect.
+func BuildResultQueries(query string, columns []system.DynamicQueryResultColumn, options ResultOptions) (string, string, []any, error) {
+	columnNames := []string{}
+
+	for _, column := range columns {
+		columnNames = append(columnNames, column.Name)
+	}
+
+	conditions := []string{}
+	args := []any{}
+
+	for _, filter := range options.Filters {
+		if !slices.Contains(columnNames, filter.Column) {
+			return "", "", nil, fmt.Errorf("%w: %s", ErrUnknownColumn, filter.Column)
+		}
+
+		conditions = append(conditions, fmt.Sprintf("strpos(lower(CAST(%s AS VARCHAR)), lower(?)) > 0", QuoteIdentifier(filter.Column)))
+		args = append(args, filter.Value)
+	}
+
+	orderings := []string{}
+
+	for _, sort := range options.Sort {
+		if !slices.Contains(columnNames, sort.Column) {
+			return "", "", nil, fmt.Errorf("%w: %s", ErrUnknownColumn, sort.Column)
+		}
+
+		direction := "ASC"
+
+		if sort.Descending {
+			direction = "DESC"
+		}
+
+		orderings = append(orderings, fmt.Sprintf("%s %s", QuoteIdentifier(sort.Column), direction))
+	}
+
+	// Every column follows, by position, so that rows the sort leaves tied,
+	// or every row when nothing is sorted, keep the same order across pages.
+	for position, column := range columns {
+		if orderable(column.Type) {
+			orderings = append(orderings, strconv.Itoa(position+1))
+		}
+	}
+
+	where := ""
+
+	if len(conditions) > 0 {
+		where = fmt.Sprintf("\nWHERE %s", strings.Join(conditions, " AND "))
+	}
+
+	selectQuery := fmt.Sprintf("SELECT * FROM (\n%s\n) AS dynamic_query_results%s", query, where)
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM (\n%s\n) AS dynamic_query_results%s", query, where)
+
+	if len(orderings) > 0 {
+		selectQuery = fmt.Sprintf("%s\nORDER BY %s", selectQuery, strings.Join(orderings, ", "))
+	}
+
+	if options.PageSize > 0 {
+		page := max(options.Page, 1)
+
+		selectQuery = fmt.Sprintf("%s\nOFFSET %d\nLIMIT %d", selectQuery, (page-1)*options.PageSize, options.PageSize)
+	}
+
+	return selectQuery, countQuery, args, nil
+}
+
+func orderable(columnType string) bool {
+	columnType = strings.ToLower(columnType)
+
+	for _, unorderable := range unorderableTypes {
+		if strings.Contains(columnType, unorderable) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,39 @@
+package trino
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+)
+
+func TestBuildResultQueriesOrder(t *testing.T) {
+	columns := []system.DynamicQueryResultColumn{
+		{Name: "id", Type: "bigint"},
+		{Name: "details", Type: "map(varchar, varchar)"},
+		{Name: "name", Type: "varchar"},
+	}
+
+	tests := []struct {
+		name  string
+		sort  []ResultSort
+		order string
+	}{
+		{"default", nil, "\nORDER BY 1, 3\n"},
+		{"sorted", []ResultSort{{Column: "name", Descending: true}}, "\nORDER BY \"name\" DESC, 1, 3\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selectQuery, _, _, err := BuildResultQueries("SELECT 1", columns, ResultOptions{Page: 2, PageSize: 10, Sort: test.sort})
+
+			if err != nil {
+				t.Fatalf("BuildResultQueries() = %v, want no error", err)
+			}
+
+			if !strings.Contains(selectQuery, test.order) {
+				t.Fatalf("BuildResultQueries() = %q, want it to contain %q", selectQuery, test.order)
+			}
+		})
+	}
+}
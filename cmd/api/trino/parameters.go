@@ -0,0 +1,310 @@
+package trino
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	trinoDriver "github.com/trinodb/trino-go-client/trino"
+)
+
+var ErrInvalidParameter = errors.New("invalid parameter")
+
+// placeholderPattern matches {{name}}, {{name.start}} and {{name.end}}.
+var placeholderPattern = regexp.MustCompile(`^\{\{\s*([A-Za-z_][A-Za-z0-9_]*)(?:\.(start|end))?\s*\}\}$`)
+
+// placeholder is a {{name}}, {{name.start}} or {{name.end}} in a query.
+type placeholder struct {
+	token sqlToken
+	name  string
+	// part is start or end for a date range, otherwise empty.
+	part string
+}
+
+// placeholders returns the placeholders of query in order, skipping any that
+// are inside a string literal, a quoted identifier or a comment.
+func placeholders(query string) ([]placeholder, error) {
+	tokens, err := tokenize(query)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidParameter, err.Error())
+	}
+
+	found := []placeholder{}
+
+	for _, token := range tokens {
+		if token.kind != placeholderToken {
+			continue
+		}
+
+		match := placeholderPattern.FindStringSubmatch(token.text)
+
+		if match == nil {
+			return nil, fmt.Errorf("%w: placeholder %s is not a parameter name", ErrInvalidParameter, token.text)
+		}
+
+		found = append(found, placeholder{token: token, name: match[1], part: match[2]})
+	}
+
+	return found, nil
+}
+
+var parameterNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+const maxStringParameterLength = 255
+
+// ValidateParameters checks that the declared parameters are well formed and
+// that every placeholder in query refers to one of them.
+func ValidateParameters(query string, parameters system.DynamicQueryParameters) error {
+	declared := map[string]system.DynamicQueryParameter{}
+
+	for _, parameter := range parameters {
+		if !parameterNamePattern.MatchString(parameter.Name) {
+			return fmt.Errorf("%w: %q is not a valid parameter name", ErrInvalidParameter, parameter.Name)
+		}
+
+		if _, ok := declared[parameter.Name]; ok {
+			return fmt.Errorf("%w: %s is declared more than once", ErrInvalidParameter, parameter.Name)
+		}
+
+		switch parameter.Type {
+		case system.DateParameter, system.DateRangeParameter, system.POPParameter, system.StringParameter, system.NumberParameter:
+		case system.EnumParameter:
+			if len(parameter.Options) == 0 {
+				return fmt.Errorf("%w: %s must declare at least one option", ErrInvalidParameter, parameter.Name)
+			}
+		default:
+			return fmt.Errorf("%w: %s has unsupported type %q", ErrInvalidParameter, parameter.Name, parameter.Type)
+		}
+
+		if parameter.Default != "" {
+			if _, err := parseParameterValue(parameter, parameter.Default); err != nil {
+				return fmt.Errorf("%w: default for %s: %s", ErrInvalidParameter, parameter.Name, err.Error())
+			}
+		}
+
+		declared[parameter.Name] = parameter
+	}
+
+	found, err := placeholders(query)
+
+	if err != nil {
+		return err
+	}
+
+	for _, placeholder := range found {
+		parameter, ok := declared[placeholder.name]
+
+		if !ok {
+			return fmt.Errorf("%w: placeholder %s is not declared", ErrInvalidParameter, placeholder.token.text)
+		}
+
+		if (parameter.Type == system.DateRangeParameter) != (placeholder.part != "") {
+			return fmt.Errorf("%w: placeholder %s does not match the %s parameter type", ErrInvalidParameter, placeholder.token.text, parameter.Type)
+		}
+	}
+
+	return nil
+}
+
+// BindParameters replaces every placeholder in query with a ? and returns the
+// typed values, in placeholder order, to pass to Trino as query arguments.
+// Values missing from values fall back to the parameter default.
+func BindParameters(query string, parameters system.DynamicQueryParameters, values map[string]string) (string, []any, error) {
+	if err := ValidateParameters(query, parameters); err != nil {
+		return "", nil, err
+	}
+
+	bound := map[string][]any{}
+
+	for _, parameter := range parameters {
+		value := strings.TrimSpace(values[parameter.Name])
+
+		if value == "" {
+			value = parameter.Default
+		}
+
+		if value == "" && parameter.Required {
+			return "", nil, fmt.Errorf("%w: %s is required", ErrInvalidParameter, parameter.Name)
+		}
+
+		if value == "" {
+			bound[parameter.Name] = []any{nil, nil}
+
+			continue
+		}
+
+		parsed, err := parseParameterValue(parameter, value)
+
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: %s: %s", ErrInvalidParameter, parameter.Name, err.Error())
+		}
+
+		bound[parameter.Name] = parsed
+	}
+
+	return substituteParameters(query, bound)
+}
+
+// BindParametersForPlanning binds query for EXPLAIN and previews, where no
+// values are given. Each parameter takes its default, or a value of its type
+// when it has none, so that required parameters do not stop Trino from
+// planning the query.
+func BindParametersForPlanning(query string, parameters system.DynamicQueryParameters) (string, []any, error) {
+	if err := ValidateParameters(query, parameters); err != nil {
+		return "", nil, err
+	}
+
+	bound := map[string][]any{}
+
+	for _, parameter := range parameters {
+		if parameter.Default == "" {
+			bound[parameter.Name] = planningValue(parameter)
+
+			continue
+		}
+
+		parsed, err := parseParameterValue(parameter, parameter.Default)
+
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: %s: %s", ErrInvalidParameter, parameter.Name, err.Error())
+		}
+
+		bound[parameter.Name] = parsed
+	}
+
+	return substituteParameters(query, bound)
+}
+
+// planningValue is a value of the type of parameter that stands in for one
+// that was not given.
+func planningValue(parameter system.DynamicQueryParameter) []any {
+	today := trinoDate(time.Now())
+
+	switch parameter.Type {
+	case system.DateParameter:
+		return []any{today}
+	case system.DateRangeParameter:
+		return []any{today, today}
+	case system.NumberParameter:
+		return []any{trinoDriver.Numeric("0")}
+	case system.EnumParameter:
+		return []any{parameter.Options[0]}
+	default:
+		return []any{""}
+	}
+}
+
+// substituteParameters replaces every placeholder in query with a ? and
+// returns the values in bound, in placeholder order. Placeholders inside
+// string literals, quoted identifiers and comments are left as they are.
+func substituteParameters(query string, bound map[string][]any) (string, []any, error) {
+	found, err := placeholders(query)
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	runes := []rune(query)
+	substituted := strings.Builder{}
+	args := []any{}
+	last := 0
+
+	for _, placeholder := range found {
+		substituted.WriteString(string(runes[last:placeholder.token.offset]))
+		substituted.WriteString("?")
+
+		last = placeholder.token.offset + len([]rune(placeholder.token.text))
+
+		switch placeholder.part {
+		case "end":
+			args = append(args, bound[placeholder.name][1])
+		default:
+			args = append(args, bound[placeholder.name][0])
+		}
+	}
+
+	substituted.WriteString(string(runes[last:]))
+
+	return substituted.String(), args, nil
+}
+
+func parseParameterValue(parameter system.DynamicQueryParameter, value string) ([]any, error) {
+	switch parameter.Type {
+	case system.DateParameter:
+		date, err := parseDate(value)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return []any{trinoDate(date)}, nil
+	case system.DateRangeParameter:
+		start, end, ok := strings.Cut(value, ",")
+
+		if !ok {
+			return nil, errors.New("expected a start and end date separated by a comma")
+		}
+
+		startDate, err := parseDate(start)
+
+		if err != nil {
+			return nil, err
+		}
+
+		endDate, err := parseDate(end)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if endDate.Before(startDate) {
+			return nil, errors.New("the end date is before the start date")
+		}
+
+		return []any{trinoDate(startDate), trinoDate(endDate)}, nil
+	case system.NumberParameter:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("%q is not a number", value)
+		}
+
+		return []any{trinoDriver.Numeric(value)}, nil
+	case system.EnumParameter:
+		if !slices.Contains(parameter.Options, value) {
+			return nil, fmt.Errorf("%q is not one of %s", value, strings.Join(parameter.Options, ", "))
+		}
+
+		return []any{value}, nil
+	default:
+		if len(value) > maxStringParameterLength {
+			return nil, fmt.Errorf("value is longer than %d characters", maxStringParameterLength)
+		}
+
+		return []any{value}, nil
+	}
+}
+
+func parseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date, nil
+	}
+
+	date, err := time.Parse(time.RFC3339, value)
+
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a date", value)
+	}
+
+	return date, nil
+}
+
+func trinoDate(date time.Time) any {
+	return trinoDriver.Date(date.Year(), date.Month(), date.Day())
+}
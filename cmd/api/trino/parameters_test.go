@@ -0,0 +1,99 @@
+package trino
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	trinoDriver "github.com/trinodb/trino-go-client/trino"
+)
+
+func TestBindParameters(t *testing.T) {
+	parameters := system.DynamicQueryParameters{
+		{Name: "pop", Type: system.POPParameter, Required: true},
+		{Name: "period", Type: system.DateRangeParameter},
+		{Name: "status", Type: system.EnumParameter, Options: []string{"active", "expired"}, Default: "active"},
+		{Name: "minimum", Type: system.NumberParameter},
+	}
+
+	start, end := trinoDate(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)), trinoDate(time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name   string
+		query  string
+		values map[string]string
+		want   string
+		args   []any
+		err    error
+	}{
+		{"placeholder", `SELECT * FROM t WHERE pop = {{pop}}`, map[string]string{"pop": "north"}, `SELECT * FROM t WHERE pop = ?`, []any{"north"}, nil},
+		{"spaces", `SELECT * FROM t WHERE pop = {{ pop }}`, map[string]string{"pop": "north"}, `SELECT * FROM t WHERE pop = ?`, []any{"north"}, nil},
+		{"literal", `SELECT '{{pop}}', "{{pop}}" FROM t WHERE pop = {{pop}}`, map[string]string{"pop": "north"}, `SELECT '{{pop}}', "{{pop}}" FROM t WHERE pop = ?`, []any{"north"}, nil},
+		{"comments", "SELECT 1 -- {{pop}}\n/* {{minimum}} */ WHERE pop = {{pop}}", map[string]string{"pop": "north"}, "SELECT 1 -- {{pop}}\n/* {{minimum}} */ WHERE pop = ?", []any{"north"}, nil},
+		{"range", `SELECT * FROM t WHERE d BETWEEN {{period.start}} AND {{period.end}} AND pop = {{pop}}`, map[string]string{"pop": "north", "period": "2026-01-01,2026-01-31"}, `SELECT * FROM t WHERE d BETWEEN ? AND ? AND pop = ?`, []any{start, end, "north"}, nil},
+		{"range reversed", `SELECT {{period.end}}, {{period.start}}`, map[string]string{"pop": "north", "period": "2026-01-01,2026-01-31"}, `SELECT ?, ?`, []any{end, start}, nil},
+		{"default", `SELECT * FROM t WHERE status = {{status}} AND pop = {{pop}}`, map[string]string{"pop": "north"}, `SELECT * FROM t WHERE status = ? AND pop = ?`, []any{"active", "north"}, nil},
+		{"value over default", `SELECT {{status}}`, map[string]string{"pop": "north", "status": "expired"}, `SELECT ?`, []any{"expired"}, nil},
+		{"optional without value", `SELECT {{minimum}}, {{period.start}}`, map[string]string{"pop": "north"}, `SELECT ?, ?`, []any{nil, nil}, nil},
+		{"number", `SELECT {{minimum}}`, map[string]string{"pop": "north", "minimum": "10.5"}, `SELECT ?`, []any{trinoDriver.Numeric("10.5")}, nil},
+		{"missing required", `SELECT {{pop}}`, map[string]string{}, "", nil, ErrInvalidParameter},
+		{"blank required", `SELECT {{pop}}`, map[string]string{"pop": "  "}, "", nil, ErrInvalidParameter},
+		{"undeclared", `SELECT {{other}}`, map[string]string{"pop": "north"}, "", nil, ErrInvalidParameter},
+		{"range without part", `SELECT {{period}}`, map[string]string{"pop": "north"}, "", nil, ErrInvalidParameter},
+		{"invalid option", `SELECT {{status}}`, map[string]string{"pop": "north", "status": "unknown"}, "", nil, ErrInvalidParameter},
+		{"invalid range", `SELECT {{period.start}}`, map[string]string{"pop": "north", "period": "2026-01-31,2026-01-01"}, "", nil, ErrInvalidParameter},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, args, err := BindParameters(test.query, parameters, test.values)
+
+			if test.err != nil {
+				if !errors.Is(err, test.err) {
+					t.Fatalf("BindParameters(%q) error = %v, want %v", test.query, err, test.err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("BindParameters(%q) error = %v, want none", test.query, err)
+			}
+
+			if query != test.want {
+				t.Errorf("BindParameters(%q) query = %q, want %q", test.query, query, test.want)
+			}
+
+			if !reflect.DeepEqual(args, test.args) {
+				t.Errorf("BindParameters(%q) args = %v, want %v", test.query, args, test.args)
+			}
+		})
+	}
+}
+
+func TestBindParametersForPlanning(t *testing.T) {
+	parameters := system.DynamicQueryParameters{
+		{Name: "pop", Type: system.POPParameter, Required: true},
+		{Name: "period", Type: system.DateRangeParameter, Required: true},
+		{Name: "status", Type: system.EnumParameter, Options: []string{"active", "expired"}, Required: true},
+		{Name: "minimum", Type: system.NumberParameter, Default: "5"},
+	}
+
+	query, args, err := BindParametersForPlanning(`SELECT {{pop}}, {{period.start}}, {{period.end}}, {{status}}, {{minimum}}, '{{pop}}'`, parameters)
+
+	if err != nil {
+		t.Fatalf("BindParametersForPlanning() error = %v, want none", err)
+	}
+
+	if want := `SELECT ?, ?, ?, ?, ?, '{{pop}}'`; query != want {
+		t.Errorf("BindParametersForPlanning() query = %q, want %q", query, want)
+	}
+
+	today := trinoDate(time.Now())
+
+	if want := []any{"", today, today, "active", trinoDriver.Numeric("5")}; !reflect.DeepEqual(args, want) {
+		t.Errorf("BindParametersForPlanning() args = %v, want %v", args, want)
+	}
+}
@@ -0,0 +1,211 @@
+package trino
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/internal/masking"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	defaultTopValues = 10
+	maxTopValues     = 25
+)
+
+// orderableTypes are the Trino types, by prefix, that have a minimum and a
+// maximum and can be cast to varchar.
+var orderableTypes = []string{
+	"tinyint", "smallint", "integer", "bigint", "real", "double", "decimal",
+	"varchar", "char", "date", "time", "timestamp",
+}
+
+type ProfileColumnParams struct {
+	Catalog string `json:"catalog"`
+	Schema  string `json:"schema"`
+	Table   string `json:"table"`
+	Column  string `json:"column"`
+	Top     int    `json:"top,omitempty" jsonschema:"The number of most common values to return, 10 by default and at most 25."`
+}
+
+// ColumnProfile is the result of the profile-column tool. Min, Max and
+// TopValues are masked when the column has a masking rule.
+type ColumnProfile struct {
+	Catalog   string       `json:"catalog"`
+	Schema    string       `json:"schema"`
+	Table     string       `json:"table"`
+	Column    string       `json:"column"`
+	Type      string       `json:"type"`
+	Rows      int64        `json:"rows"`
+	Nulls     int64        `json:"nulls"`
+	NullRatio float64      `json:"null_ratio"`
+	Distinct  int64        `json:"distinct"`
+	Min       *string      `json:"min"`
+	Max       *string      `json:"max"`
+	TopValues []ValueCount `json:"top_values"`
+	Masked    bool         `json:"masked"`
+}
+
+// ValueCount is how many rows hold a value.
+type ValueCount struct {
+	Value *string `json:"value"`
+	Count int64   `json:"count"`
+}
+
+func (t *trino) ProfileColumn(ctx context.Context, request *mcp.CallToolRequest, params ProfileColumnParams) (*mcp.CallToolResult, any, error) {
+	log.Infof("Profiling column %s.%s.%s.%s...", params.Catalog, params.Schema, params.Table, params.Column)
+
+	table := TableParams{Catalog: params.Catalog, Schema: params.Schema, Table: params.Table}
+
+	if result, _, err := popScoped(request, "profile-column"); result != nil {
+		return result, nil, err
+	}
+
+	if result, _, err := t.allowTable(request, table); result != nil {
+		return result, nil, err
+	}
+
+	if err := ValidateIdentifier("column", params.Column); err != nil {
+		return ToolError("The column is invalid", err)
+	}
+
+	top := params.Top
+
+	if top <= 0 {
+		top = defaultTopValues
+	}
+
+	top = min(top, maxTopValues)
+
+	rules, err := t.maskingRules(ctx, request)
+
+	if err != nil {
+		return ToolError("Error loading the masking rules", err)
+	}
+
+	ctx, execution := t.startExecution(ctx, request, "MCP profile-column", fmt.Sprintf("%s.%s", table.name(), QuoteIdentifier(params.Column)))
+
+	profile, err := t.profileColumn(ctx, table, params.Column, t.policyFor(request), top)
+
+	if err := execution.Finish(err); err != nil {
+		return ToolError("Error profiling the column", err)
+	}
+
+	if rule, ok := rules.Rule(profile.Column); ok {
+		profile.Masked = true
+
+		for _, value := range []*string{profile.Min, profile.Max} {
+			if value != nil {
+				*value = masking.Apply(rule, *value)
+			}
+		}
+
+		for _, topValue := range profile.TopValues {
+			if topValue.Value != nil {
+				*topValue.Value = masking.Apply(rule, *topValue.Value)
+			}
+		}
+	}
+
+	return JSONResult(profile)
+}
+
+func (t *trino) profileColumn(ctx context.Context, table TableParams, name string, policy Policy, top int) (ColumnProfile, error) {
+	columns, err := t.tableColumns(ctx, table, policy)
+
+	if err != nil {
+		return ColumnProfile{}, err
+	}
+
+	index := slices.IndexFunc(columns, func(column TableColumn) bool {
+		return strings.EqualFold(column.Name, name)
+	})
+
+	if index < 0 {
+		return ColumnProfile{}, fmt.Errorf("the column %s does not exist or is hidden", name)
+	}
+
+	column := columns[index]
+
+	profile := ColumnProfile{
+		Catalog:   strings.ToLower(table.Catalog),
+		Schema:    strings.ToLower(table.Schema),
+		Table:     strings.ToLower(table.Table),
+		Column:    column.Name,
+		Type:      column.Type,
+		TopValues: []ValueCount{},
+	}
+
+	quoted := QuoteIdentifier(column.Name)
+	orderable := slices.ContainsFunc(orderableTypes, func(prefix string) bool {
+		return strings.HasPrefix(column.Type, prefix)
+	})
+
+	minimum, maximum := "CAST(NULL AS VARCHAR)", "CAST(NULL AS VARCHAR)"
+
+	switch {
+	case orderable && (strings.HasPrefix(column.Type, "date") || strings.HasPrefix(column.Type, "time")):
+		// Dates and times are compared as text, which orders them the same
+		// way, because reading them directly can fail in the Trino driver.
+		minimum, maximum = fmt.Sprintf("min(CAST(%s AS VARCHAR))", quoted), fmt.Sprintf("max(CAST(%s AS VARCHAR))", quoted)
+	case orderable:
+		minimum, maximum = fmt.Sprintf("CAST(min(%s) AS VARCHAR)", quoted), fmt.Sprintf("CAST(max(%s) AS VARCHAR)", quoted)
+	}
+
+	var minimumValue, maximumValue sql.NullString
+
+	err = t.db.QueryRowContext(ctx, fmt.Sprintf("SELECT count(*), count(%s), count(DISTINCT %s), %s, %s FROM %s", quoted, quoted, minimum, maximum, table.name()), queryArgs(ctx)...).
+		Scan(&profile.Rows, &profile.Nulls, &profile.Distinct, &minimumValue, &maximumValue)
+
+	if err != nil {
+		return profile, err
+	}
+
+	// count(column) counted the rows that are not null.
+	profile.Nulls = profile.Rows - profile.Nulls
+
+	if profile.Rows > 0 {
+		profile.NullRatio = float64(profile.Nulls) / float64(profile.Rows)
+	}
+
+	if minimumValue.Valid {
+		profile.Min = &minimumValue.String
+	}
+
+	if maximumValue.Valid {
+		profile.Max = &maximumValue.String
+	}
+
+	if !orderable && column.Type != "boolean" {
+		return profile, nil
+	}
+
+	rows, err := t.db.QueryContext(ctx, fmt.Sprintf("SELECT CAST(%s AS VARCHAR), count(*) FROM %s GROUP BY 1 ORDER BY 2 DESC, 1 LIMIT %d", quoted, table.name(), top), queryArgs(ctx)...)
+
+	if err != nil {
+		return profile, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var value sql.NullString
+		var topValue ValueCount
+
+		if err := rows.Scan(&value, &topValue.Count); err != nil {
+			return profile, err
+		}
+
+		if value.Valid {
+			topValue.Value = &value.String
+		}
+
+		profile.TopValues = append(profile.TopValues, topValue)
+	}
+
+	return profile, rows.Err()
+}
@@ -0,0 +1,166 @@
+package trino
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/internal/ai"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Prompts are the reusable MCP prompts, with the arguments each one takes.
+var Prompts = []*mcp.Prompt{
+	{
+		Name:        "generate-dynamic-query",
+		Description: "Write a Trino SQL query for a report, following the same instructions as dynamic query generation.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "request", Description: "The report to write a query for, in plain language.", Required: true},
+		},
+	},
+	{
+		Name:        "explore-table",
+		Description: "Explain what a Zing or Radius table holds and how it joins to the other tables.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "catalog", Description: "The catalog of the table, zing or radius.", Required: true},
+			{Name: "table", Description: "The name of the table, such as Customers.", Required: true},
+		},
+	},
+	{
+		Name:        "pop-summary",
+		Description: "Summarise this month's revenue, recharges and expiring customers for a POP.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "pop", Description: "The POP to summarise.", Required: true},
+		},
+	},
+}
+
+// GenerateDynamicQueryPrompt renders the default prompt template, or the
+// built-in one when none is marked as the default, with the glossary kept in
+// Postgres, followed by the request.
+func (t *trino) GenerateDynamicQueryPrompt(ctx context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	log.Info("Rendering the generate-dynamic-query prompt...")
+
+	arguments, err := promptArguments(request, "request")
+
+	if err != nil {
+		return nil, err
+	}
+
+	body := ai.DefaultPromptTemplate
+	promptTemplate, err := t.postgres.GetDefaultPromptTemplate(ctx)
+
+	if err != nil && !strings.Contains(err.Error(), "no rows in result set") {
+		log.Errorf("🔥 Error loading the default prompt template: %s", err.Error())
+
+		return nil, err
+	}
+
+	if err == nil {
+		body = promptTemplate.Body
+	}
+
+	promptData := t.promptData
+
+	if promptData.Glossary, err = ai.LoadGlossary(ctx, t.postgres); err != nil {
+		log.Errorf("🔥 Error loading the glossary: %s", err.Error())
+
+		return nil, err
+	}
+
+	instructions, err := ai.RenderPrompt(body, promptData)
+
+	if err != nil {
+		log.Errorf("🔥 Error rendering the prompt template: %s", err.Error())
+
+		return nil, err
+	}
+
+	return &mcp.GetPromptResult{
+		Description: "Write a Trino SQL query for a report.",
+		Messages: []*mcp.PromptMessage{
+			{Role: "user", Content: &mcp.TextContent{Text: instructions}},
+			{Role: "user", Content: &mcp.TextContent{Text: arguments["request"]}},
+		},
+	}, nil
+}
+
+// ExploreTablePrompt asks for a table to be explained from its resources and
+// the table tools.
+func (t *trino) ExploreTablePrompt(ctx context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	log.Info("Rendering the explore-table prompt...")
+
+	arguments, err := promptArguments(request, "catalog", "table")
+
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, table := arguments["catalog"], arguments["table"]
+
+	text := strings.Join([]string{
+		fmt.Sprintf("Explain what the %s table in the %s catalog holds, for someone writing reports on it.", table, catalog),
+		"",
+		fmt.Sprintf("1. Read the %s%s/%s resource for its columns, and the %s and %s resources.", tableSchemaURIPrefix, catalog, table, RelationshipsURI, GlossaryURI),
+		"2. Find its schema with list-schemas, then call describe-table and profile-column on the columns that matter to see what they hold.",
+		"3. Call suggest-joins to see how it joins to the other Zing and Radius tables.",
+		"",
+		"Describe what one row stands for, the columns that identify, filter and date a row, and the tables it is most often joined to, using the glossary's terms.",
+	}, "\n")
+
+	return &mcp.GetPromptResult{
+		Description: fmt.Sprintf("Explain the %s.%s table.", catalog, table),
+		Messages: []*mcp.PromptMessage{
+			{Role: "user", Content: &mcp.TextContent{Text: text}},
+		},
+	}, nil
+}
+
+// PopSummaryPrompt asks for a POP to be summarised from the report tools.
+func (t *trino) PopSummaryPrompt(ctx context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	log.Info("Rendering the pop-summary prompt...")
+
+	arguments, err := promptArguments(request, "pop")
+
+	if err != nil {
+		return nil, err
+	}
+
+	pop := arguments["pop"]
+
+	text := strings.Join([]string{
+		fmt.Sprintf("Summarise how the %s POP is doing this month.", pop),
+		"",
+		fmt.Sprintf("1. Call analytics-monthly-revenue with the pop %q for the revenue, its growth and the unique purchasers.", pop),
+		fmt.Sprintf("2. Call report-recharge-type-counts with the pop %q, the period months and a count of 3 to compare the products bought with the last two months.", pop),
+		fmt.Sprintf("3. Call report-expiring-customers with the pop %q and expires_to a week from today for the customers to follow up with.", pop),
+		"",
+		fmt.Sprintf("Read the %s resource for what the terms mean. Keep the summary short, lead with the revenue and call out anything unusual.", GlossaryURI),
+	}, "\n")
+
+	return &mcp.GetPromptResult{
+		Description: fmt.Sprintf("Summarise the %s POP.", pop),
+		Messages: []*mcp.PromptMessage{
+			{Role: "user", Content: &mcp.TextContent{Text: text}},
+		},
+	}, nil
+}
+
+// promptArguments returns the arguments of request, trimmed, or an error if
+// any of required is missing.
+func promptArguments(request *mcp.GetPromptRequest, required ...string) (map[string]string, error) {
+	arguments := map[string]string{}
+
+	for name, value := range request.Params.Arguments {
+		arguments[name] = strings.TrimSpace(value)
+	}
+
+	for _, name := range required {
+		if arguments[name] == "" {
+			return nil, fmt.Errorf("the %s argument is required", name)
+		}
+	}
+
+	return arguments, nil
+}
@@ -0,0 +1,90 @@
+package trino
+
+import "strings"
+
+// ColumnReference names a column of a table in a catalog. The schema is left
+// out because it is named after the database the catalog connects to.
+type ColumnReference struct {
+	Catalog string `json:"catalog"`
+	Table   string `json:"table"`
+	Column  string `json:"column"`
+}
+
+func (r ColumnReference) String() string {
+	return r.Catalog + "." + r.Table + "." + r.Column
+}
+
+func (r ColumnReference) isTable(catalog string, table string) bool {
+	return strings.EqualFold(r.Catalog, catalog) && strings.EqualFold(r.Table, table)
+}
+
+// Relationship is a join between two columns. Many rows of From match at most
+// one row of To unless Cardinality says otherwise.
+type Relationship struct {
+	From        ColumnReference `json:"from"`
+	To          ColumnReference `json:"to"`
+	Cardinality string          `json:"cardinality"`
+	Description string          `json:"description"`
+}
+
+const (
+	ManyToOne  = "many-to-one"
+	OneToOne   = "one-to-one"
+	ManyToMany = "many-to-many"
+)
+
+func zingColumn(table string, column string) ColumnReference {
+	return ColumnReference{Catalog: "zing", Table: table, Column: column}
+}
+
+func radiusColumn(table string, column string) ColumnReference {
+	return ColumnReference{Catalog: "radius", Table: table, Column: column}
+}
+
+// Relationships are the curated joins within and between the Zing and Radius
+// databases. Neither database declares the joins between them, so they are
+// kept here for the suggest-joins tool.
+var Relationships = []Relationship{
+	{zingColumn("Customers", "AddressId"), zingColumn("Addresses", "Id"), ManyToOne, "The address a customer is connected at."},
+	{zingColumn("Addresses", "BuildId"), zingColumn("Builds", "Id"), ManyToOne, "The build an address was built under."},
+	{zingColumn("Builds", "BuildTypeId"), zingColumn("BuildTypes", "Id"), ManyToOne, "The kind of build."},
+	{zingColumn("Addresses", "SalesAgentId"), zingColumn("SalesAgents", "Id"), ManyToOne, "The sales agent who signed up an address."},
+	{zingColumn("Customers", "SalesAgentId"), zingColumn("SalesAgents", "Id"), ManyToOne, "The sales agent who signed up a customer."},
+	{zingColumn("Customers", "ApprovedByUserId"), zingColumn("Users", "Id"), ManyToOne, "The staff member who approved a customer."},
+	{zingColumn("Recharges", "CustomerId"), zingColumn("Customers", "Id"), ManyToOne, "The customer who bought a recharge."},
+	{zingColumn("Recharges", "ProductId"), zingColumn("Products", "Id"), ManyToOne, "The product a recharge bought."},
+	{zingColumn("Recharges", "UserId"), zingColumn("Users", "Id"), ManyToOne, "The staff member who captured a recharge."},
+	{zingColumn("CashPayments", "CustomerId"), zingColumn("Customers", "Id"), ManyToOne, "The customer who paid in cash."},
+	{zingColumn("CashPayments", "ProductId"), zingColumn("Products", "Id"), ManyToOne, "The product a cash payment was for."},
+	{zingColumn("CashPayments", "RechargeId"), zingColumn("Recharges", "Id"), ManyToOne, "The recharge a cash payment became."},
+	{zingColumn("PaymentRequests", "CustomerId"), zingColumn("Customers", "Id"), ManyToOne, "The customer a payment was requested from."},
+	{zingColumn("PaymentRequests", "ProductId"), zingColumn("Products", "Id"), ManyToOne, "The product a payment was requested for."},
+	{zingColumn("CustomerNotes", "CustomerId"), zingColumn("Customers", "Id"), ManyToOne, "The customer a note is about."},
+	{zingColumn("SmartOLTPOPMappings", "SmartOLTTenantId"), zingColumn("SmartOLTTenants", "Id"), ManyToOne, "The SmartOLT tenant a POP belongs to."},
+	{zingColumn("Addresses", "POP"), zingColumn("SmartOLTPOPMappings", "POP"), ManyToMany, "The SmartOLT mapping for the POP an address is connected through, matched by POP name."},
+	{zingColumn("Addresses", "RadiusUsername"), radiusColumn("rm_users", "username"), OneToOne, "The Radius account an address's router signs in with."},
+	{zingColumn("Customers", "RadiusUsername"), radiusColumn("rm_users", "username"), OneToOne, "The Radius account of a customer."},
+	{zingColumn("Products", "ServiceId"), radiusColumn("rm_services", "srvid"), ManyToOne, "The Radius service a product provisions."},
+	{zingColumn("Recharges", "FromRMSvcID"), radiusColumn("rm_services", "srvid"), ManyToOne, "The Radius service a customer was on before a recharge."},
+	{zingColumn("Recharges", "ToRMSvcID"), radiusColumn("rm_services", "srvid"), ManyToOne, "The Radius service a recharge moved a customer to."},
+	{radiusColumn("rm_users", "srvid"), radiusColumn("rm_services", "srvid"), ManyToOne, "The service a Radius account is on."},
+	{radiusColumn("rm_cards", "srvid"), radiusColumn("rm_services", "srvid"), ManyToOne, "The service a prepaid card grants."},
+	{radiusColumn("radacct", "username"), radiusColumn("rm_users", "username"), ManyToOne, "The Radius account a session belongs to."},
+	{radiusColumn("rm_radacct", "username"), radiusColumn("rm_users", "username"), ManyToOne, "The Radius account an accounting record belongs to."},
+	{radiusColumn("rm_invoices", "username"), radiusColumn("rm_users", "username"), ManyToOne, "The Radius account an invoice was raised for."},
+	{radiusColumn("radusergroup", "username"), radiusColumn("rm_users", "username"), ManyToOne, "The Radius account a group membership belongs to."},
+}
+
+// RelationshipsFor returns the relationships that join catalog.table to
+// another table.
+func RelationshipsFor(catalog string, table string) []Relationship {
+	relationships := []Relationship{}
+
+	for _, relationship := range Relationships {
+		if relationship.From.isTable(catalog, table) || relationship.To.isTable(catalog, table) {
+			relationships = append(relationships, relationship)
+		}
+	}
+
+	return relationships
+}
@@ -0,0 +1,193 @@
+package trino
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/internal/ai"
+	"github.com/connor-davis/zingfibre-core/internal/mysql"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// The MCP resources document the Zing and Radius databases so that clients
+// do not have to rediscover them with list-tables on every conversation.
+const (
+	TableSchemaURITemplate = "zing://schemas/{catalog}/{table}"
+	RelationshipsURI       = "zing://relationships"
+	GlossaryURI            = "zing://glossary"
+
+	tableSchemaURIPrefix = "zing://schemas/"
+)
+
+var mermaidCardinalities = map[string]string{
+	ManyToOne:  "}o--||",
+	OneToOne:   "|o--||",
+	ManyToMany: "}o--o{",
+}
+
+// TableSchemaResources returns a resource for the DDL of every Zing and
+// Radius table that policy lets be read from some schema.
+func TableSchemaResources(policy Policy) ([]*mcp.Resource, error) {
+	tableSchemas, err := mysql.TableSchemas()
+
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []*mcp.Resource{}
+
+	for _, tableSchema := range tableSchemas {
+		if !policy.AllowsCatalogTable(tableSchema.Catalog, tableSchema.Table) {
+			continue
+		}
+
+		resources = append(resources, &mcp.Resource{
+			URI:         tableSchemaURIPrefix + tableSchema.Catalog + "/" + tableSchema.Table,
+			Name:        tableSchema.Catalog + "." + tableSchema.Table,
+			Description: fmt.Sprintf("The MySQL DDL of the %s table in the %s catalog.", tableSchema.Table, tableSchema.Catalog),
+			MIMEType:    "application/sql",
+		})
+	}
+
+	return resources, nil
+}
+
+// ReadTableSchema returns the DDL of the table a zing://schemas resource
+// names, with the columns that can never be read called out.
+func (t *trino) ReadTableSchema(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := request.Params.URI
+
+	log.Infof("Reading the %s resource...", uri)
+
+	catalog, table, ok := strings.Cut(strings.TrimPrefix(uri, tableSchemaURIPrefix), "/")
+
+	if !ok || !strings.HasPrefix(uri, tableSchemaURIPrefix) {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	tableSchemas, err := mysql.TableSchemas()
+
+	if err != nil {
+		return nil, err
+	}
+
+	index := slices.IndexFunc(tableSchemas, func(tableSchema mysql.TableSchema) bool {
+		return strings.EqualFold(tableSchema.Catalog, catalog) && strings.EqualFold(tableSchema.Table, table)
+	})
+
+	if index == -1 {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	tableSchema := tableSchemas[index]
+	policy := t.policyForScopes(extraScopes(request.Extra))
+
+	if !policy.AllowsCatalogTable(tableSchema.Catalog, tableSchema.Table) {
+		return nil, fmt.Errorf("%w: table %s.%s", ErrOutOfScope, tableSchema.Catalog, tableSchema.Table)
+	}
+
+	lines := []string{
+		fmt.Sprintf("-- The %s table of the %s catalog, as MySQL creates it.", tableSchema.Table, tableSchema.Catalog),
+		fmt.Sprintf("-- Query it through Trino as %s.<schema>.%s, finding the schema with list-schemas.", tableSchema.Catalog, strings.ToLower(tableSchema.Table)),
+	}
+
+	if hidden := policy.HiddenColumns[strings.ToLower(tableSchema.Table)]; len(hidden) > 0 {
+		lines = append(lines, fmt.Sprintf("-- These columns can never be read: %s.", strings.Join(hidden, ", ")))
+	}
+
+	lines = append(lines, "", tableSchema.DDL)
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      uri,
+				MIMEType: "application/sql",
+				Text:     strings.Join(lines, "\n"),
+			},
+		},
+	}, nil
+}
+
+// ReadRelationships returns the curated relationships that the MCP token
+// behind request may read both sides of, as a Mermaid diagram followed by
+// the join each one stands for.
+func (t *trino) ReadRelationships(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	log.Info("Reading the relationships resource...")
+
+	policy := t.policyForScopes(extraScopes(request.Extra))
+	diagram := []string{"```mermaid", "erDiagram"}
+	joins := []string{}
+
+	for _, relationship := range Relationships {
+		from, to := relationship.From, relationship.To
+
+		if !policy.AllowsCatalogTable(from.Catalog, from.Table) || !policy.AllowsCatalogTable(to.Catalog, to.Table) {
+			continue
+		}
+
+		if policy.HidesColumn(from.Table, from.Column) || policy.HidesColumn(to.Table, to.Column) {
+			continue
+		}
+
+		diagram = append(diagram, fmt.Sprintf("    %s_%s %s %s_%s : %q", from.Catalog, from.Table, mermaidCardinalities[relationship.Cardinality], to.Catalog, to.Table, from.Column+" = "+to.Column))
+		joins = append(joins, fmt.Sprintf("- `%s` = `%s` (%s): %s", from, to, relationship.Cardinality, relationship.Description))
+	}
+
+	diagram = append(diagram, "```")
+
+	text := strings.Join([]string{
+		"# Zing and Radius relationships",
+		"",
+		"Tables are named catalog_table in the diagram. Use suggest-joins for the schema each table is in.",
+		"",
+		strings.Join(diagram, "\n"),
+		"",
+		strings.Join(joins, "\n"),
+	}, "\n")
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      RelationshipsURI,
+				MIMEType: "text/markdown",
+				Text:     text,
+			},
+		},
+	}, nil
+}
+
+// ReadGlossary returns the business glossary kept in Postgres.
+func (t *trino) ReadGlossary(ctx context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	log.Info("Reading the glossary resource...")
+
+	glossary, err := ai.LoadGlossary(ctx, t.postgres)
+
+	if err != nil {
+		log.Errorf("🔥 Error loading the glossary: %s", err.Error())
+
+		return nil, err
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{
+				URI:      GlossaryURI,
+				MIMEType: "text/markdown",
+				Text:     glossaryMarkdown(glossary),
+			},
+		},
+	}, nil
+}
+
+func glossaryMarkdown(glossary []ai.GlossaryTerm) string {
+	lines := []string{"# Business glossary", ""}
+
+	for _, term := range glossary {
+		lines = append(lines, fmt.Sprintf("- **%s:** %s", term.Term, term.Definition))
+	}
+
+	return strings.Join(lines, "\n")
+}
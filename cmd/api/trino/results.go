@@ -0,0 +1,212 @@
+package trino
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/goccy/go-json"
+)
+
+// CleanQuery strips the trailing whitespace and semicolons that the Go Trino
+// driver refuses to execute.
+func CleanQuery(query string) string {
+	return strings.TrimSpace(strings.TrimRight(strings.TrimSpace(query), ";"))
+}
+
+// ScanDynamicQueryResult reads every row from rows into a DynamicQueryResult,
+// using the Trino column types to describe each column.
+func ScanDynamicQueryResult(rows *sql.Rows) (system.DynamicQueryResult, error) {
+	return ScanLimitedDynamicQueryResult(rows, Limit{})
+}
+
+// ScanLimitedDynamicQueryResult is ScanDynamicQueryResult that stops with
+// ErrRowLimit or ErrByteLimit as soon as the rows read pass the caps in limit.
+func ScanLimitedDynamicQueryResult(rows *sql.Rows, limit Limit) (system.DynamicQueryResult, error) {
+	var size int64
+
+	result := system.DynamicQueryResult{
+		Columns: []system.DynamicQueryResultColumn{},
+		Data:    []map[string]any{},
+	}
+
+	columnTypes, err := rows.ColumnTypes()
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, columnType := range columnTypes {
+		result.Columns = append(result.Columns, system.DynamicQueryResultColumn{
+			Name:  columnType.Name(),
+			Type:  columnType.DatabaseTypeName(),
+			Label: ColumnLabel(columnType.Name()),
+		})
+	}
+
+	for rows.Next() {
+		if limit.MaxRows > 0 && len(result.Data) >= limit.MaxRows {
+			return result, fmt.Errorf("%w: the query returned more than %d rows", ErrRowLimit, limit.MaxRows)
+		}
+
+		values := make([]any, len(columnTypes))
+		pointers := make([]any, len(columnTypes))
+
+		for index := range values {
+			pointers[index] = &values[index]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return result, err
+		}
+
+		row := map[string]any{}
+
+		for index, column := range result.Columns {
+			size += valueSize(values[index])
+
+			if value, ok := values[index].([]byte); ok {
+				row[column.Name] = string(value)
+
+				continue
+			}
+
+			row[column.Name] = values[index]
+		}
+
+		if limit.MaxBytes > 0 && size > limit.MaxBytes {
+			return result, fmt.Errorf("%w: the query returned more than %d bytes", ErrByteLimit, limit.MaxBytes)
+		}
+
+		result.Data = append(result.Data, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// ColumnLabel turns a column name such as "full_name" into "Full Name".
+func ColumnLabel(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || unicode.IsSpace(r)
+	})
+
+	for index, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[index] = string(runes)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// FormatValue renders a scanned Trino value as plain text for CSV exports.
+func FormatValue(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case time.Time:
+		return value.Format(time.DateTime)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// WriteCSV writes result to w as CSV, with the column labels as the header.
+func WriteCSV(w io.Writer, result system.DynamicQueryResult) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{}
+
+	for _, column := range result.Columns {
+		header = append(header, column.Label)
+	}
+
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range result.Data {
+		record := []string{}
+
+		for _, column := range result.Columns {
+			record = append(record, FormatValue(row[column.Name]))
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// DecodeDynamicQueryResult reads back a result stored as JSON, restoring the
+// value types ScanDynamicQueryResult gives from the column types so that a
+// stored result exports and masks the same way as a fresh one.
+func DecodeDynamicQueryResult(data []byte) (system.DynamicQueryResult, error) {
+	var result system.DynamicQueryResult
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&result); err != nil {
+		return result, err
+	}
+
+	for _, row := range result.Data {
+		for _, column := range result.Columns {
+			value, ok := row[column.Name]
+
+			if !ok || value == nil {
+				continue
+			}
+
+			row[column.Name] = restoreValue(column.Type, value)
+		}
+	}
+
+	return result, nil
+}
+
+func restoreValue(columnType string, value any) any {
+	switch value := value.(type) {
+	case json.Number:
+		switch strings.ToLower(columnType) {
+		case "tinyint", "smallint", "integer", "bigint":
+			if integer, err := value.Int64(); err == nil {
+				return integer
+			}
+		}
+
+		if float, err := value.Float64(); err == nil {
+			return float
+		}
+
+		return value.String()
+	case string:
+		switch strings.ToLower(columnType) {
+		case "date", "time", "time with time zone", "timestamp", "timestamp with time zone":
+			if date, err := time.Parse(time.RFC3339Nano, value); err == nil {
+				return date
+			}
+		}
+
+		return value
+	default:
+		return value
+	}
+}
@@ -0,0 +1,89 @@
+package trino
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	defaultSampleRows = 10
+	maxSampleRows     = 50
+)
+
+type SampleRowsParams struct {
+	Catalog string `json:"catalog"`
+	Schema  string `json:"schema"`
+	Table   string `json:"table"`
+	Limit   int    `json:"limit,omitempty" jsonschema:"The number of rows to return, 10 by default and at most 50."`
+}
+
+func (t *trino) SampleRows(ctx context.Context, request *mcp.CallToolRequest, params SampleRowsParams) (*mcp.CallToolResult, any, error) {
+	log.Infof("Sampling rows from %s.%s.%s...", params.Catalog, params.Schema, params.Table)
+
+	table := TableParams{Catalog: params.Catalog, Schema: params.Schema, Table: params.Table}
+
+	if result, _, err := popScoped(request, "sample-rows"); result != nil {
+		return result, nil, err
+	}
+
+	if result, _, err := t.allowTable(request, table); result != nil {
+		return result, nil, err
+	}
+
+	limit := params.Limit
+
+	if limit <= 0 {
+		limit = defaultSampleRows
+	}
+
+	limit = min(limit, maxSampleRows)
+
+	rules, err := t.maskingRules(ctx, request)
+
+	if err != nil {
+		return ToolError("Error loading the masking rules", err)
+	}
+
+	ctx, execution := t.startExecution(ctx, request, "MCP sample-rows", table.name())
+
+	result, err := t.sampleRows(ctx, table, t.policyFor(request), limit)
+
+	if err := execution.Finish(err); err != nil {
+		return ToolError("Error sampling the table", err)
+	}
+
+	rules.MaskResult(&result, nil)
+
+	return JSONResult(result)
+}
+
+// sampleRows reads the first limit rows of the table. Hidden columns are left
+// out by naming the columns that may be read instead of selecting *.
+func (t *trino) sampleRows(ctx context.Context, table TableParams, policy Policy, limit int) (system.DynamicQueryResult, error) {
+	columns, err := t.tableColumns(ctx, table, policy)
+
+	if err != nil {
+		return system.DynamicQueryResult{}, err
+	}
+
+	names := []string{}
+
+	for _, column := range columns {
+		names = append(names, QuoteIdentifier(column.Name))
+	}
+
+	rows, err := t.db.QueryContext(ctx, fmt.Sprintf("SELECT %s FROM %s LIMIT %d", strings.Join(names, ", "), table.name(), limit), queryArgs(ctx)...)
+
+	if err != nil {
+		return system.DynamicQueryResult{}, err
+	}
+
+	defer rows.Close()
+
+	return ScanLimitedDynamicQueryResult(rows, limitFor(ctx))
+}
@@ -0,0 +1,215 @@
+package trino
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var ErrOutOfScope = errors.New("out of scope")
+
+// toolNames are the tools registered with AddTool, which MCP tokens can be
+// scoped to.
+var (
+	toolNamesMutex sync.Mutex
+	toolNames      []string
+)
+
+// MCP tokens carry the tools, catalogs and POPs they may use as scopes, such
+// as tool:test-query, catalog:zing and pop:a. A token that does not limit its
+// tools, catalogs or POPs is given tool:*, catalog:* or pop:*. The role a
+// token acts as is carried as role:user.
+const (
+	toolScope    = "tool:"
+	catalogScope = "catalog:"
+	popScope     = "pop:"
+	roleScope    = "role:"
+	anyScope     = "*"
+)
+
+// TokenScopes returns the scopes for an MCP token acting as role, limited to
+// tools, catalogs and POPs. An empty list does not limit the token.
+func TokenScopes(tools []string, catalogs []string, pops []string, role postgres.RoleType) []string {
+	scopes := []string{roleScope + string(role)}
+
+	if len(tools) == 0 {
+		tools = []string{anyScope}
+	}
+
+	if len(catalogs) == 0 {
+		catalogs = []string{anyScope}
+	}
+
+	if len(pops) == 0 {
+		pops = []string{anyScope}
+	}
+
+	for _, pop := range pops {
+		scopes = append(scopes, popScope+strings.ToLower(strings.TrimSpace(pop)))
+	}
+
+	for _, tool := range tools {
+		scopes = append(scopes, toolScope+tool)
+	}
+
+	for _, catalog := range catalogs {
+		scopes = append(scopes, catalogScope+strings.ToLower(catalog))
+	}
+
+	return scopes
+}
+
+// AddTool registers a tool on server that can only be called with an MCP
+// token scoped to it.
+func AddTool[In any](server *mcp.Server, tool *mcp.Tool, handler mcp.ToolHandlerFor[In, any]) {
+	toolNamesMutex.Lock()
+
+	if !slices.Contains(toolNames, tool.Name) {
+		toolNames = append(toolNames, tool.Name)
+	}
+
+	toolNamesMutex.Unlock()
+
+	mcp.AddTool(server, tool, func(ctx context.Context, request *mcp.CallToolRequest, params In) (*mcp.CallToolResult, any, error) {
+		if !hasScope(request, toolScope, tool.Name) {
+			return &mcp.CallToolResult{
+				Content: []mcp.Content{
+					&mcp.TextContent{
+						Text: fmt.Sprintf("This MCP token is not allowed to use the %s tool.", tool.Name),
+					},
+				},
+				IsError: true,
+			}, nil, fmt.Errorf("%w: tool %s", ErrOutOfScope, tool.Name)
+		}
+
+		return handler(ctx, request, params)
+	})
+}
+
+// ToolNames returns the sorted names of the tools registered with AddTool.
+func ToolNames() []string {
+	toolNamesMutex.Lock()
+	defer toolNamesMutex.Unlock()
+
+	names := slices.Clone(toolNames)
+
+	slices.Sort(names)
+
+	return names
+}
+
+// TokenRole returns the role the MCP token behind request acts as, or
+// McpMaskingRole for a token without one.
+func TokenRole(request *mcp.CallToolRequest) postgres.RoleType {
+	for _, scope := range tokenScopes(request) {
+		if role, ok := strings.CutPrefix(scope, roleScope); ok && role != "" {
+			return postgres.RoleType(role)
+		}
+	}
+
+	return McpMaskingRole
+}
+
+// TokenPops returns the POPs the MCP token behind request is limited to, or
+// nil if it is not limited.
+func TokenPops(request *mcp.CallToolRequest) []string {
+	if hasScope(request, popScope, anyScope) {
+		return nil
+	}
+
+	pops := []string{}
+
+	for _, scope := range tokenScopes(request) {
+		if pop, ok := strings.CutPrefix(scope, popScope); ok && pop != "" {
+			pops = append(pops, pop)
+		}
+	}
+
+	return pops
+}
+
+// popScoped returns a tool error for the tools that read rows directly when
+// the MCP token behind request is limited to POPs, because SQL cannot be
+// limited to them. Such tokens can only read rows through the report tools.
+func popScoped(request *mcp.CallToolRequest, tool string) (*mcp.CallToolResult, any, error) {
+	if TokenPops(request) == nil {
+		return nil, nil, nil
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: fmt.Sprintf("This MCP token is limited to POPs and cannot use the %s tool, use the report tools instead.", tool),
+			},
+		},
+		IsError: true,
+	}, nil, fmt.Errorf("%w: tool %s with a POP scoped token", ErrOutOfScope, tool)
+}
+
+func tokenScopes(request *mcp.CallToolRequest) []string {
+	if request == nil {
+		return nil
+	}
+
+	return extraScopes(request.Extra)
+}
+
+// extraScopes returns the scopes of the MCP token behind any request, such
+// as a resource read, by its extra.
+func extraScopes(extra *mcp.RequestExtra) []string {
+	if extra == nil || extra.TokenInfo == nil {
+		return nil
+	}
+
+	return extra.TokenInfo.Scopes
+}
+
+func hasScope(request *mcp.CallToolRequest, kind string, name string) bool {
+	return containsScope(tokenScopes(request), kind, name)
+}
+
+func containsScope(scopes []string, kind string, name string) bool {
+	return slices.Contains(scopes, kind+anyScope) || slices.Contains(scopes, kind+strings.ToLower(name))
+}
+
+// policyFor narrows the policy to the catalogs the MCP token behind request
+// is scoped to.
+func (t *trino) policyFor(request *mcp.CallToolRequest) Policy {
+	return t.policyForScopes(tokenScopes(request))
+}
+
+func (t *trino) policyForScopes(scopes []string) Policy {
+	policy := t.policy
+
+	if containsScope(scopes, catalogScope, anyScope) {
+		return policy
+	}
+
+	policy.tokenScoped = true
+	policy.tokenCatalogs = []string{}
+
+	for _, scope := range scopes {
+		if catalog, ok := strings.CutPrefix(scope, catalogScope); ok {
+			policy.tokenCatalogs = append(policy.tokenCatalogs, catalog)
+		}
+	}
+
+	return policy
+}
+
+func catalogNotAllowed(catalog string) (*mcp.CallToolResult, any, error) {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: fmt.Sprintf("The catalog %s is not allowed.", catalog),
+			},
+		},
+		IsError: true,
+	}, nil, fmt.Errorf("%w: catalog %s", ErrOutOfScope, catalog)
+}
@@ -0,0 +1,113 @@
+package trino
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// JoinSuggestion is a table that can be joined to the one asked about, with
+// the condition to join it on.
+type JoinSuggestion struct {
+	Table        string       `json:"table"`
+	Condition    string       `json:"condition"`
+	Relationship Relationship `json:"relationship"`
+}
+
+func (t *trino) SuggestJoins(ctx context.Context, request *mcp.CallToolRequest, params TableParams) (*mcp.CallToolResult, any, error) {
+	log.Infof("Suggesting joins for %s.%s.%s...", params.Catalog, params.Schema, params.Table)
+
+	if result, _, err := t.allowTable(request, params); result != nil {
+		return result, nil, err
+	}
+
+	ctx, execution := t.startExecution(ctx, request, "MCP suggest-joins", params.name())
+
+	suggestions, err := t.suggestJoins(ctx, params, t.policyFor(request))
+
+	if err := execution.Finish(err); err != nil {
+		return ToolError("Error suggesting joins", err)
+	}
+
+	return JSONResult(suggestions)
+}
+
+// suggestJoins returns the curated relationships of the table that policy
+// lets both sides of be read, naming the other table by the schema it is
+// found in.
+func (t *trino) suggestJoins(ctx context.Context, params TableParams, policy Policy) ([]JoinSuggestion, error) {
+	if err := t.checkTableExists(ctx, params.Catalog, params.Schema, params.Table); err != nil {
+		return nil, err
+	}
+
+	suggestions := []JoinSuggestion{}
+	schemas := map[string]string{}
+
+	for _, relationship := range RelationshipsFor(params.Catalog, params.Table) {
+		this, other := relationship.From, relationship.To
+
+		if !this.isTable(params.Catalog, params.Table) {
+			this, other = other, this
+		}
+
+		if policy.HidesColumn(this.Table, this.Column) || policy.HidesColumn(other.Table, other.Column) {
+			continue
+		}
+
+		if !policy.AllowsCatalog(other.Catalog) {
+			continue
+		}
+
+		key := strings.ToLower(other.Catalog + "." + other.Table)
+		schema, ok := schemas[key]
+
+		if !ok {
+			var err error
+
+			if schema, err = t.tableSchema(ctx, other, params); err != nil {
+				return nil, err
+			}
+
+			schemas[key] = schema
+		}
+
+		if schema == "" || policy.CheckTable(other.Catalog, schema, other.Table) != nil {
+			continue
+		}
+
+		thisTable := strings.ToLower(fmt.Sprintf("%s.%s.%s", params.Catalog, params.Schema, params.Table))
+		otherTable := strings.ToLower(fmt.Sprintf("%s.%s.%s", other.Catalog, schema, other.Table))
+
+		suggestions = append(suggestions, JoinSuggestion{
+			Table:        otherTable,
+			Condition:    fmt.Sprintf("%s.%s = %s.%s", thisTable, strings.ToLower(this.Column), otherTable, strings.ToLower(other.Column)),
+			Relationship: relationship,
+		})
+	}
+
+	return suggestions, nil
+}
+
+// tableSchema returns the schema that holds the table of column, or an empty
+// string if there is none. A table in the same catalog as params is looked
+// for in its schema first.
+func (t *trino) tableSchema(ctx context.Context, column ColumnReference, params TableParams) (string, error) {
+	var schema string
+
+	err := t.db.QueryRowContext(ctx, fmt.Sprintf(`SELECT table_schema
+FROM %s.information_schema.tables
+WHERE table_name = ? AND table_schema <> 'information_schema'
+ORDER BY table_schema = ? DESC, table_schema
+LIMIT 1`, QuoteIdentifier(column.Catalog)), queryArgs(ctx, strings.ToLower(column.Table), strings.ToLower(params.Schema))...).Scan(&schema)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+
+	return schema, err
+}
@@ -0,0 +1,147 @@
+package trino
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/internal/masking"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/goccy/go-json"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// McpMaskingRole is the role whose masking rules the results of an MCP token
+// without a role are masked with, the least privileged role.
+const McpMaskingRole = postgres.RoleTypeUser
+
+// TableParams names the table an MCP tool inspects.
+type TableParams struct {
+	Catalog string `json:"catalog"`
+	Schema  string `json:"schema"`
+	Table   string `json:"table"`
+}
+
+// name returns the quoted catalog.schema.table name of the table.
+func (p TableParams) name() string {
+	return fmt.Sprintf("%s.%s.%s", QuoteIdentifier(strings.ToLower(p.Catalog)), QuoteIdentifier(strings.ToLower(p.Schema)), QuoteIdentifier(strings.ToLower(p.Table)))
+}
+
+// TableColumn is a column of a table as Trino describes it.
+type TableColumn struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Nullable bool   `json:"nullable"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// allowTable returns a tool error if the table is not validly named or may
+// not be read by the MCP token behind request. Whether it exists is checked
+// once the tool's execution has started.
+func (t *trino) allowTable(request *mcp.CallToolRequest, params TableParams) (*mcp.CallToolResult, any, error) {
+	policy := t.policyFor(request)
+
+	if err := errors.Join(ValidateIdentifier("catalog", params.Catalog), ValidateIdentifier("schema", params.Schema), ValidateIdentifier("table", params.Table)); err != nil {
+		return ToolError("The table is invalid", err)
+	}
+
+	if !policy.AllowsCatalog(params.Catalog) {
+		return catalogNotAllowed(params.Catalog)
+	}
+
+	if err := policy.CheckTable(params.Catalog, params.Schema, params.Table); err != nil {
+		return ToolError("The table is not allowed", err)
+	}
+
+	return nil, nil, nil
+}
+
+// tableColumns checks that the table exists and returns its columns in
+// order, leaving out those that policy hides.
+func (t *trino) tableColumns(ctx context.Context, params TableParams, policy Policy) ([]TableColumn, error) {
+	if err := t.checkTableExists(ctx, params.Catalog, params.Schema, params.Table); err != nil {
+		return nil, err
+	}
+
+	rows, err := t.db.QueryContext(ctx, fmt.Sprintf(`SELECT column_name, data_type, is_nullable
+FROM %s.information_schema.columns
+WHERE table_schema = ? AND table_name = ?
+ORDER BY ordinal_position`, QuoteIdentifier(strings.ToLower(params.Catalog))), queryArgs(ctx, strings.ToLower(params.Schema), strings.ToLower(params.Table))...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	columns := []TableColumn{}
+
+	for rows.Next() {
+		var column TableColumn
+		var nullable string
+
+		if err := rows.Scan(&column.Name, &column.Type, &nullable); err != nil {
+			return nil, err
+		}
+
+		if policy.HidesColumn(params.Table, column.Name) {
+			continue
+		}
+
+		column.Nullable = strings.EqualFold(nullable, "YES")
+
+		columns = append(columns, column)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("%w: table %s.%s.%s has no columns that can be read", ErrOutOfScope, params.Catalog, params.Schema, params.Table)
+	}
+
+	return columns, nil
+}
+
+// maskingRules returns the masking rules for the role of the MCP token behind
+// request.
+func (t *trino) maskingRules(ctx context.Context, request *mcp.CallToolRequest) (masking.Rules, error) {
+	rules, err := t.postgres.GetMaskingRulesByRole(ctx, TokenRole(request))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return masking.New(rules), nil
+}
+
+// JSONResult returns value as the JSON text of a tool result.
+func JSONResult(value any) (*mcp.CallToolResult, any, error) {
+	data, err := json.Marshal(value)
+
+	if err != nil {
+		return ToolError("Error encoding the result", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: string(data),
+			},
+		},
+	}, nil, nil
+}
+
+// ToolError returns an error tool result that starts with message.
+func ToolError(message string, err error) (*mcp.CallToolResult, any, error) {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{
+				Text: fmt.Sprintf("%s: %s", message, err.Error()),
+			},
+		},
+		IsError: true,
+	}, nil, err
+}
@@ -2,40 +2,165 @@ package trino
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
+const (
+	defaultPreviewRows = 10
+	maxPreviewRows     = 50
+)
+
 type TestQueryParams struct {
-	Query string `json:"query"`
+	Query       string                        `json:"query"`
+	Parameters  system.DynamicQueryParameters `json:"parameters,omitempty" jsonschema:"The parameters declared for the placeholders in the query, bound using their default values, or a value of their type when they have none."`
+	PreviewRows int                           `json:"preview_rows,omitempty" jsonschema:"The number of rows to return, 10 by default and at most 50."`
+}
+
+// TestQueryResult is the result of the test-query tool. Rows holds the first
+// rows of the result with personal information masked, while TotalRows counts
+// every row the query returned up to the caps in Limits.
+type TestQueryResult struct {
+	Columns         []system.DynamicQueryResultColumn `json:"columns"`
+	TotalRows       int                               `json:"total_rows"`
+	Truncated       bool                              `json:"truncated"`
+	TruncatedReason string                            `json:"truncated_reason,omitempty"`
+	Rows            []map[string]any                  `json:"rows"`
+	ExecutionMillis int64                             `json:"execution_time_ms"`
+	Limits          TestQueryLimits                   `json:"limits"`
+	TrinoQueries    []QueryStats                      `json:"trino_queries"`
+}
+
+// TestQueryLimits are the caps a test-query execution ran under. Zero leaves
+// a cap off.
+type TestQueryLimits struct {
+	MaxRows        int   `json:"max_rows"`
+	MaxBytes       int64 `json:"max_bytes"`
+	TimeoutSeconds int64 `json:"timeout_seconds"`
 }
 
 func (t *trino) TestQuery(context context.Context, request *mcp.CallToolRequest, params TestQueryParams) (*mcp.CallToolResult, any, error) {
 	log.Info("Testing query...")
 
-	row := t.db.QueryRow(params.Query)
-
 	log.Infof("Query being tested:\n%s", params.Query)
 
-	var result string
+	if result, _, err := popScoped(request, "test-query"); result != nil {
+		return result, nil, err
+	}
 
-	if err := row.Scan(&result); err != nil {
+	if err := ValidateQuery(params.Query, t.policyFor(request)); err != nil {
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{
-					Text: fmt.Sprintf("The query failed to execute: %s", err.Error()),
+					Text: fmt.Sprintf("The query is not allowed: %s", err.Error()),
 				},
 			},
 		}, nil, err
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			&mcp.TextContent{
-				Text: "The query executed successfully.",
+	query, args, err := BindParametersForPlanning(CleanQuery(params.Query), params.Parameters)
+
+	if err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("The query parameters are invalid: %s", err.Error()),
+				},
 			},
-		},
-	}, nil, nil
+		}, nil, err
+	}
+
+	rules, err := t.maskingRules(context, request)
+
+	if err != nil {
+		return ToolError("Error loading the masking rules", err)
+	}
+
+	previewRows := params.PreviewRows
+
+	if previewRows <= 0 {
+		previewRows = defaultPreviewRows
+	}
+
+	previewRows = min(previewRows, maxPreviewRows)
+
+	ctx, execution := t.startExecution(context, request, "MCP test-query", params.Query)
+
+	result, err := t.testQuery(ctx, query, args)
+
+	if err == nil {
+		stats, statsErr := execution.QueryStats(ctx)
+
+		if statsErr != nil {
+			log.Warnf("⚠️ Failed to retrieve the Trino query stats for execution %s: %s", execution.ID, statsErr.Error())
+		}
+
+		result.TrinoQueries = stats
+	}
+
+	if err := execution.Finish(err); err != nil {
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: fmt.Sprintf("The query failed to execute: %s", err.Error()),
+				},
+			},
+			IsError: true,
+		}, nil, err
+	}
+
+	result.ExecutionMillis = time.Since(execution.StartedAt).Milliseconds()
+	result.Limits = TestQueryLimits{
+		MaxRows:        execution.Limit.MaxRows,
+		MaxBytes:       execution.Limit.MaxBytes,
+		TimeoutSeconds: int64(execution.Limit.Timeout.Seconds()),
+	}
+
+	preview := system.DynamicQueryResult{
+		Columns: slices.Clone(result.Columns),
+		Data:    result.Rows[:min(previewRows, len(result.Rows))],
+	}
+
+	rules.MaskResult(&preview, ColumnLineage(params.Query))
+
+	result.Columns = preview.Columns
+	result.Rows = preview.Data
+
+	return JSONResult(result)
+}
+
+// testQuery runs query and counts its rows. When the rows pass the row or
+// byte cap of the execution the count stops there and the result is marked
+// as truncated instead of failing, so that the query can still be checked.
+func (t *trino) testQuery(ctx context.Context, query string, args []any) (TestQueryResult, error) {
+	rows, err := t.db.QueryContext(ctx, query, queryArgs(ctx, args...)...)
+
+	if err != nil {
+		return TestQueryResult{}, err
+	}
+
+	defer rows.Close()
+
+	scanned, err := ScanLimitedDynamicQueryResult(rows, limitFor(ctx))
+
+	result := TestQueryResult{
+		Columns: scanned.Columns,
+		Rows:    scanned.Data,
+	}
+
+	if errors.Is(err, ErrRowLimit) || errors.Is(err, ErrByteLimit) {
+		result.Truncated = true
+		result.TruncatedReason = err.Error()
+		err = nil
+	}
+
+	result.TotalRows = len(result.Rows)
+
+	return result, err
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/connor-davis/zingfibre-core/internal/ai"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -12,14 +14,51 @@ type Trino interface {
 	ListSchemas(context context.Context, request *mcp.CallToolRequest, params ListSchemasParams) (*mcp.CallToolResult, any, error)
 	ListTables(context context.Context, request *mcp.CallToolRequest, params ListTablesParams) (*mcp.CallToolResult, any, error)
 	TestQuery(context context.Context, request *mcp.CallToolRequest, params TestQueryParams) (*mcp.CallToolResult, any, error)
+	DescribeTable(context context.Context, request *mcp.CallToolRequest, params TableParams) (*mcp.CallToolResult, any, error)
+	SampleRows(context context.Context, request *mcp.CallToolRequest, params SampleRowsParams) (*mcp.CallToolResult, any, error)
+	ProfileColumn(context context.Context, request *mcp.CallToolRequest, params ProfileColumnParams) (*mcp.CallToolResult, any, error)
+	SuggestJoins(context context.Context, request *mcp.CallToolRequest, params TableParams) (*mcp.CallToolResult, any, error)
+	ReadTableSchema(context context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error)
+	ReadRelationships(context context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error)
+	ReadGlossary(context context.Context, request *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error)
+	GenerateDynamicQueryPrompt(context context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error)
+	ExploreTablePrompt(context context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error)
+	PopSummaryPrompt(context context.Context, request *mcp.GetPromptRequest) (*mcp.GetPromptResult, error)
 }
 
 type trino struct {
-	db *sql.DB
+	db         *sql.DB
+	postgres   *postgres.Queries
+	policy     Policy
+	executions *Executions
+	promptData ai.PromptData
 }
 
-func New(db *sql.DB) Trino {
+func New(db *sql.DB, postgres *postgres.Queries, policy Policy, executions *Executions, promptData ai.PromptData) Trino {
 	return &trino{
-		db: db,
+		db:         db,
+		postgres:   postgres,
+		policy:     policy,
+		executions: executions,
+		promptData: promptData,
 	}
 }
+
+// startExecution starts an execution for an MCP tool call, limited as the
+// mcp role and named after the token that made it.
+func (t *trino) startExecution(ctx context.Context, request *mcp.CallToolRequest, description string, query string) (context.Context, *Execution) {
+	user := "MCP"
+
+	if request != nil && request.Extra != nil && request.Extra.TokenInfo != nil {
+		if name, ok := request.Extra.TokenInfo.Extra["name"].(string); ok {
+			user = name
+		}
+	}
+
+	return t.executions.Start(ctx, ExecutionInfo{
+		Description: description,
+		User:        user,
+		Role:        McpRole,
+		Query:       query,
+	})
+}
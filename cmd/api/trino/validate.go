@@ -0,0 +1,672 @@
+package trino
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/common"
+)
+
+var ErrUnsafeQuery = errors.New("unsafe query")
+
+// Policy limits what a dynamic query may read. Names are compared without
+// regard to case, the same way the Trino MySQL connectors match them.
+type Policy struct {
+	// Catalogs that may be read. Empty allows every catalog.
+	Catalogs []string
+	// Schemas that may be read, as catalog.schema. Empty allows every schema
+	// in an allowed catalog.
+	Schemas []string
+	// AllowedTables that may be read. Each entry is a table, schema.table or
+	// catalog.schema.table name. Empty allows every table that is not denied.
+	AllowedTables []string
+	// DeniedTables may never be read. Each entry is a table, schema.table or
+	// catalog.schema.table name.
+	DeniedTables []string
+	// HiddenColumns maps a table name to the columns that may never be read
+	// from it, in any catalog or schema.
+	HiddenColumns map[string][]string
+
+	// tokenCatalogs further limits Catalogs to those an MCP token is scoped
+	// to when tokenScoped is set.
+	tokenCatalogs []string
+	tokenScoped   bool
+}
+
+// AllowsCatalog reports whether catalog may be read.
+func (p Policy) AllowsCatalog(catalog string) bool {
+	catalog = strings.ToLower(catalog)
+
+	if len(p.Catalogs) > 0 && !slices.Contains(p.Catalogs, catalog) {
+		return false
+	}
+
+	return !p.tokenScoped || slices.Contains(p.tokenCatalogs, catalog)
+}
+
+// AllowsSchema reports whether catalog.schema may be read.
+func (p Policy) AllowsSchema(catalog string, schema string) bool {
+	catalog, schema = strings.ToLower(catalog), strings.ToLower(schema)
+
+	return p.AllowsCatalog(catalog) && (len(p.Schemas) == 0 || slices.Contains(p.Schemas, catalog+"."+schema))
+}
+
+// PolicyFromEnv reads the SQL_* environment variables. SQL_HIDDEN_COLUMNS is
+// a comma separated list of table.column names. The Radius managers and the
+// Zing documents are denied unless SQL_DENIED_TABLES says otherwise.
+func PolicyFromEnv() (Policy, error) {
+	policy := Policy{
+		Catalogs:      splitList(common.EnvString("SQL_ALLOWED_CATALOGS", "zing,radius")),
+		Schemas:       splitList(common.EnvString("SQL_ALLOWED_SCHEMAS", "")),
+		AllowedTables: splitList(common.EnvString("SQL_ALLOWED_TABLES", "")),
+		DeniedTables:  splitList(common.EnvString("SQL_DENIED_TABLES", "rm_managers,documents")),
+		HiddenColumns: map[string][]string{},
+	}
+
+	for _, hiddenColumn := range splitList(common.EnvString("SQL_HIDDEN_COLUMNS", "rm_managers.password,customers.password,addresses.radiuspassword")) {
+		table, column, ok := strings.Cut(hiddenColumn, ".")
+
+		if !ok || table == "" || column == "" {
+			return policy, fmt.Errorf("SQL_HIDDEN_COLUMNS: %q is not a table.column name", hiddenColumn)
+		}
+
+		policy.HiddenColumns[table] = append(policy.HiddenColumns[table], column)
+	}
+
+	return policy, nil
+}
+
+func splitList(value string) []string {
+	list := []string{}
+
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
+
+// forbiddenKeywords can only appear in statements that change data or run
+// something other than a query. The query must already start with SELECT or
+// WITH, so this is a second line of defence.
+var forbiddenKeywords = []string{
+	"alter", "call", "create", "deallocate", "delete", "describe", "drop",
+	"execute", "grant", "insert", "into", "merge", "prepare", "revoke",
+	"truncate", "update",
+}
+
+// fromFunctions take FROM as an argument separator rather than to introduce a
+// table, as in EXTRACT(YEAR FROM x).
+var fromFunctions = []string{"extract", "trim", "substring", "overlay"}
+
+// ValidateQuery checks that query is a single read-only SELECT or WITH query
+// that only reads the catalogs, schemas, tables and columns allowed by
+// policy. The error names the line and column of the first problem found.
+func ValidateQuery(query string, policy Policy) error {
+	tokens, err := tokenize(CleanQuery(query))
+
+	if err != nil {
+		return err
+	}
+
+	if len(tokens) == 0 {
+		return fmt.Errorf("%w: the query is empty", ErrUnsafeQuery)
+	}
+
+	for index, token := range tokens {
+		if token.is(";") {
+			return unsafeAt(tokens[index], "only a single statement can be run, remove the ;")
+		}
+	}
+
+	first := 0
+
+	for first < len(tokens)-1 && tokens[first].is("(") {
+		first++
+	}
+
+	if !tokens[first].isKeyword("select", "with") {
+		return unsafeAt(tokens[first], fmt.Sprintf("%s is not allowed, the query must start with SELECT or WITH", strings.ToUpper(tokens[first].text)))
+	}
+
+	for index, token := range tokens {
+		if token.kind == wordToken && slices.Contains(forbiddenKeywords, token.value) {
+			return unsafeAt(token, fmt.Sprintf("%s is not allowed in a read-only query, double-quote it if it is a column name", strings.ToUpper(token.text)))
+		}
+
+		// TABLE(...) calls a table function, such as a connector's
+		// system.query, which reads tables the policy cannot see.
+		if token.isKeyword("table") && index+1 < len(tokens) && tokens[index+1].is("(") {
+			return unsafeAt(token, "table functions are not allowed")
+		}
+	}
+
+	cteNames := commonTableExpressionNames(tokens)
+	hiddenColumns := map[string]string{}
+
+	for _, reference := range tableReferences(tokens) {
+		if len(reference.parts) == 1 && slices.Contains(cteNames, reference.parts[0]) {
+			continue
+		}
+
+		if err := policy.checkTable(reference); err != nil {
+			return err
+		}
+
+		table := reference.parts[2]
+
+		for _, column := range policy.HiddenColumns[table] {
+			hiddenColumns[strings.ToLower(column)] = table
+		}
+	}
+
+	if len(hiddenColumns) == 0 {
+		return nil
+	}
+
+	for index, token := range tokens {
+		if (token.kind == wordToken || token.kind == quotedIdentifierToken) && hiddenColumns[token.value] != "" {
+			return unsafeAt(token, fmt.Sprintf("column %s.%s is hidden and cannot be read", hiddenColumns[token.value], token.value))
+		}
+
+		if token.is("*") && index > 0 && (tokens[index-1].isKeyword("select", "distinct", "all") || tokens[index-1].is(",") || tokens[index-1].is(".")) {
+			tables := []string{}
+
+			for _, table := range hiddenColumns {
+				if !slices.Contains(tables, table) {
+					tables = append(tables, table)
+				}
+			}
+
+			slices.Sort(tables)
+
+			return unsafeAt(token, fmt.Sprintf("* cannot be selected from a query that reads %s because it has hidden columns, list the columns explicitly", strings.Join(tables, ", ")))
+		}
+	}
+
+	return nil
+}
+
+func (p Policy) checkTable(reference tableReference) error {
+	if len(reference.parts) != 3 {
+		return unsafeAt(reference.token, fmt.Sprintf("table %s must be fully qualified as catalog.schema.table", strings.Join(reference.parts, ".")))
+	}
+
+	if message := p.tableMessage(reference.parts[0], reference.parts[1], reference.parts[2]); message != "" {
+		return unsafeAt(reference.token, message)
+	}
+
+	return nil
+}
+
+// CheckTable returns an error wrapping ErrOutOfScope if catalog.schema.table
+// may not be read.
+func (p Policy) CheckTable(catalog string, schema string, table string) error {
+	if message := p.tableMessage(strings.ToLower(catalog), strings.ToLower(schema), strings.ToLower(table)); message != "" {
+		return fmt.Errorf("%w: %s", ErrOutOfScope, message)
+	}
+
+	return nil
+}
+
+// HidesColumn reports whether column may never be read from table.
+func (p Policy) HidesColumn(table string, column string) bool {
+	return slices.ContainsFunc(p.HiddenColumns[strings.ToLower(table)], func(hidden string) bool {
+		return strings.EqualFold(hidden, column)
+	})
+}
+
+// AllowsCatalogTable reports whether table may be read from some schema of
+// catalog, for when the schema is not known. An allowed or denied table named
+// with a schema is taken to name the table in every schema of its catalog.
+func (p Policy) AllowsCatalogTable(catalog string, table string) bool {
+	catalog, table = strings.ToLower(catalog), strings.ToLower(table)
+
+	if !p.AllowsCatalog(catalog) {
+		return false
+	}
+
+	if len(p.Schemas) > 0 && !slices.ContainsFunc(p.Schemas, func(schema string) bool { return strings.HasPrefix(schema, catalog+".") }) {
+		return false
+	}
+
+	names := func(name string) bool {
+		parts := strings.Split(name, ".")
+
+		return parts[len(parts)-1] == table && (len(parts) < 3 || parts[0] == catalog)
+	}
+
+	if len(p.AllowedTables) > 0 && !slices.ContainsFunc(p.AllowedTables, names) {
+		return false
+	}
+
+	return !slices.ContainsFunc(p.DeniedTables, names)
+}
+
+// tableMessage describes why the lower cased catalog.schema.table may not be
+// read, or is empty if it may.
+func (p Policy) tableMessage(catalog string, schema string, table string) string {
+	if !p.AllowsCatalog(catalog) {
+		return fmt.Sprintf("catalog %s is not allowed", catalog)
+	}
+
+	if schema == "system" || !p.AllowsSchema(catalog, schema) {
+		return fmt.Sprintf("schema %s.%s is not allowed", catalog, schema)
+	}
+
+	names := []string{table, schema + "." + table, catalog + "." + schema + "." + table}
+
+	if len(p.AllowedTables) > 0 && !slices.ContainsFunc(names, func(name string) bool { return slices.Contains(p.AllowedTables, name) }) {
+		return fmt.Sprintf("table %s.%s.%s is not allowed", catalog, schema, table)
+	}
+
+	for _, denied := range names {
+		if slices.Contains(p.DeniedTables, denied) {
+			return fmt.Sprintf("table %s.%s.%s is not allowed", catalog, schema, table)
+		}
+	}
+
+	return ""
+}
+
+func unsafeAt(token sqlToken, message string) error {
+	return fmt.Errorf("%w: line %d, column %d: %s", ErrUnsafeQuery, token.line, token.column, message)
+}
+
+type tableReference struct {
+	parts []string
+	token sqlToken
+}
+
+// tableReferences returns every table named after FROM or JOIN, including
+// those in comma separated FROM lists, parenthesised joins and subqueries,
+// and every table read with TABLE name.
+func tableReferences(tokens []sqlToken) []tableReference {
+	references := []tableReference{}
+	openers := []string{}
+
+	for index, token := range tokens {
+		if token.is("(") {
+			opener := ""
+
+			if index > 0 && tokens[index-1].kind == wordToken {
+				opener = tokens[index-1].value
+			}
+
+			openers = append(openers, opener)
+
+			continue
+		}
+
+		if token.is(")") && len(openers) > 0 {
+			openers = openers[:len(openers)-1]
+
+			continue
+		}
+
+		if token.isKeyword("table") {
+			if reference, _ := qualifiedName(tokens, index+1); len(reference.parts) > 0 {
+				references = append(references, reference)
+			}
+
+			continue
+		}
+
+		if !token.isKeyword("from", "join") {
+			continue
+		}
+
+		if token.isKeyword("from") && len(openers) > 0 && slices.Contains(fromFunctions, openers[len(openers)-1]) {
+			continue
+		}
+
+		// a IS [NOT] DISTINCT FROM b
+		if token.isKeyword("from") && index > 1 && tokens[index-1].isKeyword("distinct") && tokens[index-2].isKeyword("is", "not") {
+			continue
+		}
+
+		next := index + 1
+
+		for next < len(tokens) {
+			if tokens[next].is("(") || tokens[next].isKeyword("lateral", "unnest", "table") {
+				if reference, ok := parenthesisedTable(tokens, next); ok {
+					references = append(references, reference)
+				}
+
+				next = skipRelation(tokens, next)
+			} else {
+				reference, end := qualifiedName(tokens, next)
+
+				if len(reference.parts) == 0 {
+					break
+				}
+
+				references = append(references, reference)
+				next = skipRelation(tokens, end)
+			}
+
+			if next >= len(tokens) || !tokens[next].is(",") || token.isKeyword("join") {
+				break
+			}
+
+			next++
+		}
+	}
+
+	return references
+}
+
+// parenthesisedTable returns the first table of a parenthesised relation such
+// as (a) or (a JOIN b ON ...). The tables it joins are named after JOIN, and
+// those of a subquery after FROM, so they are found on their own.
+func parenthesisedTable(tokens []sqlToken, index int) (tableReference, bool) {
+	if !tokens[index].is("(") {
+		return tableReference{}, false
+	}
+
+	for index < len(tokens) && tokens[index].is("(") {
+		index++
+	}
+
+	if index >= len(tokens) || tokens[index].isKeyword("select", "with", "values", "lateral", "unnest", "table") {
+		return tableReference{}, false
+	}
+
+	reference, _ := qualifiedName(tokens, index)
+
+	return reference, len(reference.parts) > 0
+}
+
+// skipRelation moves past the rest of a relation in a FROM list, including
+// any parenthesised groups and its alias, and stops at the next comma at the
+// same depth or at the end of the list.
+func skipRelation(tokens []sqlToken, index int) int {
+	depth := 0
+
+	for ; index < len(tokens); index++ {
+		token := tokens[index]
+
+		switch {
+		case token.is("("):
+			depth++
+		case token.is(")"):
+			if depth == 0 {
+				return index
+			}
+
+			depth--
+		case depth == 0 && token.is(","):
+			return index
+		case depth == 0 && token.isKeyword("where", "group", "having", "order", "limit", "offset", "fetch", "union", "intersect", "except", "window", "join", "on", "using", "cross", "inner", "left", "right", "full", "natural"):
+			return index
+		}
+	}
+
+	return index
+}
+
+func qualifiedName(tokens []sqlToken, index int) (tableReference, int) {
+	reference := tableReference{parts: []string{}}
+
+	for index < len(tokens) && (tokens[index].kind == wordToken || tokens[index].kind == quotedIdentifierToken) {
+		if len(reference.parts) == 0 {
+			reference.token = tokens[index]
+		}
+
+		reference.parts = append(reference.parts, tokens[index].value)
+		index++
+
+		if index >= len(tokens) || !tokens[index].is(".") {
+			break
+		}
+
+		index++
+	}
+
+	return reference, index
+}
+
+// commonTableExpressionNames returns the names defined by every WITH clause
+// in the query.
+func commonTableExpressionNames(tokens []sqlToken) []string {
+	names := []string{}
+
+	for index, token := range tokens {
+		if !token.isKeyword("with") {
+			continue
+		}
+
+		next := index + 1
+
+		if next < len(tokens) && tokens[next].isKeyword("recursive") {
+			next++
+		}
+
+		for next < len(tokens) && (tokens[next].kind == wordToken || tokens[next].kind == quotedIdentifierToken) {
+			names = append(names, tokens[next].value)
+			next++
+
+			if next < len(tokens) && tokens[next].is("(") {
+				next = skipGroup(tokens, next)
+			}
+
+			if next >= len(tokens) || !tokens[next].isKeyword("as") {
+				break
+			}
+
+			next++
+
+			if next >= len(tokens) || !tokens[next].is("(") {
+				break
+			}
+
+			next = skipGroup(tokens, next)
+
+			if next >= len(tokens) || !tokens[next].is(",") {
+				break
+			}
+
+			next++
+		}
+	}
+
+	return names
+}
+
+// skipGroup moves past the parenthesised group that opens at index.
+func skipGroup(tokens []sqlToken, index int) int {
+	depth := 0
+
+	for ; index < len(tokens); index++ {
+		if tokens[index].is("(") {
+			depth++
+		}
+
+		if tokens[index].is(")") {
+			depth--
+
+			if depth == 0 {
+				return index + 1
+			}
+		}
+	}
+
+	return index
+}
+
+type sqlTokenKind int
+
+const (
+	wordToken sqlTokenKind = iota
+	quotedIdentifierToken
+	stringToken
+	numberToken
+	placeholderToken
+	symbolToken
+)
+
+type sqlToken struct {
+	kind   sqlTokenKind
+	text   string
+	value  string
+	line   int
+	column int
+	// offset is the index of the first rune of the token in the query.
+	offset int
+}
+
+func (t sqlToken) is(symbol string) bool {
+	return t.kind == symbolToken && t.text == symbol
+}
+
+func (t sqlToken) isKeyword(keywords ...string) bool {
+	return t.kind == wordToken && slices.Contains(keywords, t.value)
+}
+
+// tokenize splits query into words, quoted identifiers, literals, placeholders
+// and symbols, dropping whitespace and comments. Words and quoted identifiers
+// are lower cased in value.
+func tokenize(query string) ([]sqlToken, error) {
+	tokens := []sqlToken{}
+	runes := []rune(query)
+	line, column, offset := 1, 1, 0
+
+	advance := func(count int) {
+		for range count {
+			offset++
+
+			if runes[0] == '\n' {
+				line++
+				column = 1
+			} else {
+				column++
+			}
+
+			runes = runes[1:]
+		}
+	}
+
+	for len(runes) > 0 {
+		start := sqlToken{line: line, column: column, offset: offset}
+		character := runes[0]
+
+		switch {
+		case character == ' ' || character == '\t' || character == '\n' || character == '\r':
+			advance(1)
+		case character == '-' && len(runes) > 1 && runes[1] == '-':
+			for len(runes) > 0 && runes[0] != '\n' {
+				advance(1)
+			}
+		case character == '/' && len(runes) > 1 && runes[1] == '*':
+			end := strings.Index(string(runes[2:]), "*/")
+
+			if end < 0 {
+				return nil, unsafeAt(start, "the comment is never closed")
+			}
+
+			advance(2 + len([]rune(string(runes[2:])[:end])) + 2)
+		case character == '\'' || character == '"':
+			text, ok := quoted(runes, character)
+
+			if !ok && character == '\'' {
+				return nil, unsafeAt(start, "the string is never closed")
+			}
+
+			if !ok {
+				return nil, unsafeAt(start, "the quoted identifier is never closed")
+			}
+
+			start.kind = stringToken
+			start.text = text
+			start.value = strings.ReplaceAll(text[1:len(text)-1], string(character)+string(character), string(character))
+
+			if character == '"' {
+				start.kind = quotedIdentifierToken
+				start.value = strings.ToLower(start.value)
+			}
+
+			tokens = append(tokens, start)
+			advance(len([]rune(text)))
+		case character == '{' && len(runes) > 1 && runes[1] == '{':
+			end := strings.Index(string(runes), "}}")
+
+			if end < 0 {
+				return nil, unsafeAt(start, "the placeholder is never closed")
+			}
+
+			start.kind = placeholderToken
+			start.text = string(runes)[:end+2]
+			start.value = start.text
+
+			tokens = append(tokens, start)
+			advance(len([]rune(start.text)))
+		case isWordStart(character):
+			length := 1
+
+			for length < len(runes) && isWordPart(runes[length]) {
+				length++
+			}
+
+			start.kind = wordToken
+			start.text = string(runes[:length])
+			start.value = strings.ToLower(start.text)
+
+			tokens = append(tokens, start)
+			advance(length)
+		case character >= '0' && character <= '9':
+			length := 1
+
+			for length < len(runes) && (isWordPart(runes[length]) || runes[length] == '.') {
+				length++
+			}
+
+			start.kind = numberToken
+			start.text = string(runes[:length])
+			start.value = start.text
+
+			tokens = append(tokens, start)
+			advance(length)
+		default:
+			start.kind = symbolToken
+			start.text = string(character)
+			start.value = start.text
+
+			tokens = append(tokens, start)
+			advance(1)
+		}
+	}
+
+	return tokens, nil
+}
+
+// quoted returns the literal that opens runes, including its quotes, treating
+// a doubled quote as an escaped one.
+func quoted(runes []rune, quote rune) (string, bool) {
+	for index := 1; index < len(runes); index++ {
+		if runes[index] != quote {
+			continue
+		}
+
+		if index+1 < len(runes) && runes[index+1] == quote {
+			index++
+
+			continue
+		}
+
+		return string(runes[:index+1]), true
+	}
+
+	return "", false
+}
+
+func isWordStart(character rune) bool {
+	return character == '_' || (character >= 'a' && character <= 'z') || (character >= 'A' && character <= 'Z')
+}
+
+func isWordPart(character rune) bool {
+	return isWordStart(character) || (character >= '0' && character <= '9') || character == '$' || character == '@'
+}
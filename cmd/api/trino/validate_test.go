@@ -0,0 +1,87 @@
+package trino
+
+import (
+	"errors"
+	"testing"
+)
+
+func testPolicy() Policy {
+	return Policy{
+		Catalogs:     []string{"zing", "radius"},
+		DeniedTables: []string{"rm_managers", "documents"},
+		HiddenColumns: map[string][]string{
+			"customers":   {"password"},
+			"rm_managers": {"password"},
+		},
+	}
+}
+
+func TestValidateQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		safe  bool
+	}{
+		{"select", `SELECT c."Email" FROM zing.zing.customers c`, true},
+		{"join", `SELECT c.id FROM zing.zing.customers c JOIN zing.zing.addresses a ON a.id = c.addressid`, true},
+		{"parenthesised join", `SELECT c.id FROM (zing.zing.customers c JOIN zing.zing.addresses a ON a.id = c.addressid)`, true},
+		{"subquery", `SELECT x.id FROM (SELECT id FROM zing.zing.customers) x`, true},
+		{"cte", `WITH x AS (SELECT id FROM zing.zing.customers) SELECT id FROM x`, true},
+		{"extract", `SELECT EXTRACT(YEAR FROM c.datecreated) FROM zing.zing.customers c`, true},
+		{"unqualified", `SELECT id FROM customers`, false},
+		{"denied", `SELECT id FROM zing.zing.documents`, false},
+		{"denied parenthesised", `SELECT * FROM (zing.zing.documents)`, false},
+		{"denied nested parentheses", `SELECT * FROM ((zing.zing.documents) d)`, false},
+		{"denied parenthesised join", `SELECT c.id FROM zing.zing.customers c LEFT JOIN (zing.zing.documents d) ON true`, false},
+		{"denied parenthesised join list", `SELECT c.id FROM (zing.zing.customers c JOIN zing.zing.documents d ON true)`, false},
+		{"denied after comma", `SELECT c.id FROM zing.zing.customers c, (zing.zing.documents d)`, false},
+		{"denied in subquery", `SELECT x.id FROM (SELECT id FROM zing.zing.documents) x`, false},
+		{"denied in lateral", `SELECT c.id FROM zing.zing.customers c, LATERAL (SELECT id FROM zing.zing.documents) d`, false},
+		{"denied table statement", `SELECT * FROM (TABLE zing.zing.documents)`, false},
+		{"denied table statement in cte", `WITH d AS (TABLE zing.zing.documents) SELECT * FROM d`, false},
+		{"table function", `SELECT * FROM TABLE(radius.system.query(query => 'SELECT password FROM rm_managers'))`, false},
+		{"parenthesised table function", `SELECT * FROM (TABLE(radius.system.query(query => 'SELECT 1')))`, false},
+		{"system schema", `SELECT * FROM radius.system.query`, false},
+		{"hidden column", `SELECT c.password FROM zing.zing.customers c`, false},
+		{"hidden column parenthesised", `SELECT c.password FROM (zing.zing.customers c)`, false},
+		{"star with hidden column", `SELECT * FROM (zing.zing.customers)`, false},
+		{"catalog not allowed", `SELECT * FROM system.runtime.queries`, false},
+		{"delete", `DELETE FROM zing.zing.customers`, false},
+		{"two statements", `SELECT 1; SELECT 2`, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateQuery(test.query, testPolicy())
+
+			if test.safe && err != nil {
+				t.Fatalf("ValidateQuery(%q) = %v, want no error", test.query, err)
+			}
+
+			if !test.safe && !errors.Is(err, ErrUnsafeQuery) {
+				t.Fatalf("ValidateQuery(%q) = %v, want %v", test.query, err, ErrUnsafeQuery)
+			}
+		})
+	}
+}
+
+func TestCheckTable(t *testing.T) {
+	tests := []struct {
+		catalog, schema, table string
+		allowed                bool
+	}{
+		{"zing", "zing", "customers", true},
+		{"ZING", "Zing", "Customers", true},
+		{"zing", "zing", "documents", false},
+		{"radius", "system", "query", false},
+		{"system", "runtime", "queries", false},
+	}
+
+	for _, test := range tests {
+		err := testPolicy().CheckTable(test.catalog, test.schema, test.table)
+
+		if (err == nil) != test.allowed {
+			t.Errorf("CheckTable(%s, %s, %s) = %v, want allowed %v", test.catalog, test.schema, test.table, err, test.allowed)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS
+    dynamic_query_versions (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        dynamic_query_id UUID NOT NULL REFERENCES dynamic_queries (id) ON DELETE CASCADE,
+        version INTEGER NOT NULL,
+        query TEXT,
+        prompt TEXT NOT NULL,
+        response_id TEXT,
+        parameters JSONB NOT NULL DEFAULT '[]'::jsonb,
+        created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+        created_at TIMESTAMP DEFAULT NOW(),
+        UNIQUE (dynamic_query_id, version)
+    );
+
+INSERT INTO
+    dynamic_query_versions (
+        dynamic_query_id,
+        version,
+        query,
+        prompt,
+        response_id,
+        parameters,
+        created_at
+    )
+SELECT
+    id,
+    1,
+    query,
+    prompt,
+    response_id,
+    parameters,
+    updated_at
+FROM
+    dynamic_queries;
+
+-- The counter hands out version numbers, so that two versions recorded at the
+-- same time cannot both take MAX(version) + 1.
+ALTER TABLE dynamic_queries
+ADD COLUMN latest_version INTEGER NOT NULL DEFAULT 0;
+
+UPDATE dynamic_queries
+SET
+    latest_version = 1;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE dynamic_queries
+DROP COLUMN IF EXISTS latest_version;
+
+DROP TABLE IF EXISTS dynamic_query_versions;
+
+-- +goose StatementEnd
@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE dynamic_query_job_status AS ENUM (
+    'queued',
+    'running',
+    'complete',
+    'error',
+    'cancelled'
+);
+
+CREATE TABLE IF NOT EXISTS
+    dynamic_query_jobs (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        dynamic_query_id UUID NOT NULL REFERENCES dynamic_queries (id) ON DELETE CASCADE,
+        status dynamic_query_job_status NOT NULL DEFAULT 'queued',
+        error TEXT,
+        created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+        started_at TIMESTAMP,
+        heartbeat_at TIMESTAMP,
+        finished_at TIMESTAMP,
+        created_at TIMESTAMP DEFAULT NOW(),
+        updated_at TIMESTAMP DEFAULT NOW()
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS dynamic_query_jobs_active_idx ON dynamic_query_jobs (dynamic_query_id)
+WHERE
+    status IN ('queued', 'running');
+
+CREATE TABLE IF NOT EXISTS
+    dynamic_query_job_events (
+        id BIGSERIAL PRIMARY KEY,
+        job_id UUID NOT NULL REFERENCES dynamic_query_jobs (id) ON DELETE CASCADE,
+        event TEXT NOT NULL,
+        data TEXT NOT NULL,
+        created_at TIMESTAMP DEFAULT NOW()
+    );
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS dynamic_query_job_events;
+
+DROP TABLE IF EXISTS dynamic_query_jobs;
+
+DROP TYPE IF EXISTS dynamic_query_job_status;
+
+-- +goose StatementEnd
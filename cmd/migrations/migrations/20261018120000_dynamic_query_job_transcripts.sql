@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE dynamic_query_jobs
+ADD COLUMN IF NOT EXISTS thought_process TEXT,
+ADD COLUMN IF NOT EXISTS sql_query TEXT,
+ADD COLUMN IF NOT EXISTS parameters JSONB,
+ADD COLUMN IF NOT EXISTS response_id TEXT,
+ADD COLUMN IF NOT EXISTS input_tokens BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS output_tokens BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS total_tokens BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS
+    dynamic_query_job_tool_calls (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        job_id UUID NOT NULL REFERENCES dynamic_query_jobs (id) ON DELETE CASCADE,
+        name TEXT NOT NULL,
+        arguments TEXT NOT NULL,
+        output TEXT,
+        error TEXT,
+        latency_ms BIGINT NOT NULL,
+        started_at TIMESTAMP NOT NULL,
+        created_at TIMESTAMP DEFAULT NOW()
+    );
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS dynamic_query_job_tool_calls;
+
+ALTER TABLE dynamic_query_jobs
+DROP COLUMN IF EXISTS thought_process,
+DROP COLUMN IF EXISTS sql_query,
+DROP COLUMN IF EXISTS parameters,
+DROP COLUMN IF EXISTS response_id,
+DROP COLUMN IF EXISTS input_tokens,
+DROP COLUMN IF EXISTS output_tokens,
+DROP COLUMN IF EXISTS total_tokens;
+
+-- +goose StatementEnd
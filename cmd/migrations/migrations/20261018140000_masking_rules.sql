@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE masking_strategy AS ENUM (
+    'redact',
+    'show_first',
+    'show_last',
+    'email',
+    'hash'
+);
+
+CREATE TABLE IF NOT EXISTS
+    masking_rules (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        role role_type NOT NULL,
+        column_name TEXT NOT NULL,
+        strategy masking_strategy NOT NULL,
+        visible_characters INTEGER NOT NULL DEFAULT 4,
+        created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+        created_at TIMESTAMP DEFAULT NOW(),
+        updated_at TIMESTAMP DEFAULT NOW(),
+        UNIQUE (role, column_name)
+    );
+
+INSERT INTO
+    masking_rules (role, column_name, strategy, visible_characters)
+VALUES
+    ('user', 'Email', 'email', 1),
+    ('user', 'PhoneNumber', 'show_last', 4),
+    ('user', 'IDNumber', 'show_last', 4),
+    ('user', 'RadiusUsername', 'show_last', 4);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS masking_rules;
+
+DROP TYPE IF EXISTS masking_strategy;
+
+-- +goose StatementEnd
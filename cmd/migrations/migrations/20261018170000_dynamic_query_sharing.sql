@@ -0,0 +1,83 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE dynamic_query_visibility AS ENUM ('private', 'shared', 'public');
+
+CREATE TYPE dynamic_query_permission AS ENUM ('view', 'run', 'edit');
+
+-- Every existing query was visible to every role, so they stay public. New
+-- queries are private to their owner.
+ALTER TABLE dynamic_queries
+ADD COLUMN created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+ADD COLUMN visibility dynamic_query_visibility NOT NULL DEFAULT 'public';
+
+ALTER TABLE dynamic_queries
+ALTER COLUMN visibility
+SET DEFAULT 'private';
+
+CREATE TABLE IF NOT EXISTS
+    dynamic_query_shares (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        dynamic_query_id UUID NOT NULL REFERENCES dynamic_queries (id) ON DELETE CASCADE,
+        user_id UUID REFERENCES users (id) ON DELETE CASCADE,
+        role role_type,
+        permission dynamic_query_permission NOT NULL,
+        created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+        created_at TIMESTAMP DEFAULT NOW(),
+        CHECK ((user_id IS NULL) <> (role IS NULL)),
+        UNIQUE (dynamic_query_id, user_id),
+        UNIQUE (dynamic_query_id, role)
+    );
+
+-- dynamic_query_visible reports whether a user with a role may see a dynamic
+-- query. Admins and the owner see it, anyone sees a public one and shares to
+-- the user or their role reveal a shared one. The API works out the same with
+-- more detail in accessFor.
+CREATE OR REPLACE FUNCTION dynamic_query_visible (query_id UUID, viewer_id UUID, viewer_role role_type) RETURNS BOOLEAN LANGUAGE sql STABLE AS $$
+    SELECT
+        viewer_role = 'admin'
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                dynamic_queries
+            WHERE
+                dynamic_queries.id = query_id
+                AND (
+                    dynamic_queries.created_by = viewer_id
+                    OR dynamic_queries.visibility = 'public'
+                    OR (
+                        dynamic_queries.visibility = 'shared'
+                        AND EXISTS (
+                            SELECT
+                                1
+                            FROM
+                                dynamic_query_shares
+                            WHERE
+                                dynamic_query_shares.dynamic_query_id = dynamic_queries.id
+                                AND (
+                                    dynamic_query_shares.user_id = viewer_id
+                                    OR dynamic_query_shares.role = viewer_role
+                                )
+                        )
+                    )
+                )
+        )
+$$;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP FUNCTION IF EXISTS dynamic_query_visible;
+
+DROP TABLE IF EXISTS dynamic_query_shares;
+
+ALTER TABLE dynamic_queries
+DROP COLUMN IF EXISTS visibility,
+DROP COLUMN IF EXISTS created_by;
+
+DROP TYPE IF EXISTS dynamic_query_permission;
+
+DROP TYPE IF EXISTS dynamic_query_visibility;
+
+-- +goose StatementEnd
@@ -0,0 +1,63 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS
+    dynamic_query_folders (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        parent_id UUID REFERENCES dynamic_query_folders (id) ON DELETE CASCADE,
+        name TEXT NOT NULL,
+        created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+        created_at TIMESTAMP DEFAULT NOW(),
+        updated_at TIMESTAMP DEFAULT NOW()
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS dynamic_query_folders_name_idx ON dynamic_query_folders (
+    COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'::uuid),
+    LOWER(name)
+);
+
+ALTER TABLE dynamic_queries
+ADD COLUMN folder_id UUID REFERENCES dynamic_query_folders (id) ON DELETE SET NULL,
+ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS dynamic_queries_search_idx ON dynamic_queries USING GIN (to_tsvector('english', name || ' ' || prompt));
+
+CREATE INDEX IF NOT EXISTS dynamic_queries_tags_idx ON dynamic_queries USING GIN (tags);
+
+CREATE TABLE IF NOT EXISTS
+    dynamic_query_favourites (
+        user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+        dynamic_query_id UUID NOT NULL REFERENCES dynamic_queries (id) ON DELETE CASCADE,
+        created_at TIMESTAMP DEFAULT NOW(),
+        PRIMARY KEY (user_id, dynamic_query_id)
+    );
+
+CREATE TABLE IF NOT EXISTS
+    dynamic_query_recent_runs (
+        user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+        dynamic_query_id UUID NOT NULL REFERENCES dynamic_queries (id) ON DELETE CASCADE,
+        run_count BIGINT NOT NULL DEFAULT 1,
+        last_run_at TIMESTAMP NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (user_id, dynamic_query_id)
+    );
+
+CREATE INDEX IF NOT EXISTS dynamic_query_recent_runs_user_idx ON dynamic_query_recent_runs (user_id, last_run_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS dynamic_query_recent_runs;
+
+DROP TABLE IF EXISTS dynamic_query_favourites;
+
+DROP INDEX IF EXISTS dynamic_queries_tags_idx;
+
+DROP INDEX IF EXISTS dynamic_queries_search_idx;
+
+ALTER TABLE dynamic_queries
+DROP COLUMN IF EXISTS tags,
+DROP COLUMN IF EXISTS folder_id;
+
+DROP TABLE IF EXISTS dynamic_query_folders;
+
+-- +goose StatementEnd
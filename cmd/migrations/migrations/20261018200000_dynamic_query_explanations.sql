@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE dynamic_queries
+ADD COLUMN explanation JSONB,
+ADD COLUMN explanation_error TEXT,
+ADD COLUMN explanation_started_at TIMESTAMP;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE dynamic_queries
+DROP COLUMN IF EXISTS explanation_started_at,
+DROP COLUMN IF EXISTS explanation_error,
+DROP COLUMN IF EXISTS explanation;
+
+-- +goose StatementEnd
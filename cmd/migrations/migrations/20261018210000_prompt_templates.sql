@@ -0,0 +1,53 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS
+    prompt_templates (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        name TEXT NOT NULL UNIQUE,
+        description TEXT NOT NULL DEFAULT '',
+        body TEXT NOT NULL,
+        version INTEGER NOT NULL DEFAULT 1,
+        is_default BOOLEAN NOT NULL DEFAULT FALSE,
+        created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+        created_at TIMESTAMP DEFAULT NOW(),
+        updated_at TIMESTAMP DEFAULT NOW()
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS prompt_templates_default_idx ON prompt_templates (is_default)
+WHERE
+    is_default;
+
+CREATE TABLE IF NOT EXISTS
+    prompt_template_versions (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        prompt_template_id UUID NOT NULL REFERENCES prompt_templates (id) ON DELETE CASCADE,
+        version INTEGER NOT NULL,
+        body TEXT NOT NULL,
+        created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+        created_at TIMESTAMP DEFAULT NOW(),
+        UNIQUE (prompt_template_id, version)
+    );
+
+ALTER TABLE dynamic_queries
+ADD COLUMN prompt_template_id UUID REFERENCES prompt_templates (id) ON DELETE SET NULL;
+
+ALTER TABLE dynamic_query_jobs
+ADD COLUMN prompt_template_id UUID REFERENCES prompt_templates (id) ON DELETE SET NULL,
+ADD COLUMN prompt_template_version INTEGER;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE dynamic_query_jobs
+DROP COLUMN IF EXISTS prompt_template_version,
+DROP COLUMN IF EXISTS prompt_template_id;
+
+ALTER TABLE dynamic_queries
+DROP COLUMN IF EXISTS prompt_template_id;
+
+DROP TABLE IF EXISTS prompt_template_versions;
+
+DROP TABLE IF EXISTS prompt_templates;
+
+-- +goose StatementEnd
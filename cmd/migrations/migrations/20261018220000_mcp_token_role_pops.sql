@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE mcp_tokens
+ADD COLUMN IF NOT EXISTS role role_type NOT NULL DEFAULT 'user',
+ADD COLUMN IF NOT EXISTS pops TEXT[] NOT NULL DEFAULT '{}';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE mcp_tokens
+DROP COLUMN IF EXISTS pops,
+DROP COLUMN IF EXISTS role;
+
+-- +goose StatementEnd
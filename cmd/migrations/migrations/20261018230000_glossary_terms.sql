@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS
+    glossary_terms (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        term TEXT NOT NULL,
+        definition TEXT NOT NULL,
+        created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+        created_at TIMESTAMP DEFAULT NOW(),
+        updated_at TIMESTAMP DEFAULT NOW()
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS glossary_terms_term_idx ON glossary_terms (lower(term));
+
+INSERT INTO
+    glossary_terms (term, definition)
+VALUES
+    ('POP', 'A point of presence, the fibre site a customer is connected through. Stored as the POP name in `zing.Addresses.POP`.'),
+    ('ServiceID', 'The number that identifies a customer''s connection, `zing.Addresses.ServiceID`. Staff quote it instead of the address.'),
+    ('Radius username', 'The username a customer''s router signs in to the network with. `zing.Addresses.RadiusUsername` matches `radius.rm_users.username`.'),
+    ('Build', 'The development or project an address was built under, `zing.Builds`, linked through `zing.Addresses.BuildId`.'),
+    ('Build type', 'The kind of build, such as a freestanding area or a multi-dwelling unit, `zing.BuildTypes.Name` through `zing.Builds.BuildTypeId`.'),
+    ('Recharge', 'A purchase that extends a customer''s access, `zing.Recharges`. Only rows with `RechargeSuccessful = 1` were paid.'),
+    ('Intro package', 'The free access a customer starts with. Recharges for it have no product, so the product category or name is NULL.'),
+    ('Expiring customer', 'A customer whose access, `radius.rm_users.expiration`, runs out within the period asked about.');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS glossary_terms;
+
+-- +goose StatementEnd
@@ -965,11 +965,6 @@ export const UpdateDynamicQuerySchema = {
     Prompt: {
       type: 'string',
     },
-    Status: {
-      default: 'in_progress',
-      enum: ['complete', 'in_progress', 'error'],
-      type: 'string',
-    },
   },
 } as const;
 
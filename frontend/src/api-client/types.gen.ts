@@ -313,7 +313,6 @@ export type SuccessResponse = {
 export type UpdateDynamicQuery = {
   Name?: string;
   Prompt?: string;
-  Status?: 'complete' | 'in_progress' | 'error';
 };
 
 export type UpdateUser = {
@@ -3130,7 +3129,18 @@ export type GetApiDynamicQueriesByIdResultsData = {
   path: {
     id: string;
   };
-  query?: never;
+  query?: {
+    page?: number;
+    pageSize?: number;
+    /**
+     * Comma separated list of Column:asc or Column:desc
+     */
+    sort?: string;
+    /**
+     * Column:value, repeat for each filtered column
+     */
+    filter?: Array<string>;
+  };
   url: '/api/dynamic-queries/{id}/results';
 };
 
@@ -24,10 +24,8 @@ import {
 import { ArrowLeftIcon } from 'lucide-react';
 import { useEffect, useRef, useState } from 'react';
 import { useForm } from 'react-hook-form';
-import { usePapaParse } from 'react-papaparse';
-
-import { type ParseResult } from 'papaparse';
 import { toast } from 'sonner';
+import z from 'zod';
 
 import {
   type DynamicQuery,
@@ -36,9 +34,9 @@ import {
   type UpdateDynamicQuery,
   getApiDynamicQueriesById,
 } from '@/api-client';
+import Pagination from '@/components/pagination';
 import { Alert, AlertDescription, AlertTitle } from '@/components/ui/alert';
 import { Button } from '@/components/ui/button';
-import { DataTablePagination } from '@/components/ui/data-table';
 import {
   Form,
   FormControl,
@@ -68,6 +66,10 @@ import { apiClient } from '@/lib/utils';
 
 export const Route = createFileRoute('/dynamic-reports/$id')({
   component: () => <RouteComponent />,
+  validateSearch: z.object({
+    page: z.coerce.number().default(1),
+    pageSize: z.coerce.number().default(10),
+  }),
   pendingComponent: () => (
     <div className="flex flex-col w-full h-full items-center justify-center">
       <Label className="text-muted-foreground">Loading dynamic report...</Label>
@@ -118,12 +120,12 @@ export const Route = createFileRoute('/dynamic-reports/$id')({
 
 function RouteComponent() {
   const router = useRouter();
-  const { readString } = usePapaParse();
 
-  const [columns, setColumns] = useState<string[]>([]);
-  const [data, setData] = useState<Record<string, string>[]>([]);
+  const [columns, setColumns] = useState<DynamicQueryResult['columns']>([]);
+  const [data, setData] = useState<DynamicQueryResult['data']>([]);
 
   const { id } = Route.useParams();
+  const { page, pageSize } = Route.useSearch();
   const { dynamicQuery } = Route.useLoaderData();
   const {
     data: dynamicQueryResults,
@@ -135,6 +137,10 @@ function RouteComponent() {
       path: {
         id,
       },
+      query: {
+        page,
+        pageSize,
+      },
     }),
     enabled: dynamicQuery?.Status === 'complete',
   });
@@ -154,7 +160,6 @@ function RouteComponent() {
   const updateForm = useForm<UpdateDynamicQuery>({
     defaultValues: {
       Prompt: dynamicQuery?.Prompt,
-      Status: 'in_progress',
     },
   });
 
@@ -200,11 +205,10 @@ function RouteComponent() {
 
         for (const part of parts) {
           const eventMatch = part.match(/^event: (.+)$/m);
-          // const dataMatch = part.match(/^data: (.+)$/m);
+          const dataMatch = part.match(/^data: (.+)$/m);
 
           const eventType = eventMatch ? eventMatch[1].trim() : null;
-          // const dataString = dataMatch ? dataMatch[1].trim() : null;
-          // const dataValue = JSON.parse(dataString || '');
+          const dataString = dataMatch ? dataMatch[1].trim() : '';
 
           if (eventType === 'done') {
             setStatusDetail(
@@ -215,6 +219,16 @@ function RouteComponent() {
               return router.invalidate();
             }, 1000);
           }
+
+          if (eventType === 'error' || eventType === 'cancelled') {
+            setStatusDetail(
+              `The dynamic query could not be generated: ${dataString}`
+            );
+
+            setTimeout(() => {
+              return router.invalidate();
+            }, 1000);
+          }
         }
       }
     } catch (error: unknown) {
@@ -271,15 +285,12 @@ function RouteComponent() {
       if (dynamicQueryResults === undefined || dynamicQueryResults === null)
         return;
 
-      const data = ((dynamicQueryResults ?? {}) as { data: string }).data;
+      const result = (
+        (dynamicQueryResults ?? {}) as { data: DynamicQueryResult }
+      ).data;
 
-      readString(data, {
-        header: true,
-        complete: ({ data }: ParseResult<Record<string, string>>) => {
-          setData(data);
-          setColumns(Object.keys(data[0] ?? {}));
-        },
-      });
+      setColumns(result?.columns ?? []);
+      setData(result?.data ?? []);
     }, 0);
 
     return () => {
@@ -289,11 +300,15 @@ function RouteComponent() {
 
   const table = useReactTable({
     data: data,
-    columns: columns.map((column) => ({
-      accessorKey: column,
-      header: () => <Label>{column}</Label>,
-      cell: ({ row }) => <Label>{row.getValue(column)}</Label>,
-    })) as ColumnDef<Record<string, string>, string>[],
+    columns: columns.map<ColumnDef<DynamicQueryResult['data'][number]>>(
+      (column) => ({
+        id: column.name,
+        accessorFn: (row) => row[column.name],
+        header: () => <Label>{column.label}</Label>,
+        cell: ({ getValue }) => <Label>{String(getValue() ?? '')}</Label>,
+      })
+    ),
+    manualPagination: true,
     onSortingChange: setSorting,
     onColumnFiltersChange: setColumnFilters,
     getCoreRowModel: getCoreRowModel(),
@@ -428,7 +443,12 @@ function RouteComponent() {
                   </Table>
                 </div>
 
-                <DataTablePagination table={table} />
+                <Pagination
+                  pages={
+                    ((dynamicQueryResults ?? {}) as { pages?: number }).pages ??
+                    1
+                  }
+                />
               </div>
             )}
           </div>
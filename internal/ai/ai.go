@@ -1,28 +1,142 @@
 package ai
 
 import (
+	"context"
+	"fmt"
+	"strconv"
+
 	"github.com/connor-davis/zingfibre-core/common"
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
 	"github.com/connor-davis/zingfibre-core/internal/postgres"
-	"github.com/gofiber/fiber/v2"
-	"github.com/google/uuid"
 	"github.com/openai/openai-go/v3"
-	"github.com/openai/openai-go/v3/option"
 )
 
 type AI interface {
-	GenerateDynamicQuery(queryId uuid.UUID, prompt string, ctx *fiber.Ctx) error
+	GenerateDynamicQuery(ctx context.Context, dynamicQuery postgres.DynamicQuery, systemPrompt string, progress func(Progress)) (GenerateDynamicQueryOutput, error)
+	ExplainDynamicQuery(ctx context.Context, dynamicQuery postgres.DynamicQuery) (system.DynamicQueryExplanation, error)
+}
+
+const (
+	ProviderOpenAI           = "openai"
+	ProviderOpenAICompatible = "openai-compatible"
+	ProviderFake             = "fake"
+)
+
+// GenerationTools are the MCP tools dynamic query generation may call, all
+// without approval. The MCP token issued for generation is limited to them.
+var GenerationTools = []string{
+	"list-catalogs",
+	"list-schemas",
+	"list-tables",
+	"test-query",
+	"describe-table",
+	"sample-rows",
+	"profile-column",
+	"suggest-joins",
+}
+
+// Config selects the provider used for generation and tunes the model for a
+// deployment. A nil Temperature or a zero MaxOutputTokens leaves the provider
+// default in place.
+type Config struct {
+	Provider        string
+	Model           string
+	BaseURL         string
+	APIKey          string
+	Temperature     *float64
+	MaxOutputTokens int64
+	MaxToolCalls    int
+	MCPURL          string
+	MCPToken        string
+	FakeScriptPath  string
+	// DateFormat is the format prompt templates tell the model to format
+	// dates in, such as dd/mm/yyyy.
+	DateFormat string
 }
 
-type ai struct {
-	postgres *postgres.Queries
-	openai   openai.Client
+// ConfigFromEnv reads the AI_* environment variables.
+func ConfigFromEnv() (Config, error) {
+	config := Config{
+		Provider:       common.EnvString("AI_PROVIDER", ProviderOpenAI),
+		Model:          common.EnvString("AI_MODEL", ""),
+		BaseURL:        common.EnvString("AI_BASE_URL", ""),
+		APIKey:         common.EnvString("AI_API_KEY", common.EnvString("OPENAI_API_KEY", "")),
+		MCPURL:         common.EnvString("MCP_BASE_URL", "http://localhost:6173/api/mcp"),
+		MCPToken:       common.EnvString("MCP_TOKEN", ""),
+		FakeScriptPath: common.EnvString("AI_FAKE_SCRIPT", ""),
+		DateFormat:     common.EnvString("AI_DATE_FORMAT", DefaultDateFormat),
+		MaxToolCalls:   25,
+	}
+
+	if err := ValidateDateFormat(config.DateFormat); err != nil {
+		return config, fmt.Errorf("AI_DATE_FORMAT: %w", err)
+	}
+
+	if value := common.EnvString("AI_TEMPERATURE", ""); value != "" {
+		temperature, err := strconv.ParseFloat(value, 64)
+
+		if err != nil {
+			return config, fmt.Errorf("AI_TEMPERATURE: %w", err)
+		}
+
+		config.Temperature = &temperature
+	}
+
+	if value := common.EnvString("AI_MAX_OUTPUT_TOKENS", ""); value != "" {
+		maxOutputTokens, err := strconv.ParseInt(value, 10, 64)
+
+		if err != nil {
+			return config, fmt.Errorf("AI_MAX_OUTPUT_TOKENS: %w", err)
+		}
+
+		config.MaxOutputTokens = maxOutputTokens
+	}
+
+	if value := common.EnvString("AI_MAX_TOOL_CALLS", ""); value != "" {
+		maxToolCalls, err := strconv.Atoi(value)
+
+		if err != nil {
+			return config, fmt.Errorf("AI_MAX_TOOL_CALLS: %w", err)
+		}
+
+		config.MaxToolCalls = maxToolCalls
+	}
+
+	return config, nil
 }
 
-func New(postgres *postgres.Queries) AI {
-	openai := openai.NewClient(option.WithAPIKey(common.EnvString("OPENAI_API_KEY", "")))
+// New returns the provider selected by config.
+func New(config Config) (AI, error) {
+	switch config.Provider {
+	case ProviderOpenAI:
+		if config.Model == "" {
+			config.Model = openai.ChatModelGPT5Mini
+		}
+
+		return newOpenAI(config), nil
+	case ProviderOpenAICompatible:
+		if config.BaseURL == "" {
+			return nil, fmt.Errorf("the %s provider requires AI_BASE_URL", config.Provider)
+		}
+
+		if config.Model == "" {
+			return nil, fmt.Errorf("the %s provider requires AI_MODEL", config.Provider)
+		}
+
+		return newOpenAICompatible(config), nil
+	case ProviderFake:
+		if config.FakeScriptPath == "" {
+			return NewFake(DefaultFakeScripts), nil
+		}
+
+		scripts, err := LoadFakeScripts(config.FakeScriptPath)
+
+		if err != nil {
+			return nil, err
+		}
 
-	return &ai{
-		postgres: postgres,
-		openai:   openai,
+		return NewFake(scripts), nil
+	default:
+		return nil, fmt.Errorf("unknown AI provider %q", config.Provider)
 	}
 }
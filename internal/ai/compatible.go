@@ -0,0 +1,281 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/goccy/go-json"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
+	"github.com/openai/openai-go/v3/shared"
+)
+
+// openAICompatible generates through the Chat Completions API of any
+// OpenAI-compatible server, such as Ollama or llama.cpp. Those servers cannot
+// reach the MCP server themselves, so the tool calls are made from here.
+type openAICompatible struct {
+	client openai.Client
+	config Config
+}
+
+func newOpenAICompatible(config Config) *openAICompatible {
+	options := []option.RequestOption{
+		option.WithBaseURL(config.BaseURL),
+	}
+
+	if config.APIKey != "" {
+		options = append(options, option.WithAPIKey(config.APIKey))
+	}
+
+	return &openAICompatible{
+		client: openai.NewClient(options...),
+		config: config,
+	}
+}
+
+func (ai *openAICompatible) GenerateDynamicQuery(ctx context.Context, dynamicQuery postgres.DynamicQuery, systemPrompt string, progress func(Progress)) (GenerateDynamicQueryOutput, error) {
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "zing-ai", Version: "v1.0.0"}, nil).Connect(ctx, &mcp.StreamableClientTransport{
+		Endpoint: ai.config.MCPURL,
+		HTTPClient: &http.Client{
+			Transport: &bearerTransport{token: ai.config.MCPToken, base: http.DefaultTransport},
+		},
+	}, nil)
+
+	if err != nil {
+		return GenerateDynamicQueryOutput{}, fmt.Errorf("unable to connect to the MCP server: %w", err)
+	}
+
+	defer session.Close()
+
+	listedTools, err := session.ListTools(ctx, nil)
+
+	if err != nil {
+		return GenerateDynamicQueryOutput{}, fmt.Errorf("unable to list the MCP tools: %w", err)
+	}
+
+	tools := []openai.ChatCompletionToolUnionParam{}
+
+	for _, tool := range listedTools.Tools {
+		if !slices.Contains(GenerationTools, tool.Name) {
+			continue
+		}
+
+		parameters := shared.FunctionParameters{}
+
+		if schema, err := json.Marshal(tool.InputSchema); err == nil {
+			_ = json.Unmarshal(schema, &parameters)
+		}
+
+		tools = append(tools, openai.ChatCompletionFunctionTool(shared.FunctionDefinitionParam{
+			Name:        tool.Name,
+			Description: openai.String(tool.Description),
+			Parameters:  parameters,
+		}))
+	}
+
+	messages := []openai.ChatCompletionMessageParamUnion{
+		openai.SystemMessage(systemPrompt),
+		openai.UserMessage(dynamicQuery.Prompt),
+	}
+
+	output := GenerateDynamicQueryOutput{}
+	toolCalls := 0
+
+	for {
+		params := openai.ChatCompletionNewParams{
+			Model:    ai.config.Model,
+			Messages: messages,
+			Tools:    tools,
+			ResponseFormat: openai.ChatCompletionNewParamsResponseFormatUnion{
+				OfJSONSchema: &shared.ResponseFormatJSONSchemaParam{
+					JSONSchema: shared.ResponseFormatJSONSchemaJSONSchemaParam{
+						Name:        "create_dynamic_query_output",
+						Schema:      GenerateDynamicQueryOutputSchema,
+						Strict:      openai.Bool(true),
+						Description: openai.String("The output for create dynamic query"),
+					},
+				},
+			},
+		}
+
+		if ai.config.Temperature != nil {
+			params.Temperature = openai.Float(*ai.config.Temperature)
+		}
+
+		if ai.config.MaxOutputTokens > 0 {
+			params.MaxCompletionTokens = openai.Int(ai.config.MaxOutputTokens)
+		}
+
+		progress(Progress{Type: "chat.completion.requested"})
+
+		completion, err := ai.client.Chat.Completions.New(ctx, params)
+
+		if err != nil {
+			return output, err
+		}
+
+		output.Usage.InputTokens += completion.Usage.PromptTokens
+		output.Usage.OutputTokens += completion.Usage.CompletionTokens
+		output.Usage.TotalTokens += completion.Usage.TotalTokens
+
+		progress(Progress{Type: "chat.completion.completed"})
+
+		if len(completion.Choices) == 0 {
+			return output, errors.New("the model returned no choices")
+		}
+
+		message := completion.Choices[0].Message
+
+		if len(message.ToolCalls) == 0 {
+			usage := output.Usage
+
+			if err := json.Unmarshal([]byte(message.Content), &output); err != nil {
+				return output, fmt.Errorf("the model returned output that is not valid JSON: %w", err)
+			}
+
+			output.Usage = usage
+
+			return output, nil
+		}
+
+		messages = append(messages, message.ToParam())
+
+		for _, call := range message.ToolCalls {
+			toolCalls++
+
+			if toolCalls > ai.config.MaxToolCalls {
+				return output, fmt.Errorf("the model made more than %d tool calls without answering", ai.config.MaxToolCalls)
+			}
+
+			toolCall := callTool(ctx, session, call.Function.Name, call.Function.Arguments)
+
+			progress(Progress{Type: "mcp_call", ToolCall: &toolCall})
+
+			content := toolCall.Output
+
+			if toolCall.Error != "" {
+				content = fmt.Sprintf("Error: %s", toolCall.Error)
+			}
+
+			messages = append(messages, openai.ToolMessage(content, call.ID))
+		}
+	}
+}
+
+// ExplainDynamicQuery asks the model to describe the SQL of dynamicQuery in
+// plain English. It does not need the MCP server.
+func (ai *openAICompatible) ExplainDynamicQuery(ctx context.Context, dynamicQuery postgres.DynamicQuery) (system.DynamicQueryExplanation, error) {
+	params := openai.ChatCompletionNewParams{
+		Model: ai.config.Model,
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			openai.SystemMessage(explainSystemPrompt()),
+			openai.UserMessage(explainPrompt(dynamicQuery)),
+		},
+		ResponseFormat: openai.ChatCompletionNewParamsResponseFormatUnion{
+			OfJSONSchema: &shared.ResponseFormatJSONSchemaParam{
+				JSONSchema: shared.ResponseFormatJSONSchemaJSONSchemaParam{
+					Name:        "explain_dynamic_query_output",
+					Schema:      ExplainDynamicQueryOutputSchema,
+					Strict:      openai.Bool(true),
+					Description: openai.String("The output for explain dynamic query"),
+				},
+			},
+		},
+	}
+
+	if ai.config.Temperature != nil {
+		params.Temperature = openai.Float(*ai.config.Temperature)
+	}
+
+	if ai.config.MaxOutputTokens > 0 {
+		params.MaxCompletionTokens = openai.Int(ai.config.MaxOutputTokens)
+	}
+
+	completion, err := ai.client.Chat.Completions.New(ctx, params)
+
+	if err != nil {
+		return system.DynamicQueryExplanation{}, err
+	}
+
+	if len(completion.Choices) == 0 {
+		return system.DynamicQueryExplanation{}, errors.New("the model returned no choices")
+	}
+
+	explanation := system.DynamicQueryExplanation{}
+
+	if err := json.Unmarshal([]byte(completion.Choices[0].Message.Content), &explanation); err != nil {
+		return explanation, fmt.Errorf("the model returned output that is not valid JSON: %w", err)
+	}
+
+	return explanation, nil
+}
+
+// bearerTransport authenticates every request to the MCP server with the MCP
+// token.
+type bearerTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *bearerTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request = request.Clone(request.Context())
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t.token))
+
+	return t.base.RoundTrip(request)
+}
+
+func callTool(ctx context.Context, session *mcp.ClientSession, name string, arguments string) ToolCall {
+	toolCall := ToolCall{
+		Name:      name,
+		Arguments: arguments,
+		StartedAt: time.Now(),
+	}
+
+	var parsedArguments map[string]any
+
+	if strings.TrimSpace(arguments) != "" {
+		if err := json.Unmarshal([]byte(arguments), &parsedArguments); err != nil {
+			toolCall.Error = fmt.Sprintf("the arguments are not valid JSON: %s", err.Error())
+			toolCall.Latency = time.Since(toolCall.StartedAt)
+
+			return toolCall
+		}
+	}
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      name,
+		Arguments: parsedArguments,
+	})
+
+	toolCall.Latency = time.Since(toolCall.StartedAt)
+
+	if err != nil {
+		toolCall.Error = err.Error()
+
+		return toolCall
+	}
+
+	texts := []string{}
+
+	for _, content := range result.Content {
+		if text, ok := content.(*mcp.TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+
+	if result.IsError {
+		toolCall.Error = strings.Join(texts, "\n")
+	} else {
+		toolCall.Output = strings.Join(texts, "\n")
+	}
+
+	return toolCall
+}
@@ -1,10 +1,132 @@
 package ai
 
 import (
-	"github.com/gofiber/fiber/v2"
-	"github.com/google/uuid"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/goccy/go-json"
 )
 
-func (ai *ai) GenerateDynamicQuery(queryId uuid.UUID, prompt string, ctx *fiber.Ctx) error {
-	return nil
+type GenerateDynamicQueryOutput struct {
+	SqlQuery       string                        `json:"sql_query" jsonschema_description:"The SQL query to be executed for the dynamic query."`
+	ThoughtProcess string                        `json:"thought_process" jsonschema_description:"Your thought process"`
+	Parameters     system.DynamicQueryParameters `json:"parameters" jsonschema_description:"The parameters referenced by placeholders in the SQL query."`
+	ResponseID     string                        `json:"-"`
+	Usage          Usage                         `json:"-"`
+}
+
+type Usage struct {
+	InputTokens  int64
+	OutputTokens int64
+	TotalTokens  int64
+}
+
+// ToolCall is a single MCP tool call the model made while generating.
+type ToolCall struct {
+	Name      string
+	Arguments string
+	Output    string
+	Error     string
+	StartedAt time.Time
+	Latency   time.Duration
+}
+
+// Progress is reported for every streamed event. ToolCall is only set once a
+// tool call has finished.
+type Progress struct {
+	Type     string
+	ToolCall *ToolCall
+}
+
+var GenerateDynamicQueryOutputSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"sql_query": map[string]any{
+			"type":                   "string",
+			"description":            "The SQL query to be executed for the dynamic query.",
+			"jsonschema_description": "The SQL query to be executed for the dynamic query.",
+		},
+		"thought_process": map[string]any{
+			"type":        "string",
+			"description": "Your thought process",
+		},
+		"parameters": map[string]any{
+			"type":        "array",
+			"description": "The parameters referenced by placeholders in the SQL query.",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name": map[string]any{
+						"type":        "string",
+						"description": "The placeholder name, letters, digits and underscores only.",
+					},
+					"label": map[string]any{
+						"type":        "string",
+						"description": "A human-readable label for the parameter input.",
+					},
+					"type": map[string]any{
+						"type": "string",
+						"enum": []string{"date", "date_range", "pop", "string", "number", "enum"},
+					},
+					"required": map[string]any{
+						"type": "boolean",
+					},
+					"default": map[string]any{
+						"type":        "string",
+						"description": "The default value. Dates are yyyy-mm-dd and date ranges are start,end.",
+					},
+					"options": map[string]any{
+						"type":        "array",
+						"description": "The allowed values for enum parameters, empty otherwise.",
+						"items": map[string]any{
+							"type": "string",
+						},
+					},
+				},
+				"required":             []string{"name", "label", "type", "required", "default", "options"},
+				"additionalProperties": false,
+			},
+		},
+	},
+	"required":             []string{"sql_query", "thought_process", "parameters"},
+	"additionalProperties": false,
+}
+
+const refinementPrompt = `The dynamic query was originally requested as:
+
+%s
+
+Its current SQL query is:
+
+~~~sql
+%s
+~~~
+
+Its current parameters are:
+
+~~~json
+%s
+~~~
+
+Revise the query according to the instruction below and keep everything else about it the same. Follow the same workflow as before, including testing the complete revised query, and output the complete revised query with all of its parameters.
+
+Instruction: %s`
+
+// RefinementPrompt asks the model to revise the SQL of a dynamic query by
+// following a follow-up instruction. The current SQL is repeated in full so
+// that providers which cannot continue a previous response still have it.
+func RefinementPrompt(prompt string, sqlQuery string, parameters system.DynamicQueryParameters, instruction string) string {
+	if parameters == nil {
+		parameters = system.DynamicQueryParameters{}
+	}
+
+	encodedParameters, err := json.MarshalIndent(parameters, "", "  ")
+
+	if err != nil {
+		encodedParameters = []byte("[]")
+	}
+
+	return fmt.Sprintf(strings.ReplaceAll(refinementPrompt, "~", "`"), prompt, sqlQuery, encodedParameters, instruction)
 }
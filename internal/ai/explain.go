@@ -0,0 +1,100 @@
+package ai
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/goccy/go-json"
+)
+
+var ExplainDynamicQueryOutputSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"summary": map[string]any{
+			"type":        "string",
+			"description": "What the query returns, in two or three plain-English sentences.",
+		},
+		"tables": map[string]any{
+			"type":        "array",
+			"description": "Every table the query reads, fully qualified as catalog.schema.table.",
+			"items": map[string]any{
+				"type": "string",
+			},
+		},
+		"filters": map[string]any{
+			"type":        "array",
+			"description": "Every condition that limits which rows are returned, in plain English.",
+			"items": map[string]any{
+				"type": "string",
+			},
+		},
+		"columns": map[string]any{
+			"type":        "array",
+			"description": "Every output column of the query, in the order they are returned.",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name": map[string]any{
+						"type":        "string",
+						"description": "The output column name exactly as the query aliases it.",
+					},
+					"description": map[string]any{
+						"type":        "string",
+						"description": "What the column holds and where it comes from, in plain English.",
+					},
+				},
+				"required":             []string{"name", "description"},
+				"additionalProperties": false,
+			},
+		},
+	},
+	"required":             []string{"summary", "tables", "filters", "columns"},
+	"additionalProperties": false,
+}
+
+const explainDynamicQuerySystemPrompt = `You explain TrinoDB SQL queries to non-technical staff of an internet service provider who run them as reports.
+
+- Write plain English. Do not use SQL terms such as join, CTE, coalesce or partition.
+- Describe filters the way a person would check them, for example "Only recharges that succeeded" or "Only customers at the selected POP".
+- Parameters written as ~{{name}}~ are chosen by the person running the report. Refer to them by their label, for example "the selected period".
+- Describe every output column, using the exact name the query gives it.
+- Only describe what the SQL does. Do not guess at intent the SQL does not show.`
+
+const explainDynamicQueryPrompt = `The query was requested as:
+
+%s
+
+Its SQL is:
+
+~~~sql
+%s
+~~~
+
+Its parameters are:
+
+~~~json
+%s
+~~~`
+
+func explainSystemPrompt() string {
+	return strings.ReplaceAll(explainDynamicQuerySystemPrompt, "~", "`")
+}
+
+// explainPrompt asks the model to explain the current SQL of dynamicQuery.
+func explainPrompt(dynamicQuery postgres.DynamicQuery) string {
+	parameters := dynamicQuery.Parameters
+
+	if parameters == nil {
+		parameters = system.DynamicQueryParameters{}
+	}
+
+	encodedParameters, err := json.MarshalIndent(parameters, "", "  ")
+
+	if err != nil {
+		encodedParameters = []byte("[]")
+	}
+
+	return fmt.Sprintf(strings.ReplaceAll(explainDynamicQueryPrompt, "~", "`"), dynamicQuery.Prompt, dynamicQuery.Query.String, encodedParameters)
+}
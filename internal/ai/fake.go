@@ -0,0 +1,137 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/goccy/go-json"
+)
+
+// FakeScript is one canned generation for the fake provider. It is played
+// back for any prompt that contains Prompt; an empty Prompt matches every
+// prompt. Explanation is returned when the generated SQL is explained.
+type FakeScript struct {
+	Prompt      string                          `json:"prompt"`
+	ToolCalls   []FakeToolCall                  `json:"tool_calls"`
+	Output      GenerateDynamicQueryOutput      `json:"output"`
+	Error       string                          `json:"error"`
+	Explanation *system.DynamicQueryExplanation `json:"explanation"`
+}
+
+type FakeToolCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+	Output    string `json:"output"`
+	Error     string `json:"error"`
+}
+
+var DefaultFakeScripts = []FakeScript{
+	{
+		ToolCalls: []FakeToolCall{
+			{
+				Name:      "list-catalogs",
+				Arguments: "{}",
+				Output:    "zing\nradius",
+			},
+		},
+		Output: GenerateDynamicQueryOutput{
+			SqlQuery:       `SELECT 1 AS "Value"`,
+			ThoughtProcess: "This is a scripted response from the fake AI provider.",
+			Parameters:     nil,
+		},
+		Explanation: &system.DynamicQueryExplanation{
+			Summary: "This is a scripted explanation from the fake AI provider.",
+			Tables:  []string{},
+			Filters: []string{},
+			Columns: []system.DynamicQueryColumnDescription{
+				{
+					Name:        "Value",
+					Description: "The number one.",
+				},
+			},
+		},
+	},
+}
+
+// fake plays back scripted generations so that the rest of the pipeline can
+// be exercised without a model.
+type fake struct {
+	scripts []FakeScript
+}
+
+func NewFake(scripts []FakeScript) AI {
+	return &fake{
+		scripts: scripts,
+	}
+}
+
+// LoadFakeScripts reads a JSON array of scripts from path.
+func LoadFakeScripts(path string) ([]FakeScript, error) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var scripts []FakeScript
+
+	if err := json.Unmarshal(data, &scripts); err != nil {
+		return nil, fmt.Errorf("unable to parse the fake AI scripts in %s: %w", path, err)
+	}
+
+	return scripts, nil
+}
+
+func (ai *fake) GenerateDynamicQuery(ctx context.Context, dynamicQuery postgres.DynamicQuery, systemPrompt string, progress func(Progress)) (GenerateDynamicQueryOutput, error) {
+	for _, script := range ai.scripts {
+		if !strings.Contains(dynamicQuery.Prompt, script.Prompt) {
+			continue
+		}
+
+		progress(Progress{Type: "response.created"})
+
+		for _, scriptedToolCall := range script.ToolCalls {
+			if err := ctx.Err(); err != nil {
+				return GenerateDynamicQueryOutput{}, err
+			}
+
+			toolCall := ToolCall{
+				Name:      scriptedToolCall.Name,
+				Arguments: scriptedToolCall.Arguments,
+				Output:    scriptedToolCall.Output,
+				Error:     scriptedToolCall.Error,
+				StartedAt: time.Now(),
+			}
+
+			progress(Progress{Type: "response.output_item.done", ToolCall: &toolCall})
+		}
+
+		if script.Error != "" {
+			return GenerateDynamicQueryOutput{}, errors.New(script.Error)
+		}
+
+		progress(Progress{Type: "response.completed"})
+
+		return script.Output, nil
+	}
+
+	return GenerateDynamicQueryOutput{}, fmt.Errorf("no fake AI script matches the prompt %q", dynamicQuery.Prompt)
+}
+
+func (ai *fake) ExplainDynamicQuery(ctx context.Context, dynamicQuery postgres.DynamicQuery) (system.DynamicQueryExplanation, error) {
+	for _, script := range ai.scripts {
+		if !strings.Contains(dynamicQuery.Prompt, script.Prompt) || script.Explanation == nil {
+			continue
+		}
+
+		return *script.Explanation, nil
+	}
+
+	return system.DynamicQueryExplanation{}, fmt.Errorf("no fake AI script explains the prompt %q", dynamicQuery.Prompt)
+}
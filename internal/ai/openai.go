@@ -0,0 +1,230 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+	"github.com/goccy/go-json"
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
+	openaiResponses "github.com/openai/openai-go/v3/responses"
+)
+
+// openAI generates through the OpenAI Responses API, which calls the MCP
+// server itself.
+type openAI struct {
+	client openai.Client
+	config Config
+}
+
+func newOpenAI(config Config) *openAI {
+	return &openAI{
+		client: openai.NewClient(option.WithAPIKey(config.APIKey)),
+		config: config,
+	}
+}
+
+// GenerateDynamicQuery asks the model, instructed by systemPrompt, to write
+// the SQL for dynamicQuery, continuing from its previous response when there is one. Every streamed
+// event, and every finished tool call, is passed to progress as it arrives.
+func (ai *openAI) GenerateDynamicQuery(ctx context.Context, dynamicQuery postgres.DynamicQuery, systemPrompt string, progress func(Progress)) (GenerateDynamicQueryOutput, error) {
+	streamParams := openaiResponses.ResponseNewParams{
+		Model:        ai.config.Model,
+		Instructions: openai.String(systemPrompt),
+		Input: openaiResponses.ResponseNewParamsInputUnion{
+			OfString: openai.String(dynamicQuery.Prompt),
+		},
+		Text: openaiResponses.ResponseTextConfigParam{
+			Format: openaiResponses.ResponseFormatTextConfigUnionParam{
+				OfJSONSchema: &openaiResponses.ResponseFormatTextJSONSchemaConfigParam{
+					Name:        "create_dynamic_query_output",
+					Schema:      GenerateDynamicQueryOutputSchema,
+					Strict:      openai.Bool(true),
+					Description: openai.String("The output for create dynamic query"),
+				},
+			},
+		},
+		Tools: []openaiResponses.ToolUnionParam{
+			{
+				OfMcp: &openaiResponses.ToolMcpParam{
+					ServerLabel:       "zingfibre_mcp",
+					ServerDescription: openai.String("The ZingFibre MCP server that allows AI to interact with parts of the ZingFibre Reports Portal system."),
+					ServerURL:         openai.String(ai.config.MCPURL),
+					Headers: map[string]string{
+						"Authorization": fmt.Sprintf("Bearer %s", ai.config.MCPToken),
+					},
+					AllowedTools: openaiResponses.ToolMcpAllowedToolsUnionParam{
+						OfMcpAllowedTools: GenerationTools,
+					},
+					RequireApproval: openaiResponses.ToolMcpRequireApprovalUnionParam{
+						OfMcpToolApprovalFilter: &openaiResponses.ToolMcpRequireApprovalMcpToolApprovalFilterParam{
+							Never: openaiResponses.ToolMcpRequireApprovalMcpToolApprovalFilterNeverParam{
+								ToolNames: GenerationTools,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if ai.config.Temperature != nil {
+		streamParams.Temperature = openai.Float(*ai.config.Temperature)
+	}
+
+	if ai.config.MaxOutputTokens > 0 {
+		streamParams.MaxOutputTokens = openai.Int(ai.config.MaxOutputTokens)
+	}
+
+	if ai.config.MaxToolCalls > 0 {
+		streamParams.MaxToolCalls = openai.Int(int64(ai.config.MaxToolCalls))
+	}
+
+	if dynamicQuery.ResponseID.Valid {
+		streamParams.PreviousResponseID = openai.String(dynamicQuery.ResponseID.String)
+	}
+
+	stream := ai.client.Responses.NewStreaming(ctx, streamParams)
+
+	defer stream.Close()
+
+	toolCallsStartedAt := map[string]time.Time{}
+	toolCalls := 0
+
+	for stream.Next() {
+		current := stream.Current()
+
+		switch current.Type {
+		case "response.output_item.added":
+			if item := current.AsResponseOutputItemAdded().Item; item.Type == "mcp_call" {
+				toolCallsStartedAt[item.ID] = time.Now()
+				toolCalls++
+
+				// The API is asked to stop at the limit too, this catches a
+				// model that does not.
+				if ai.config.MaxToolCalls > 0 && toolCalls > ai.config.MaxToolCalls {
+					return GenerateDynamicQueryOutput{}, fmt.Errorf("the model made more than %d tool calls without answering", ai.config.MaxToolCalls)
+				}
+			}
+
+			progress(Progress{Type: current.Type})
+		case "response.output_item.done":
+			progress(Progress{Type: current.Type, ToolCall: toolCall(current.AsResponseOutputItemDone().Item, toolCallsStartedAt)})
+		case "response.completed":
+			response := current.AsResponseCompleted().Response
+
+			output := GenerateDynamicQueryOutput{}
+
+			if err := json.Unmarshal([]byte(response.OutputText()), &output); err != nil {
+				output.ResponseID = response.ID
+				output.Usage = usage(response.Usage)
+
+				return output, fmt.Errorf("the model returned output that is not valid JSON: %w", err)
+			}
+
+			output.ResponseID = response.ID
+			output.Usage = usage(response.Usage)
+
+			return output, nil
+		case "response.failed":
+			response := current.AsResponseFailed().Response
+
+			return GenerateDynamicQueryOutput{ResponseID: response.ID, Usage: usage(response.Usage)}, fmt.Errorf("the model failed to respond: %s", response.Error.Message)
+		default:
+			progress(Progress{Type: current.Type})
+		}
+	}
+
+	if err := stream.Err(); err != nil {
+		return GenerateDynamicQueryOutput{}, err
+	}
+
+	return GenerateDynamicQueryOutput{}, errors.New("the model stream ended without a completed response")
+}
+
+// ExplainDynamicQuery asks the model to describe the SQL of dynamicQuery in
+// plain English. It does not need any tools.
+func (ai *openAI) ExplainDynamicQuery(ctx context.Context, dynamicQuery postgres.DynamicQuery) (system.DynamicQueryExplanation, error) {
+	params := openaiResponses.ResponseNewParams{
+		Model:        ai.config.Model,
+		Instructions: openai.String(explainSystemPrompt()),
+		Input: openaiResponses.ResponseNewParamsInputUnion{
+			OfString: openai.String(explainPrompt(dynamicQuery)),
+		},
+		Text: openaiResponses.ResponseTextConfigParam{
+			Format: openaiResponses.ResponseFormatTextConfigUnionParam{
+				OfJSONSchema: &openaiResponses.ResponseFormatTextJSONSchemaConfigParam{
+					Name:        "explain_dynamic_query_output",
+					Schema:      ExplainDynamicQueryOutputSchema,
+					Strict:      openai.Bool(true),
+					Description: openai.String("The output for explain dynamic query"),
+				},
+			},
+		},
+	}
+
+	if ai.config.Temperature != nil {
+		params.Temperature = openai.Float(*ai.config.Temperature)
+	}
+
+	if ai.config.MaxOutputTokens > 0 {
+		params.MaxOutputTokens = openai.Int(ai.config.MaxOutputTokens)
+	}
+
+	response, err := ai.client.Responses.New(ctx, params)
+
+	if err != nil {
+		return system.DynamicQueryExplanation{}, err
+	}
+
+	if response.Error.Message != "" {
+		return system.DynamicQueryExplanation{}, fmt.Errorf("the model failed to respond: %s", response.Error.Message)
+	}
+
+	explanation := system.DynamicQueryExplanation{}
+
+	if err := json.Unmarshal([]byte(response.OutputText()), &explanation); err != nil {
+		return explanation, fmt.Errorf("the model returned output that is not valid JSON: %w", err)
+	}
+
+	return explanation, nil
+}
+
+// toolCall returns the finished MCP call in item, or nil when item is not an
+// MCP call.
+func toolCall(item openaiResponses.ResponseOutputItemUnion, startedAt map[string]time.Time) *ToolCall {
+	if item.Type != "mcp_call" {
+		return nil
+	}
+
+	mcpCall := item.AsMcpCall()
+
+	started, ok := startedAt[item.ID]
+
+	if !ok {
+		started = time.Now()
+	}
+
+	delete(startedAt, item.ID)
+
+	return &ToolCall{
+		Name:      mcpCall.Name,
+		Arguments: mcpCall.Arguments,
+		Output:    mcpCall.Output,
+		Error:     mcpCall.Error,
+		StartedAt: started,
+		Latency:   time.Since(started),
+	}
+}
+
+func usage(responseUsage openaiResponses.ResponseUsage) Usage {
+	return Usage{
+		InputTokens:  responseUsage.InputTokens,
+		OutputTokens: responseUsage.OutputTokens,
+		TotalTokens:  responseUsage.TotalTokens,
+	}
+}
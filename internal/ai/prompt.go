@@ -0,0 +1,154 @@
+package ai
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+)
+
+// DefaultPromptTemplate is the system prompt used for generation when no
+// prompt template is selected for a dynamic query and none is marked as the
+// default. Prompt templates are Go text/template templates delimited by [[ ]],
+// so that the {{name}} placeholders they describe are left alone.
+//
+//go:embed prompts/dynamic_query.tmpl
+var DefaultPromptTemplate string
+
+const DefaultDateFormat = "dd/mm/yyyy"
+
+// GlossaryTerm explains a term staff use for the business, such as POP, so
+// that the model can map a request onto the right tables and columns.
+type GlossaryTerm struct {
+	Term       string `json:"term"`
+	Definition string `json:"definition"`
+}
+
+// PromptData is what prompt templates are executed with.
+type PromptData struct {
+	// Catalogs the model may read. Empty when every catalog is allowed.
+	Catalogs   []string
+	DateFormat string
+	// Glossary is loaded with LoadGlossary when a prompt is rendered, so that
+	// edits take effect without a restart. The glossary_terms migration seeds
+	// the terms.
+	Glossary []GlossaryTerm
+}
+
+// LoadGlossary returns the glossary terms kept in Postgres, ordered by term.
+func LoadGlossary(ctx context.Context, queries *postgres.Queries) ([]GlossaryTerm, error) {
+	terms, err := queries.GetGlossaryTerms(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	glossary := []GlossaryTerm{}
+
+	for _, term := range terms {
+		glossary = append(glossary, GlossaryTerm{
+			Term:       term.Term,
+			Definition: term.Definition,
+		})
+	}
+
+	return glossary, nil
+}
+
+var datePartPattern = regexp.MustCompile(`yyyy|mm|dd`)
+
+// datePartPositions are where each part of a date starts in a date or
+// timestamp cast to VARCHAR, and how long it is.
+var datePartPositions = map[string][2]int{
+	"yyyy": {1, 4},
+	"mm":   {6, 2},
+	"dd":   {9, 2},
+}
+
+// ValidateDateFormat checks that format is made of yyyy, mm and dd, each
+// exactly once, separated by anything other than letters.
+func ValidateDateFormat(format string) error {
+	parts := datePartPattern.FindAllString(format, -1)
+
+	if len(parts) != 3 || strings.Count(format, "yyyy") != 1 || strings.Count(format, "mm") != 1 || strings.Count(format, "dd") != 1 {
+		return fmt.Errorf("the date format %q must contain yyyy, mm and dd once each", format)
+	}
+
+	for _, separator := range datePartPattern.Split(format, -1) {
+		if strings.ContainsFunc(separator, func(r rune) bool { return unicode.IsLetter(r) || r == '\'' }) {
+			return fmt.Errorf("the date format %q may only separate its parts with punctuation or spaces", format)
+		}
+	}
+
+	return nil
+}
+
+// formatDateExpression returns the SQL that formats column as format without
+// calling date_format(), which fails on timestamps with sub-second precision.
+func formatDateExpression(format string, column string) string {
+	parts := datePartPattern.FindAllString(format, -1)
+	separators := datePartPattern.Split(format, -1)
+	lines := []string{}
+
+	for index, part := range parts {
+		position := datePartPositions[part]
+		line := fmt.Sprintf("substr(CAST(%s AS VARCHAR), %d, %d)", column, position[0], position[1])
+
+		if index == 0 && separators[0] != "" {
+			line = fmt.Sprintf("'%s' || %s", separators[0], line)
+		}
+
+		if separator := separators[index+1]; separator != "" && index < len(parts)-1 {
+			line += fmt.Sprintf(" || '%s' ||", separator)
+		} else if separator != "" {
+			line += fmt.Sprintf(" || '%s'", separator)
+		} else if index < len(parts)-1 {
+			line += " ||"
+		}
+
+		lines = append(lines, line)
+	}
+
+	return fmt.Sprintf("CASE\n  WHEN %s IS NULL THEN NULL\n  ELSE %s\nEND", column, strings.Join(lines, "\n       "))
+}
+
+// ParsePromptTemplate parses body as a prompt template for data. Besides the
+// standard functions, templates can call formatDate with a column to get the
+// SQL that formats it in the configured date format, and indent to indent
+// every line but the first of a multi-line value.
+func ParsePromptTemplate(body string, data PromptData) (*template.Template, error) {
+	return template.New("prompt").
+		Delims("[[", "]]").
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			"formatDate": func(column string) string {
+				return formatDateExpression(data.DateFormat, column)
+			},
+			"indent": func(spaces int, text string) string {
+				return strings.ReplaceAll(text, "\n", "\n"+strings.Repeat(" ", spaces))
+			},
+		}).
+		Parse(body)
+}
+
+// RenderPrompt executes the prompt template in body with data.
+func RenderPrompt(body string, data PromptData) (string, error) {
+	prompt, err := ParsePromptTemplate(body, data)
+
+	if err != nil {
+		return "", err
+	}
+
+	builder := strings.Builder{}
+
+	if err := prompt.Execute(&builder, data); err != nil {
+		return "", err
+	}
+
+	return builder.String(), nil
+}
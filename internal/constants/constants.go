@@ -13,6 +13,8 @@ const (
 	ConflictErrorDetails       string = "The request could not be completed due to a conflict with the current state of the resource."
 	ForbiddenError             string = "Forbidden"
 	ForbiddenErrorDetails      string = "You do not have permission to access this resource. Please check your permissions or contact support."
+	GatewayTimeoutError        string = "Gateway Timeout"
+	GatewayTimeoutErrorDetails string = "The query took too long to run. Please narrow it down with filters or parameters and try again."
 	Created                    string = "Created"
 	CreatedDetails             string = "The resource has been successfully created."
 	Success                    string = "Success"
@@ -0,0 +1,77 @@
+package diff
+
+import "strings"
+
+type Operation string
+
+const (
+	Equal  Operation = "equal"
+	Insert Operation = "insert"
+	Delete Operation = "delete"
+)
+
+type Line struct {
+	Operation Operation `json:"operation"`
+	Text      string    `json:"text"`
+	OldLine   int       `json:"oldLine,omitempty"`
+	NewLine   int       `json:"newLine,omitempty"`
+}
+
+// Lines returns a line by line diff that turns before into after, based on the
+// longest common subsequence of their lines.
+func Lines(before string, after string) []Line {
+	a := splitLines(before)
+	b := splitLines(after)
+
+	lcs := make([][]int, len(a)+1)
+
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := []Line{}
+	i, j := 0, 0
+
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, Line{Operation: Equal, Text: a[i], OldLine: i + 1, NewLine: j + 1})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, Line{Operation: Delete, Text: a[i], OldLine: i + 1})
+			i++
+		default:
+			lines = append(lines, Line{Operation: Insert, Text: b[j], NewLine: j + 1})
+			j++
+		}
+	}
+
+	for ; i < len(a); i++ {
+		lines = append(lines, Line{Operation: Delete, Text: a[i], OldLine: i + 1})
+	}
+
+	for ; j < len(b); j++ {
+		lines = append(lines, Line{Operation: Insert, Text: b[j], NewLine: j + 1})
+	}
+
+	return lines
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
@@ -0,0 +1,153 @@
+package mail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/zingfibre-core/common"
+)
+
+var ErrNotConfigured = errors.New("SMTP is not configured")
+
+// Config points the mailer at an SMTP server. Username and Password may be
+// left empty for servers that do not authenticate, such as a local SMTP sink
+// used in development.
+type Config struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// ConfigFromEnv reads the SMTP_* environment variables.
+func ConfigFromEnv() Config {
+	return Config{
+		Host:     common.EnvString("SMTP_HOST", ""),
+		Port:     common.EnvString("SMTP_PORT", "587"),
+		Username: common.EnvString("SMTP_USERNAME", ""),
+		Password: common.EnvString("SMTP_PASSWORD", ""),
+		From:     common.EnvString("SMTP_FROM", "reports@zingfibre.co.za"),
+	}
+}
+
+type Attachment struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+type Mailer interface {
+	Send(to []string, subject string, body string, attachments ...Attachment) error
+}
+
+type mailer struct {
+	config Config
+}
+
+func New(config Config) Mailer {
+	return &mailer{
+		config: config,
+	}
+}
+
+// Send sends a plain text message with attachments to every address in to.
+// It returns ErrNotConfigured when no SMTP host is set.
+func (m *mailer) Send(to []string, subject string, body string, attachments ...Attachment) error {
+	if m.config.Host == "" {
+		return ErrNotConfigured
+	}
+
+	message, err := m.message(to, subject, body, attachments)
+
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+
+	if m.config.Username != "" {
+		auth = smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+	}
+
+	return smtp.SendMail(net.JoinHostPort(m.config.Host, m.config.Port), auth, m.config.From, to, message)
+}
+
+func (m *mailer) message(to []string, subject string, body string, attachments []Attachment) ([]byte, error) {
+	var message bytes.Buffer
+
+	writer := multipart.NewWriter(&message)
+
+	// Subjects are built from user supplied names, so line breaks are removed
+	// to keep them from adding headers.
+	subject = strings.NewReplacer("\r", " ", "\n", " ").Replace(subject)
+
+	fmt.Fprintf(&message, "From: %s\r\n", m.config.From)
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&message, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", writer.Boundary())
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	bodyWriter := quotedprintable.NewWriter(part)
+
+	if _, err := bodyWriter.Write([]byte(body)); err != nil {
+		return nil, err
+	}
+
+	if err := bodyWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range attachments {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(attachment.ContentType, map[string]string{"name": attachment.Name})},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Name})},
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+
+		// Encoded lines may not be longer than 76 characters.
+		for len(encoded) > 76 {
+			if _, err := fmt.Fprintf(part, "%s\r\n", encoded[:76]); err != nil {
+				return nil, err
+			}
+
+			encoded = encoded[76:]
+		}
+
+		if _, err := fmt.Fprintf(part, "%s\r\n", encoded); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return message.Bytes(), nil
+}
@@ -0,0 +1,203 @@
+package masking
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
+	"github.com/connor-davis/zingfibre-core/internal/postgres"
+)
+
+// Rules are the masking rules for one role, keyed by normalised column name.
+type Rules map[string]postgres.MaskingRule
+
+// New indexes rules by column name.
+func New(rules []postgres.MaskingRule) Rules {
+	indexed := Rules{}
+
+	for _, rule := range rules {
+		indexed[Normalize(rule.ColumnName)] = rule
+	}
+
+	return indexed
+}
+
+// Normalize reduces a column name to lower case letters and digits so that
+// PhoneNumber, Phone Number and phone_number name the same column.
+func Normalize(column string) string {
+	var normalized strings.Builder
+
+	for _, character := range strings.ToLower(column) {
+		if unicode.IsLetter(character) || unicode.IsDigit(character) {
+			normalized.WriteRune(character)
+		}
+	}
+
+	return normalized.String()
+}
+
+// Rule returns the rule for the first of columns that has one.
+func (r Rules) Rule(columns ...string) (postgres.MaskingRule, bool) {
+	for _, column := range columns {
+		if rule, ok := r[Normalize(column)]; ok {
+			return rule, true
+		}
+	}
+
+	return postgres.MaskingRule{}, false
+}
+
+// Mask masks value if column has a rule.
+func (r Rules) Mask(column string, value string) string {
+	rule, ok := r.Rule(column)
+
+	if !ok {
+		return value
+	}
+
+	return Apply(rule, value)
+}
+
+// MaskRecord masks a CSV record in place using its header for column names.
+func (r Rules) MaskRecord(header []string, record []string) {
+	if len(r) == 0 {
+		return
+	}
+
+	for index := range min(len(header), len(record)) {
+		record[index] = r.Mask(header[index], record[index])
+	}
+}
+
+// MaskRows masks the string fields of every struct in rows, which must be a
+// slice of structs or of interfaces holding structs. Fields are matched by
+// name.
+func (r Rules) MaskRows(rows any) {
+	if len(r) == 0 {
+		return
+	}
+
+	slice := reflect.ValueOf(rows)
+
+	if slice.Kind() != reflect.Slice {
+		return
+	}
+
+	for index := range slice.Len() {
+		element := slice.Index(index)
+
+		if element.Kind() == reflect.Interface {
+			if element.IsNil() || element.Elem().Kind() != reflect.Struct {
+				continue
+			}
+
+			masked := reflect.New(element.Elem().Type()).Elem()
+			masked.Set(element.Elem())
+
+			r.maskStruct(masked)
+			element.Set(masked)
+
+			continue
+		}
+
+		if element.Kind() == reflect.Struct {
+			r.maskStruct(element)
+		}
+	}
+}
+
+func (r Rules) maskStruct(value reflect.Value) {
+	for index := range value.NumField() {
+		field := value.Field(index)
+
+		if field.Kind() != reflect.String || !field.CanSet() {
+			continue
+		}
+
+		field.SetString(r.Mask(value.Type().Field(index).Name, field.String()))
+	}
+}
+
+// AnyColumn is the lineage key for the columns every result column may have
+// been derived from, used when the columns of a query cannot be traced.
+const AnyColumn = "*"
+
+// ResultRule returns the rule for a dynamic query result column, either its
+// own or that of a column it was derived from according to lineage, which
+// maps lower cased column names to the columns they read.
+func (r Rules) ResultRule(column string, lineage map[string][]string) (postgres.MaskingRule, bool) {
+	columns := append([]string{column}, lineage[strings.ToLower(column)]...)
+
+	return r.Rule(append(columns, lineage[AnyColumn]...)...)
+}
+
+// MaskResult masks the columns of a dynamic query result in place using
+// ResultRule.
+func (r Rules) MaskResult(result *system.DynamicQueryResult, lineage map[string][]string) {
+	if len(r) == 0 {
+		return
+	}
+
+	for index, column := range result.Columns {
+		rule, ok := r.ResultRule(column.Name, lineage)
+
+		if !ok {
+			continue
+		}
+
+		// Masked values are always text, whatever the column held before.
+		result.Columns[index].Type = "varchar"
+
+		for _, row := range result.Data {
+			if value, ok := row[column.Name]; ok && value != nil {
+				row[column.Name] = Apply(rule, fmt.Sprint(value))
+			}
+		}
+	}
+}
+
+// Apply masks value with rule.
+func Apply(rule postgres.MaskingRule, value string) string {
+	if value == "" {
+		return value
+	}
+
+	characters := []rune(value)
+	visible := max(int(rule.VisibleCharacters), 0)
+
+	switch rule.Strategy {
+	case postgres.MaskingStrategyShowFirst:
+		if visible >= len(characters) {
+			return strings.Repeat("*", len(characters))
+		}
+
+		return string(characters[:visible]) + strings.Repeat("*", len(characters)-visible)
+	case postgres.MaskingStrategyShowLast:
+		if visible >= len(characters) {
+			return strings.Repeat("*", len(characters))
+		}
+
+		return strings.Repeat("*", len(characters)-visible) + string(characters[len(characters)-visible:])
+	case postgres.MaskingStrategyEmail:
+		local, domain, ok := strings.Cut(value, "@")
+
+		if !ok {
+			return "****"
+		}
+
+		localCharacters := []rune(local)
+		visible = min(visible, len(localCharacters))
+
+		return string(localCharacters[:visible]) + strings.Repeat("*", len(localCharacters)-visible) + "@" + domain
+	case postgres.MaskingStrategyHash:
+		hash := sha256.Sum256([]byte(value))
+
+		return hex.EncodeToString(hash[:])[:12]
+	default:
+		return "****"
+	}
+}
@@ -2,6 +2,46 @@ package schemas
 
 import "github.com/getkin/kin-openapi/openapi3"
 
+var DynamicQueryParameterSchema = openapi3.NewObjectSchema().WithProperties(map[string]*openapi3.Schema{
+	"name":  openapi3.NewStringSchema(),
+	"label": openapi3.NewStringSchema(),
+	"type": openapi3.NewStringSchema().WithEnum(
+		"date",
+		"date_range",
+		"pop",
+		"string",
+		"number",
+		"enum",
+	),
+	"required": openapi3.NewBoolSchema(),
+	"default":  openapi3.NewStringSchema(),
+	"options":  openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()),
+}).WithRequired([]string{
+	"name",
+	"label",
+	"type",
+	"required",
+	"default",
+	"options",
+}).NewRef()
+
+var DynamicQueryParametersSchema = openapi3.NewArraySchema().WithItems(DynamicQueryParameterSchema.Value).NewRef()
+
+var DynamicQueryExplanationSchema = openapi3.NewObjectSchema().WithProperties(map[string]*openapi3.Schema{
+	"summary": openapi3.NewStringSchema(),
+	"tables":  openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()),
+	"filters": openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()),
+	"columns": openapi3.NewArraySchema().WithItems(openapi3.NewObjectSchema().WithProperties(map[string]*openapi3.Schema{
+		"name":        openapi3.NewStringSchema(),
+		"description": openapi3.NewStringSchema(),
+	})),
+}).WithRequired([]string{
+	"summary",
+	"tables",
+	"filters",
+	"columns",
+}).NewRef()
+
 var DynamicQuerySchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
 	"ID":    openapi3.NewUUIDSchema(),
 	"Name":  openapi3.NewStringSchema(),
@@ -11,7 +51,23 @@ var DynamicQuerySchema = openapi3.NewSchema().WithProperties(map[string]*openapi
 		"in_progress",
 		"error",
 	).WithDefault("in_progress"),
-	"Prompt": openapi3.NewStringSchema(),
+	"Prompt":     openapi3.NewStringSchema(),
+	"Parameters": DynamicQueryParametersSchema.Value,
+	"CreatedBy":  openapi3.NewUUIDSchema(),
+	"Visibility": openapi3.NewStringSchema().WithEnum(
+		"private",
+		"shared",
+		"public",
+	),
+	"FolderID":             openapi3.NewUUIDSchema().WithNullable(),
+	"Tags":                 openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()),
+	"Explanation":          DynamicQueryExplanationSchema.Value,
+	"ExplanationError":     openapi3.NewStringSchema().WithNullable(),
+	"ExplanationStartedAt": openapi3.NewDateTimeSchema().WithNullable(),
+	"PromptTemplateID":     openapi3.NewUUIDSchema().WithNullable(),
+	"LatestVersion":        openapi3.NewInt32Schema(),
+	"Favourite":            openapi3.NewBoolSchema(),
+	"LastRunAt":            openapi3.NewDateTimeSchema().WithNullable(),
 }).NewRef()
 
 var DynamicQueryArraySchema = openapi3.NewArraySchema().WithItems(DynamicQuerySchema.Value).NewRef()
@@ -19,16 +75,27 @@ var DynamicQueryArraySchema = openapi3.NewArraySchema().WithItems(DynamicQuerySc
 var CreateDynamicQuerySchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
 	"Name":   openapi3.NewStringSchema(),
 	"Prompt": openapi3.NewStringSchema(),
+	"Visibility": openapi3.NewStringSchema().WithEnum(
+		"private",
+		"shared",
+		"public",
+	).WithDefault("private"),
+	"FolderID":         openapi3.NewUUIDSchema(),
+	"Tags":             openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()),
+	"PromptTemplateID": openapi3.NewUUIDSchema(),
 }).NewRef()
 
 var UpdateDynamicQuerySchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
-	"Name":   openapi3.NewStringSchema(),
-	"Prompt": openapi3.NewStringSchema(),
-	"Status": openapi3.NewStringSchema().WithEnum(
-		"complete",
-		"in_progress",
-		"error",
-	).WithDefault("in_progress"),
+	"Name":       openapi3.NewStringSchema(),
+	"Prompt":     openapi3.NewStringSchema(),
+	"Parameters": DynamicQueryParametersSchema.Value,
+	"Visibility": openapi3.NewStringSchema().WithEnum(
+		"private",
+		"shared",
+		"public",
+	),
+	"FolderID": openapi3.NewStringSchema(),
+	"Tags":     openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()),
 }).NewRef()
 
 var DynamicQueryResultsSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
@@ -37,6 +104,7 @@ var DynamicQueryResultsSchema = openapi3.NewSchema().WithProperties(map[string]*
 			WithAdditionalProperties(openapi3.NewAnyOfSchema(
 				openapi3.NewStringSchema(),
 				openapi3.NewIntegerSchema(),
+				openapi3.NewFloat64Schema(),
 				openapi3.NewBoolSchema(),
 				openapi3.NewDateTimeSchema(),
 			)),
@@ -56,3 +124,275 @@ var DynamicQueryResultsSchema = openapi3.NewSchema().WithProperties(map[string]*
 	"data",
 	"columns",
 }).NewRef()
+
+var DynamicQueryVersionSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"ID":             openapi3.NewUUIDSchema(),
+	"DynamicQueryID": openapi3.NewUUIDSchema(),
+	"Version":        openapi3.NewInt32Schema(),
+	"Query":          openapi3.NewStringSchema(),
+	"Prompt":         openapi3.NewStringSchema(),
+	"ResponseID":     openapi3.NewStringSchema(),
+	"Parameters":     DynamicQueryParametersSchema.Value,
+	"CreatedBy":      openapi3.NewUUIDSchema(),
+	"CreatedByEmail": openapi3.NewStringSchema(),
+	"CreatedAt":      openapi3.NewDateTimeSchema(),
+}).NewRef()
+
+var DynamicQueryVersionDiffLineSchema = openapi3.NewObjectSchema().WithProperties(map[string]*openapi3.Schema{
+	"operation": openapi3.NewStringSchema().WithEnum(
+		"equal",
+		"insert",
+		"delete",
+	),
+	"text":    openapi3.NewStringSchema(),
+	"oldLine": openapi3.NewIntegerSchema(),
+	"newLine": openapi3.NewIntegerSchema(),
+}).WithRequired([]string{
+	"operation",
+	"text",
+}).NewRef()
+
+var DynamicQueryVersionDiffSchema = openapi3.NewObjectSchema().WithProperties(map[string]*openapi3.Schema{
+	"from":   openapi3.NewInt32Schema(),
+	"to":     openapi3.NewInt32Schema(),
+	"query":  openapi3.NewArraySchema().WithItems(DynamicQueryVersionDiffLineSchema.Value),
+	"prompt": openapi3.NewArraySchema().WithItems(DynamicQueryVersionDiffLineSchema.Value),
+}).WithRequired([]string{
+	"from",
+	"to",
+	"query",
+	"prompt",
+}).NewRef()
+
+var DynamicQueryJobSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"ID":             openapi3.NewUUIDSchema(),
+	"DynamicQueryID": openapi3.NewUUIDSchema(),
+	"Status": openapi3.NewStringSchema().WithEnum(
+		"queued",
+		"running",
+		"complete",
+		"error",
+		"cancelled",
+	),
+	"Error":                 openapi3.NewStringSchema(),
+	"CreatedBy":             openapi3.NewUUIDSchema(),
+	"MessageID":             openapi3.NewUUIDSchema().WithNullable(),
+	"PromptTemplateID":      openapi3.NewUUIDSchema().WithNullable(),
+	"PromptTemplateVersion": openapi3.NewInt32Schema().WithNullable(),
+	"ThoughtProcess":        openapi3.NewStringSchema(),
+	"SqlQuery":              openapi3.NewStringSchema(),
+	"Parameters":            DynamicQueryParametersSchema.Value,
+	"ResponseID":            openapi3.NewStringSchema(),
+	"InputTokens":           openapi3.NewInt64Schema(),
+	"OutputTokens":          openapi3.NewInt64Schema(),
+	"TotalTokens":           openapi3.NewInt64Schema(),
+	"StartedAt":             openapi3.NewDateTimeSchema(),
+	"HeartbeatAt":           openapi3.NewDateTimeSchema(),
+	"FinishedAt":            openapi3.NewDateTimeSchema(),
+	"CreatedAt":             openapi3.NewDateTimeSchema(),
+	"UpdatedAt":             openapi3.NewDateTimeSchema(),
+}).NewRef()
+
+var DynamicQueryToolCallSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"ID":        openapi3.NewUUIDSchema(),
+	"JobID":     openapi3.NewUUIDSchema(),
+	"Name":      openapi3.NewStringSchema(),
+	"Arguments": openapi3.NewStringSchema(),
+	"Output":    openapi3.NewStringSchema(),
+	"Error":     openapi3.NewStringSchema(),
+	"LatencyMs": openapi3.NewInt64Schema(),
+	"StartedAt": openapi3.NewDateTimeSchema(),
+	"CreatedAt": openapi3.NewDateTimeSchema(),
+}).NewRef()
+
+var DynamicQueryRunSchema = openapi3.NewAllOfSchema(
+	DynamicQueryJobSchema.Value,
+	openapi3.NewSchema().WithProperty("ToolCalls", openapi3.NewArraySchema().WithItems(DynamicQueryToolCallSchema.Value)),
+).NewRef()
+
+var DynamicQueryScheduleSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"ID":             openapi3.NewUUIDSchema(),
+	"DynamicQueryID": openapi3.NewUUIDSchema(),
+	"Cron":           openapi3.NewStringSchema(),
+	"Timezone":       openapi3.NewStringSchema(),
+	"Parameters":     openapi3.NewObjectSchema().WithAdditionalProperties(openapi3.NewStringSchema()),
+	"Delivery":       openapi3.NewStringSchema().WithEnum("email", "directory"),
+	"Recipients":     openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()),
+	"Enabled":        openapi3.NewBoolSchema(),
+	"NextRunAt":      openapi3.NewDateTimeSchema(),
+	"LastRunAt":      openapi3.NewDateTimeSchema(),
+	"CreatedBy":      openapi3.NewUUIDSchema(),
+	"CreatedAt":      openapi3.NewDateTimeSchema(),
+	"UpdatedAt":      openapi3.NewDateTimeSchema(),
+}).NewRef()
+
+var UpdateDynamicQueryScheduleSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"cron":       openapi3.NewStringSchema().WithMinLength(1),
+	"timezone":   openapi3.NewStringSchema(),
+	"parameters": openapi3.NewObjectSchema().WithAdditionalProperties(openapi3.NewStringSchema()),
+	"delivery":   openapi3.NewStringSchema().WithEnum("email", "directory"),
+	"recipients": openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()),
+	"enabled":    openapi3.NewBoolSchema(),
+}).NewRef()
+
+var DynamicQueryScheduleRunSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"ID":             openapi3.NewUUIDSchema(),
+	"ScheduleID":     openapi3.NewUUIDSchema(),
+	"DynamicQueryID": openapi3.NewUUIDSchema(),
+	"Status": openapi3.NewStringSchema().WithEnum(
+		"running",
+		"complete",
+		"error",
+	),
+	"RowCount":    openapi3.NewInt64Schema(),
+	"DeliveredTo": openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()),
+	"Error":       openapi3.NewStringSchema(),
+	"StartedAt":   openapi3.NewDateTimeSchema(),
+	"FinishedAt":  openapi3.NewDateTimeSchema(),
+}).NewRef()
+
+var TrinoQuerySchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"QueryID": openapi3.NewStringSchema(),
+	"State":   openapi3.NewStringSchema(),
+}).NewRef()
+
+var TrinoExecutionSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"ID":           openapi3.NewUUIDSchema(),
+	"Description":  openapi3.NewStringSchema(),
+	"User":         openapi3.NewStringSchema(),
+	"Role":         openapi3.NewStringSchema(),
+	"Query":        openapi3.NewStringSchema(),
+	"StartedAt":    openapi3.NewDateTimeSchema(),
+	"Deadline":     openapi3.NewDateTimeSchema().WithNullable(),
+	"TrinoQueries": openapi3.NewArraySchema().WithItems(TrinoQuerySchema.Value),
+}).NewRef()
+
+var TrinoExecutionArraySchema = openapi3.NewArraySchema().WithItems(TrinoExecutionSchema.Value).NewRef()
+
+var DynamicQueryShareSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"ID":             openapi3.NewUUIDSchema(),
+	"DynamicQueryID": openapi3.NewUUIDSchema(),
+	"UserID":         openapi3.NewUUIDSchema().WithNullable(),
+	"Role": openapi3.NewStringSchema().WithEnum(
+		"admin",
+		"staff",
+		"user",
+	).WithNullable(),
+	"Permission": openapi3.NewStringSchema().WithEnum(
+		"view",
+		"run",
+		"edit",
+	),
+	"CreatedBy": openapi3.NewUUIDSchema(),
+	"CreatedAt": openapi3.NewDateTimeSchema(),
+}).NewRef()
+
+var DynamicQueryShareArraySchema = openapi3.NewArraySchema().WithItems(DynamicQueryShareSchema.Value).NewRef()
+
+var CreateDynamicQueryShareSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"user_id": openapi3.NewUUIDSchema(),
+	"role": openapi3.NewStringSchema().WithEnum(
+		"admin",
+		"staff",
+		"user",
+	),
+	"permission": openapi3.NewStringSchema().WithEnum(
+		"view",
+		"run",
+		"edit",
+	),
+}).NewRef()
+
+var DynamicQueryFolderSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"ID":        openapi3.NewUUIDSchema(),
+	"ParentID":  openapi3.NewUUIDSchema().WithNullable(),
+	"Name":      openapi3.NewStringSchema(),
+	"CreatedBy": openapi3.NewUUIDSchema().WithNullable(),
+	"CreatedAt": openapi3.NewDateTimeSchema(),
+	"UpdatedAt": openapi3.NewDateTimeSchema(),
+}).NewRef()
+
+var DynamicQueryFolderArraySchema = openapi3.NewArraySchema().WithItems(DynamicQueryFolderSchema.Value).NewRef()
+
+var CreateDynamicQueryFolderSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"name":      openapi3.NewStringSchema(),
+	"parent_id": openapi3.NewUUIDSchema().WithNullable(),
+}).WithRequired([]string{
+	"name",
+}).NewRef()
+
+var RecentDynamicQuerySchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"ID":        openapi3.NewUUIDSchema(),
+	"Name":      openapi3.NewStringSchema(),
+	"Status":    openapi3.NewStringSchema(),
+	"FolderID":  openapi3.NewUUIDSchema().WithNullable(),
+	"Tags":      openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()),
+	"RunCount":  openapi3.NewInt64Schema(),
+	"LastRunAt": openapi3.NewDateTimeSchema(),
+}).NewRef()
+
+var RecentDynamicQueryArraySchema = openapi3.NewArraySchema().WithItems(RecentDynamicQuerySchema.Value).NewRef()
+
+var DynamicQueryMessageSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"ID":             openapi3.NewUUIDSchema(),
+	"DynamicQueryID": openapi3.NewUUIDSchema(),
+	"ParentID":       openapi3.NewUUIDSchema().WithNullable(),
+	"Content":        openapi3.NewStringSchema(),
+	"Status": openapi3.NewStringSchema().WithEnum(
+		"generating",
+		"proposed",
+		"accepted",
+		"discarded",
+		"error",
+	),
+	"SqlQuery":       openapi3.NewStringSchema().WithNullable(),
+	"Parameters":     DynamicQueryParametersSchema.Value,
+	"ThoughtProcess": openapi3.NewStringSchema().WithNullable(),
+	"ResponseID":     openapi3.NewStringSchema().WithNullable(),
+	"Error":          openapi3.NewStringSchema().WithNullable(),
+	"CreatedBy":      openapi3.NewUUIDSchema().WithNullable(),
+	"JobID":          openapi3.NewUUIDSchema(),
+	"CreatedAt":      openapi3.NewDateTimeSchema(),
+	"UpdatedAt":      openapi3.NewDateTimeSchema(),
+}).NewRef()
+
+var DynamicQueryMessageArraySchema = openapi3.NewArraySchema().WithItems(DynamicQueryMessageSchema.Value).NewRef()
+
+var CreateDynamicQueryMessageSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"content": openapi3.NewStringSchema().WithMinLength(1),
+}).WithRequired([]string{
+	"content",
+}).NewRef()
+
+var DynamicQueryDryRunSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"Columns": openapi3.NewArraySchema().WithItems(
+		openapi3.NewObjectSchema().WithProperties(map[string]*openapi3.Schema{
+			"name":  openapi3.NewStringSchema(),
+			"type":  openapi3.NewStringSchema(),
+			"label": openapi3.NewStringSchema(),
+		}),
+	),
+	"Plan": openapi3.NewStringSchema(),
+	"Estimate": openapi3.NewObjectSchema().WithProperties(map[string]*openapi3.Schema{
+		"Rows":    openapi3.NewStringSchema(),
+		"Size":    openapi3.NewStringSchema(),
+		"CPU":     openapi3.NewStringSchema(),
+		"Memory":  openapi3.NewStringSchema(),
+		"Network": openapi3.NewStringSchema(),
+	}).WithNullable(),
+	"AnalyzedPlan": openapi3.NewStringSchema(),
+	"Preview":      DynamicQueryResultsSchema.Value,
+}).NewRef()
+
+var DynamicQuerySqlSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"sql_query":  openapi3.NewStringSchema().WithMinLength(1),
+	"parameters": DynamicQueryParametersSchema.Value,
+	"analyze":    openapi3.NewBoolSchema(),
+}).WithRequired([]string{
+	"sql_query",
+}).NewRef()
+
+var DynamicQuerySqlUpdateSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"DynamicQuery": DynamicQuerySchema.Value,
+	"DryRun":       DynamicQueryDryRunSchema.Value,
+}).NewRef()
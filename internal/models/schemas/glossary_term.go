@@ -0,0 +1,8 @@
+package schemas
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+var GlossaryTermSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"term":       openapi3.NewStringSchema().WithMinLength(1),
+	"definition": openapi3.NewStringSchema().WithMinLength(1),
+}).NewRef()
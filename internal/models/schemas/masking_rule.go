@@ -0,0 +1,10 @@
+package schemas
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+var MaskingRuleSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"role":               openapi3.NewStringSchema().WithEnum("admin", "staff", "user"),
+	"column_name":        openapi3.NewStringSchema().WithMinLength(1),
+	"strategy":           openapi3.NewStringSchema().WithEnum("redact", "show_first", "show_last", "email", "hash"),
+	"visible_characters": openapi3.NewInt32Schema().WithMin(0),
+}).NewRef()
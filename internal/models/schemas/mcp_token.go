@@ -0,0 +1,34 @@
+package schemas
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+var McpTokenSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"ID":             openapi3.NewUUIDSchema(),
+	"Name":           openapi3.NewStringSchema(),
+	"TokenPrefix":    openapi3.NewStringSchema(),
+	"Tools":          openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()),
+	"Catalogs":       openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()),
+	"Role":           openapi3.NewStringSchema().WithEnum("admin", "staff", "user"),
+	"Pops":           openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()),
+	"CreatedBy":      openapi3.NewUUIDSchema(),
+	"CreatedByEmail": openapi3.NewStringSchema(),
+	"LastUsedAt":     openapi3.NewDateTimeSchema(),
+	"RevokedAt":      openapi3.NewDateTimeSchema(),
+	"ExpiresAt":      openapi3.NewDateTimeSchema(),
+	"CreatedAt":      openapi3.NewDateTimeSchema(),
+}).NewRef()
+
+var CreatedMcpTokenSchema = openapi3.NewAllOfSchema(
+	McpTokenSchema.Value,
+	openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+		"Token": openapi3.NewStringSchema(),
+	}),
+).NewRef()
+
+var CreateMcpTokenSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"name":     openapi3.NewStringSchema().WithMinLength(1),
+	"tools":    openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()),
+	"catalogs": openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()),
+	"role":     openapi3.NewStringSchema().WithEnum("admin", "staff", "user").WithDefault("user"),
+	"pops":     openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()),
+}).NewRef()
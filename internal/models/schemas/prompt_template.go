@@ -0,0 +1,47 @@
+package schemas
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+var PromptTemplateSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"ID":          openapi3.NewUUIDSchema(),
+	"Name":        openapi3.NewStringSchema(),
+	"Description": openapi3.NewStringSchema(),
+	"Body":        openapi3.NewStringSchema(),
+	"Version":     openapi3.NewInt32Schema(),
+	"IsDefault":   openapi3.NewBoolSchema(),
+	"CreatedBy":   openapi3.NewUUIDSchema().WithNullable(),
+	"CreatedAt":   openapi3.NewDateTimeSchema(),
+	"UpdatedAt":   openapi3.NewDateTimeSchema(),
+}).NewRef()
+
+var PromptTemplateArraySchema = openapi3.NewArraySchema().WithItems(PromptTemplateSchema.Value).NewRef()
+
+var CreatePromptTemplateSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"name":        openapi3.NewStringSchema().WithMinLength(1),
+	"description": openapi3.NewStringSchema(),
+	"body":        openapi3.NewStringSchema().WithMinLength(1),
+}).WithRequired([]string{
+	"name",
+	"body",
+}).NewRef()
+
+var PromptTemplateVersionSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"ID":               openapi3.NewUUIDSchema(),
+	"PromptTemplateID": openapi3.NewUUIDSchema(),
+	"Version":          openapi3.NewInt32Schema(),
+	"Body":             openapi3.NewStringSchema(),
+	"CreatedBy":        openapi3.NewUUIDSchema().WithNullable(),
+	"CreatedAt":        openapi3.NewDateTimeSchema(),
+}).NewRef()
+
+var PromptTemplateVersionArraySchema = openapi3.NewArraySchema().WithItems(PromptTemplateVersionSchema.Value).NewRef()
+
+var PreviewPromptTemplateSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"body": openapi3.NewStringSchema().WithMinLength(1),
+}).WithRequired([]string{
+	"body",
+}).NewRef()
+
+var DynamicQueryPromptTemplateSchema = openapi3.NewSchema().WithProperties(map[string]*openapi3.Schema{
+	"prompt_template_id": openapi3.NewUUIDSchema().WithNullable(),
+}).NewRef()
@@ -1,10 +1,97 @@
 package system
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DynamicQueryResultColumn struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Label string `json:"label"`
+}
+
 type DynamicQueryResult struct {
-	Columns []struct {
-		Name  string `json:"name"`
-		Type  string `json:"type"`
-		Label string `json:"label"`
-	} `json:"columns"`
-	Data []map[string]any `json:"data"`
+	Columns []DynamicQueryResultColumn `json:"columns"`
+	Data    []map[string]any           `json:"data"`
+}
+
+type DynamicQueryParameterType string
+
+const (
+	DateParameter      DynamicQueryParameterType = "date"
+	DateRangeParameter DynamicQueryParameterType = "date_range"
+	POPParameter       DynamicQueryParameterType = "pop"
+	StringParameter    DynamicQueryParameterType = "string"
+	NumberParameter    DynamicQueryParameterType = "number"
+	EnumParameter      DynamicQueryParameterType = "enum"
+)
+
+type DynamicQueryParameter struct {
+	Name     string                    `json:"name"`
+	Label    string                    `json:"label"`
+	Type     DynamicQueryParameterType `json:"type"`
+	Required bool                      `json:"required"`
+	Default  string                    `json:"default"`
+	Options  []string                  `json:"options"`
+}
+
+type DynamicQueryParameters []DynamicQueryParameter
+
+// DynamicQueryDryRun is what Trino reports about a query without saving it:
+// its plan and cost estimate, the columns it returns and the first rows.
+type DynamicQueryDryRun struct {
+	Columns      []DynamicQueryResultColumn
+	Plan         string
+	Estimate     *DynamicQueryEstimate
+	AnalyzedPlan string
+	Preview      DynamicQueryResult
+}
+
+// DynamicQueryEstimate is the cost Trino estimates for the output of a query.
+// Values Trino cannot estimate are "?".
+type DynamicQueryEstimate struct {
+	Rows    string
+	Size    string
+	CPU     string
+	Memory  string
+	Network string
+}
+
+// DynamicQueryExplanation describes in plain English what the SQL of a
+// dynamic query does, for people who cannot read SQL.
+type DynamicQueryExplanation struct {
+	Summary string                          `json:"summary"`
+	Tables  []string                        `json:"tables"`
+	Filters []string                        `json:"filters"`
+	Columns []DynamicQueryColumnDescription `json:"columns"`
+}
+
+type DynamicQueryColumnDescription struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// DynamicQueryParameterValues holds the value given for each parameter of a
+// dynamic query by name, such as the values a schedule runs it with.
+type DynamicQueryParameterValues map[string]string
+
+// TrinoExecution is a Trino execution running in the API, such as the
+// results of a dynamic query being fetched or an MCP test query.
+type TrinoExecution struct {
+	ID           uuid.UUID
+	Description  string
+	User         string
+	Role         string
+	Query        string
+	StartedAt    time.Time
+	Deadline     *time.Time
+	TrinoQueries []TrinoQuery
+}
+
+// TrinoQuery is a query that Trino is running for a TrinoExecution.
+type TrinoQuery struct {
+	QueryID string
+	State   string
 }
@@ -0,0 +1,64 @@
+package mysql
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// schemas are the CREATE TABLE statements sqlc generates the zing and radius
+// packages from, one table per file. Each directory is named after the Trino
+// catalog that reads the database.
+//
+//go:embed zing/schemas/*.sql radius/schemas/*.sql
+var schemas embed.FS
+
+var catalogs = []string{"zing", "radius"}
+
+var tableNamePattern = regexp.MustCompile("(?i)CREATE\\s+TABLE\\s+(?:IF\\s+NOT\\s+EXISTS\\s+)?`?(\\w+)`?")
+
+// TableSchema is the DDL of a table in the Zing or Radius database.
+type TableSchema struct {
+	Catalog string
+	Table   string
+	DDL     string
+}
+
+// TableSchemas returns the DDL of every Zing and Radius table, by catalog and
+// then in the order of their files.
+func TableSchemas() ([]TableSchema, error) {
+	tables := []TableSchema{}
+
+	for _, catalog := range catalogs {
+		directory := path.Join(catalog, "schemas")
+		entries, err := schemas.ReadDir(directory)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			data, err := schemas.ReadFile(path.Join(directory, entry.Name()))
+
+			if err != nil {
+				return nil, err
+			}
+
+			match := tableNamePattern.FindSubmatch(data)
+
+			if match == nil {
+				return nil, fmt.Errorf("%s/%s does not create a table", directory, entry.Name())
+			}
+
+			tables = append(tables, TableSchema{
+				Catalog: catalog,
+				Table:   string(match[1]),
+				DDL:     strings.TrimSpace(string(data)),
+			})
+		}
+	}
+
+	return tables, nil
+}
@@ -0,0 +1,237 @@
+-- name: GetReportsCustomersForPop :many
+SELECT
+    CONCAT(t1.FirstName, ' ', t1.Surname) AS full_name,
+    t1.Email AS email,
+    t2.RadiusUsername AS radius_username,
+    t1.PhoneNumber AS phone_number
+FROM Customers t1
+LEFT JOIN Addresses t2 ON t1.AddressId = t2.Id
+WHERE
+    (sqlc.arg('pop') = '' OR TRIM(LOWER(t2.POP)) = TRIM(LOWER(sqlc.arg('pop'))))
+    AND (
+        t1.FirstName LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+        OR t1.Surname LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+        OR t1.Email LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+        OR t1.PhoneNumber LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+        OR t2.RadiusUsername LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+    )
+ORDER BY
+    CONCAT(t1.FirstName, ' ', t1.Surname) ASC,
+    t1.Email ASC
+LIMIT ?
+OFFSET ?;
+
+-- name: GetReportsTotalCustomersForPop :one
+SELECT
+    COUNT(*) AS total_customers
+FROM Customers t1
+LEFT JOIN Addresses t2 ON t1.AddressId = t2.Id
+WHERE
+    (sqlc.arg('pop') = '' OR TRIM(LOWER(t2.POP)) = TRIM(LOWER(sqlc.arg('pop'))))
+    AND (
+        t1.FirstName LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+        OR t1.Surname LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+        OR t1.Email LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+        OR t1.PhoneNumber LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+        OR t2.RadiusUsername LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+    )
+ORDER BY
+    t1.RadiusUsername ASC,
+    t1.Email ASC
+LIMIT 1;
+
+-- name: GetReportsRechargesForPop :many
+SELECT
+    t1.DateCreated AS date_created,
+    t2.Email AS email,
+    CONCAT(t2.FirstName, ' ', t2.Surname) AS full_name,
+    CASE 
+        WHEN t3.Category IS NULL OR t3.Name IS NULL THEN 'Intro Package'
+        ELSE CONCAT(t3.Category, ' ', t3.Name, ' Access')
+    END AS item_name,
+    t1.PaymentAmount AS amount,
+    t1.Method AS method,
+    t1.RechargeSuccessful AS successful,
+    t4.ServiceId AS service_id,
+    t5.Name AS build_name,
+    t6.Name AS build_type
+FROM
+    Recharges t1
+LEFT JOIN Customers t2 ON t1.CustomerId = t2.Id
+LEFT JOIN Products t3 ON t1.ProductId = t3.Id
+LEFT JOIN Addresses t4 ON t2.AddressId = t4.Id
+LEFT JOIN Builds t5 ON t4.BuildId = t5.Id
+LEFT JOIN BuildTypes t6 ON t5.BuildTypeId = t6.Id
+WHERE
+    (sqlc.arg('pop') = '' OR TRIM(LOWER(t4.POP)) = TRIM(LOWER(sqlc.arg('pop'))))
+    AND CAST(t1.DateCreated AS DATE) >= sqlc.arg('start_date')
+    AND CAST(t1.DateCreated AS DATE) <= sqlc.arg('end_date')
+    AND (
+        t2.FirstName LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+        OR t2.Surname LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+        OR t2.Email LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+        OR t1.PaymentAmount LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+        OR t4.ServiceId LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+        OR t5.Name LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+        OR t6.Name LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+    )
+ORDER BY
+    t1.DateCreated DESC
+LIMIT ?
+OFFSET ?;
+
+-- name: GetReportsTotalRechargesForPop :one
+SELECT
+    COUNT(*) AS total_recharges
+FROM
+    Recharges t1
+LEFT JOIN Customers t2 ON t1.CustomerId = t2.Id
+LEFT JOIN Products t3 ON t1.ProductId = t3.Id
+LEFT JOIN Addresses t4 ON t2.AddressId = t4.Id
+LEFT JOIN Builds t5 ON t4.BuildId = t5.Id
+LEFT JOIN BuildTypes t6 ON t5.BuildTypeId = t6.Id
+WHERE
+    (sqlc.arg('pop') = '' OR TRIM(LOWER(t4.POP)) = TRIM(LOWER(sqlc.arg('pop'))))
+    AND CAST(t1.DateCreated AS DATE) >= sqlc.arg('start_date')
+    AND CAST(t1.DateCreated AS DATE) <= sqlc.arg('end_date')
+    AND (
+        t2.FirstName LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+        OR t2.Surname LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+        OR t1.PaymentAmount LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+        OR t4.ServiceId LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+        OR t5.Name LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+        OR t6.Name LIKE CONCAT('%', TRIM(LOWER(sqlc.arg('search'))), '%')
+    )
+ORDER BY
+    t1.DateCreated DESC
+LIMIT 1;
+
+-- name: GetReportsRechargeTypeCountsForPop :many
+SELECT
+	*
+FROM
+	(
+		SELECT
+			t3.Name AS recharge_name,
+			COUNT(*) AS recharge_count,
+			CASE
+				WHEN sqlc.arg('period') = 'weeks' THEN CONCAT(
+					FLOOR((DAY(t1.DateCreated) - 1) / 7) + 1,
+					'-',
+					MONTH(t1.DateCreated),
+					'-',
+					YEAR(t1.DateCreated)
+				)
+				WHEN sqlc.arg('period') = 'months' THEN CONCAT(MONTH(t1.DateCreated), '-', YEAR(t1.DateCreated))
+			END AS recharge_period,
+			MAX(t1.DateCreated) AS recharge_max_date
+		FROM
+			Recharges t1
+			LEFT JOIN Customers t2 ON t1.CustomerId = t2.Id
+			LEFT JOIN Products t3 ON t1.ProductId = t3.Id
+            LEFT JOIN Addresses t4 ON t2.AddressId = t4.Id
+		WHERE
+			(sqlc.arg('pop') = '' OR TRIM(LOWER(t4.POP)) = TRIM(LOWER(sqlc.arg('pop'))))
+			AND(
+                (
+                    sqlc.arg('period') = 'weeks'
+                    AND t1.DateCreated >= 
+                        CASE 
+                            WHEN sqlc.arg('count') = 1 THEN DATE_FORMAT(NOW(), '%Y-%m-01 00:00:00')
+                            ELSE DATE_FORMAT(DATE_SUB(DATE_FORMAT(NOW(), '%Y-%m-01'), INTERVAL (sqlc.arg('count') - 1) WEEK), '%Y-%m-01 00:00:00')
+                        END
+                )
+                OR(
+                    sqlc.arg('period') = 'months'
+                    AND t1.DateCreated >= 
+                        CASE 
+                            WHEN sqlc.arg('count') = 1 THEN DATE_FORMAT(NOW(), '%Y-%m-01 00:00:00')
+                            ELSE DATE_FORMAT(DATE_SUB(DATE_FORMAT(NOW(), '%Y-%m-01'), INTERVAL (sqlc.arg('count') - 1) MONTH), '%Y-%m-01 00:00:00')
+                        END
+                )
+			)
+		GROUP BY
+			recharge_name,
+			recharge_period
+	) AS sub
+ORDER BY
+	recharge_max_date ASC,
+    recharge_count DESC;
+
+-- name: GetAnalyticsMonthlyRevenueStatisticsForPop :one
+SELECT
+	CAST(
+        SUM(
+            CASE
+                WHEN 
+                    t1.DateCreated >= DATE_FORMAT(CURDATE(), '%Y-%m-01 00:00:00')
+                THEN t1.PaymentAmount
+                ELSE 0
+            END
+        ) AS SIGNED
+    ) AS revenue,
+	CAST(
+        (
+            SUM(
+                CASE
+                    WHEN 
+                        t1.DateCreated >= DATE_FORMAT(CURDATE(), '%Y-%m-01 00:00:00')
+                    THEN t1.PaymentAmount
+                    ELSE 0
+                END
+            )
+            -
+            SUM(
+                CASE
+                    WHEN 
+                        t1.DateCreated >= DATE_FORMAT(DATE_SUB(CURDATE(), INTERVAL 1 MONTH), '%Y-%m-01 00:00:00')
+                        AND t1.DateCreated <= DATE_FORMAT(DATE_SUB(CURDATE(), INTERVAL 1 MONTH), '%Y-%m-%d 23:59:59')
+                    THEN t1.PaymentAmount
+                    ELSE 0
+                END
+            )
+        ) AS SIGNED
+    ) AS revenue_growth_amount,
+	ROUND(
+        (
+            SUM(
+                CASE
+                    WHEN 
+                        t1.DateCreated >= DATE_FORMAT(CURDATE(), '%Y-%m-01 00:00:00')
+                    THEN PaymentAmount
+                    ELSE 0
+                END
+            )
+            /
+            NULLIF(SUM(
+                CASE
+                    WHEN 
+                        t1.DateCreated >= DATE_FORMAT(DATE_SUB(CURDATE(), INTERVAL 1 MONTH), '%Y-%m-01 00:00:00')
+                        AND t1.DateCreated <= DATE_FORMAT(DATE_SUB(CURDATE(), INTERVAL 1 MONTH), '%Y-%m-%d 23:59:59')
+                    THEN PaymentAmount
+                    ELSE 0
+                END
+            ), 0) - 1
+        ), 4
+    ) AS revenue_growth_percentage
+FROM Recharges t1
+LEFT JOIN Customers t2 ON t1.CustomerId = t2.Id
+LEFT JOIN Addresses t3 ON t2.AddressId = t3.Id
+WHERE
+    t1.RechargeSuccessful = 1
+    AND (sqlc.arg('pop') = '' OR TRIM(LOWER(t3.POP)) = TRIM(LOWER(sqlc.arg('pop'))));
+
+-- name: GetAnalyticsMonthlyUniquePurchasersForPop :one
+SELECT
+    CAST(
+        COUNT(DISTINCT t2.RadiusUsername) AS SIGNED
+    ) AS unique_purchasers
+FROM
+    Recharges t1
+LEFT JOIN Customers t2 ON t1.CustomerId = t2.Id
+LEFT JOIN Addresses t3 ON t2.AddressId = t3.Id
+WHERE
+    t1.RechargeSuccessful = 1
+    AND t1.PaymentAmount > 0
+    AND (sqlc.arg('pop') = '' OR TRIM(LOWER(t3.POP)) = TRIM(LOWER(sqlc.arg('pop'))))
+    AND t1.DateCreated >= DATE_FORMAT(NOW(), '%Y-%m-01 00:00:00');
@@ -8,10 +8,64 @@ package postgres
 import (
 	"context"
 
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const claimDynamicQueryExplanation = `-- name: ClaimDynamicQueryExplanation :one
+UPDATE dynamic_queries
+SET
+    explanation_started_at = NOW()
+WHERE
+    id = (
+        SELECT
+            id
+        FROM
+            dynamic_queries
+        WHERE
+            query IS NOT NULL
+            AND explanation IS NULL
+            AND explanation_error IS NULL
+            AND (
+                explanation_started_at IS NULL
+                OR explanation_started_at < NOW() - make_interval(secs => $1::FLOAT8)
+            )
+        ORDER BY
+            updated_at ASC
+        LIMIT
+            1
+        FOR UPDATE
+            SKIP LOCKED
+    ) RETURNING id, name, query, response_id, status, prompt, parameters, created_by, visibility, folder_id, tags, explanation, explanation_error, explanation_started_at, prompt_template_id, latest_version, created_at, updated_at
+`
+
+func (q *Queries) ClaimDynamicQueryExplanation(ctx context.Context, staleSeconds float64) (DynamicQuery, error) {
+	row := q.db.QueryRow(ctx, claimDynamicQueryExplanation, staleSeconds)
+	var i DynamicQuery
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Query,
+		&i.ResponseID,
+		&i.Status,
+		&i.Prompt,
+		&i.Parameters,
+		&i.CreatedBy,
+		&i.Visibility,
+		&i.FolderID,
+		&i.Tags,
+		&i.Explanation,
+		&i.ExplanationError,
+		&i.ExplanationStartedAt,
+		&i.PromptTemplateID,
+		&i.LatestVersion,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const createDynamicQuery = `-- name: CreateDynamicQuery :one
 INSERT INTO
     dynamic_queries (
@@ -19,18 +73,30 @@ INSERT INTO
         query,
         response_id,
         status,
-        prompt
+        prompt,
+        parameters,
+        created_by,
+        visibility,
+        folder_id,
+        tags,
+        prompt_template_id
     )
 VALUES
-    ($1, $2, $3, $4, $5) RETURNING id, name, query, response_id, status, prompt, created_at, updated_at
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id, name, query, response_id, status, prompt, parameters, created_by, visibility, folder_id, tags, explanation, explanation_error, explanation_started_at, prompt_template_id, latest_version, created_at, updated_at
 `
 
 type CreateDynamicQueryParams struct {
-	Name       string
-	Query      pgtype.Text
-	ResponseID pgtype.Text
-	Status     DynamicQueryStatus
-	Prompt     string
+	Name             string
+	Query            pgtype.Text
+	ResponseID       pgtype.Text
+	Status           DynamicQueryStatus
+	Prompt           string
+	Parameters       system.DynamicQueryParameters
+	CreatedBy        pgtype.UUID
+	Visibility       DynamicQueryVisibility
+	FolderID         pgtype.UUID
+	Tags             []string
+	PromptTemplateID pgtype.UUID
 }
 
 func (q *Queries) CreateDynamicQuery(ctx context.Context, arg CreateDynamicQueryParams) (DynamicQuery, error) {
@@ -40,6 +106,12 @@ func (q *Queries) CreateDynamicQuery(ctx context.Context, arg CreateDynamicQuery
 		arg.ResponseID,
 		arg.Status,
 		arg.Prompt,
+		arg.Parameters,
+		arg.CreatedBy,
+		arg.Visibility,
+		arg.FolderID,
+		arg.Tags,
+		arg.PromptTemplateID,
 	)
 	var i DynamicQuery
 	err := row.Scan(
@@ -49,6 +121,16 @@ func (q *Queries) CreateDynamicQuery(ctx context.Context, arg CreateDynamicQuery
 		&i.ResponseID,
 		&i.Status,
 		&i.Prompt,
+		&i.Parameters,
+		&i.CreatedBy,
+		&i.Visibility,
+		&i.FolderID,
+		&i.Tags,
+		&i.Explanation,
+		&i.ExplanationError,
+		&i.ExplanationStartedAt,
+		&i.PromptTemplateID,
+		&i.LatestVersion,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -58,7 +140,7 @@ func (q *Queries) CreateDynamicQuery(ctx context.Context, arg CreateDynamicQuery
 const deleteDynamicQuery = `-- name: DeleteDynamicQuery :one
 DELETE FROM dynamic_queries
 WHERE
-    id = $1 RETURNING id, name, query, response_id, status, prompt, created_at, updated_at
+    id = $1 RETURNING id, name, query, response_id, status, prompt, parameters, created_by, visibility, folder_id, tags, explanation, explanation_error, explanation_started_at, prompt_template_id, latest_version, created_at, updated_at
 `
 
 func (q *Queries) DeleteDynamicQuery(ctx context.Context, id uuid.UUID) (DynamicQuery, error) {
@@ -71,6 +153,16 @@ func (q *Queries) DeleteDynamicQuery(ctx context.Context, id uuid.UUID) (Dynamic
 		&i.ResponseID,
 		&i.Status,
 		&i.Prompt,
+		&i.Parameters,
+		&i.CreatedBy,
+		&i.Visibility,
+		&i.FolderID,
+		&i.Tags,
+		&i.Explanation,
+		&i.ExplanationError,
+		&i.ExplanationStartedAt,
+		&i.PromptTemplateID,
+		&i.LatestVersion,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -79,32 +171,146 @@ func (q *Queries) DeleteDynamicQuery(ctx context.Context, id uuid.UUID) (Dynamic
 
 const getDynamicQueries = `-- name: GetDynamicQueries :many
 SELECT
-    id, name, query, response_id, status, prompt, created_at, updated_at
+    dynamic_queries.id, dynamic_queries.name, dynamic_queries.query, dynamic_queries.response_id, dynamic_queries.status, dynamic_queries.prompt, dynamic_queries.parameters, dynamic_queries.created_by, dynamic_queries.visibility, dynamic_queries.folder_id, dynamic_queries.tags, dynamic_queries.explanation, dynamic_queries.explanation_error, dynamic_queries.explanation_started_at, dynamic_queries.prompt_template_id, dynamic_queries.latest_version, dynamic_queries.created_at, dynamic_queries.updated_at,
+    EXISTS (
+        SELECT
+            1
+        FROM
+            dynamic_query_favourites
+        WHERE
+            dynamic_query_favourites.dynamic_query_id = dynamic_queries.id
+            AND dynamic_query_favourites.user_id = $3::uuid
+    ) AS favourite,
+    dynamic_query_recent_runs.last_run_at
 FROM
     dynamic_queries
+    LEFT JOIN dynamic_query_recent_runs ON dynamic_query_recent_runs.dynamic_query_id = dynamic_queries.id
+    AND dynamic_query_recent_runs.user_id = $3::uuid
 WHERE
-    TRIM(LOWER(name)) ILIKE '%' || TRIM(LOWER($3::text)) || '%'
+    (
+        $4::text = ''
+        OR to_tsvector('english', dynamic_queries.name || ' ' || dynamic_queries.prompt) @@ websearch_to_tsquery('english', $4::text)
+        OR TRIM(LOWER(dynamic_queries.name)) ILIKE '%' || TRIM(LOWER($4::text)) || '%'
+    )
+    AND dynamic_query_visible(dynamic_queries.id, $3::uuid, $5::role_type)
+    AND (
+        $6::uuid IS NULL
+        OR dynamic_queries.folder_id IN (
+            WITH RECURSIVE
+                folders AS (
+                    SELECT
+                        dynamic_query_folders.id
+                    FROM
+                        dynamic_query_folders
+                    WHERE
+                        dynamic_query_folders.id = $6::uuid
+                    UNION ALL
+                    SELECT
+                        dynamic_query_folders.id
+                    FROM
+                        dynamic_query_folders
+                        JOIN folders ON dynamic_query_folders.parent_id = folders.id
+                )
+            SELECT
+                folders.id
+            FROM
+                folders
+        )
+    )
+    AND (
+        $7::text IS NULL
+        OR dynamic_queries.tags @> ARRAY[$7::text]
+    )
+    AND (
+        $8::dynamic_query_status IS NULL
+        OR dynamic_queries.status = $8::dynamic_query_status
+    )
+    AND (
+        $9::uuid IS NULL
+        OR dynamic_queries.created_by = $9::uuid
+    )
+    AND (
+        NOT $10::boolean
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                dynamic_query_favourites
+            WHERE
+                dynamic_query_favourites.dynamic_query_id = dynamic_queries.id
+                AND dynamic_query_favourites.user_id = $3::uuid
+        )
+    )
 ORDER BY
-    name ASC
+    CASE
+        WHEN $11::text = 'recent' THEN dynamic_query_recent_runs.last_run_at
+    END DESC NULLS LAST,
+    CASE
+        WHEN $11::text = 'updated' THEN dynamic_queries.updated_at
+    END DESC NULLS LAST,
+    dynamic_queries.name ASC
 LIMIT $1
 OFFSET $2
 `
 
 type GetDynamicQueriesParams struct {
-	Limit      int32
-	Offset     int32
-	SearchTerm string
+	Limit          int32
+	Offset         int32
+	UserID         uuid.UUID
+	SearchTerm     string
+	Role           RoleType
+	FolderID       pgtype.UUID
+	Tag            pgtype.Text
+	Status         NullDynamicQueryStatus
+	OwnerID        pgtype.UUID
+	FavouritesOnly bool
+	Sort           string
+}
+
+type GetDynamicQueriesRow struct {
+	ID                   uuid.UUID
+	Name                 string
+	Query                pgtype.Text
+	ResponseID           pgtype.Text
+	Status               DynamicQueryStatus
+	Prompt               string
+	Parameters           system.DynamicQueryParameters
+	CreatedBy            pgtype.UUID
+	Visibility           DynamicQueryVisibility
+	FolderID             pgtype.UUID
+	Tags                 []string
+	Explanation          *system.DynamicQueryExplanation
+	ExplanationError     pgtype.Text
+	ExplanationStartedAt pgtype.Timestamp
+	PromptTemplateID     pgtype.UUID
+	LatestVersion        int32
+	CreatedAt            pgtype.Timestamp
+	UpdatedAt            pgtype.Timestamp
+	Favourite            bool
+	LastRunAt            pgtype.Timestamp
 }
 
-func (q *Queries) GetDynamicQueries(ctx context.Context, arg GetDynamicQueriesParams) ([]DynamicQuery, error) {
-	rows, err := q.db.Query(ctx, getDynamicQueries, arg.Limit, arg.Offset, arg.SearchTerm)
+func (q *Queries) GetDynamicQueries(ctx context.Context, arg GetDynamicQueriesParams) ([]GetDynamicQueriesRow, error) {
+	rows, err := q.db.Query(ctx, getDynamicQueries,
+		arg.Limit,
+		arg.Offset,
+		arg.UserID,
+		arg.SearchTerm,
+		arg.Role,
+		arg.FolderID,
+		arg.Tag,
+		arg.Status,
+		arg.OwnerID,
+		arg.FavouritesOnly,
+		arg.Sort,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []DynamicQuery
+	var items []GetDynamicQueriesRow
 	for rows.Next() {
-		var i DynamicQuery
+		var i GetDynamicQueriesRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
@@ -112,8 +318,20 @@ func (q *Queries) GetDynamicQueries(ctx context.Context, arg GetDynamicQueriesPa
 			&i.ResponseID,
 			&i.Status,
 			&i.Prompt,
+			&i.Parameters,
+			&i.CreatedBy,
+			&i.Visibility,
+			&i.FolderID,
+			&i.Tags,
+			&i.Explanation,
+			&i.ExplanationError,
+			&i.ExplanationStartedAt,
+			&i.PromptTemplateID,
+			&i.LatestVersion,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Favourite,
+			&i.LastRunAt,
 		); err != nil {
 			return nil, err
 		}
@@ -127,7 +345,7 @@ func (q *Queries) GetDynamicQueries(ctx context.Context, arg GetDynamicQueriesPa
 
 const getDynamicQuery = `-- name: GetDynamicQuery :one
 SELECT
-    id, name, query, response_id, status, prompt, created_at, updated_at
+    id, name, query, response_id, status, prompt, parameters, created_by, visibility, folder_id, tags, explanation, explanation_error, explanation_started_at, prompt_template_id, latest_version, created_at, updated_at
 FROM
     dynamic_queries
 WHERE
@@ -146,28 +364,377 @@ func (q *Queries) GetDynamicQuery(ctx context.Context, id uuid.UUID) (DynamicQue
 		&i.ResponseID,
 		&i.Status,
 		&i.Prompt,
+		&i.Parameters,
+		&i.CreatedBy,
+		&i.Visibility,
+		&i.FolderID,
+		&i.Tags,
+		&i.Explanation,
+		&i.ExplanationError,
+		&i.ExplanationStartedAt,
+		&i.PromptTemplateID,
+		&i.LatestVersion,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
+const getDynamicQueryTags = `-- name: GetDynamicQueryTags :many
+SELECT DISTINCT
+    UNNEST(dynamic_queries.tags)::text AS tag
+FROM
+    dynamic_queries
+WHERE
+    dynamic_query_visible(dynamic_queries.id, $1::uuid, $2::role_type)
+ORDER BY
+    tag ASC
+`
+
+type GetDynamicQueryTagsParams struct {
+	UserID uuid.UUID
+	Role   RoleType
+}
+
+func (q *Queries) GetDynamicQueryTags(ctx context.Context, arg GetDynamicQueryTagsParams) ([]string, error) {
+	rows, err := q.db.Query(ctx, getDynamicQueryTags, arg.UserID, arg.Role)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, err
+		}
+		items = append(items, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRecentDynamicQueries = `-- name: GetRecentDynamicQueries :many
+SELECT
+    dynamic_queries.id, dynamic_queries.name, dynamic_queries.query, dynamic_queries.response_id, dynamic_queries.status, dynamic_queries.prompt, dynamic_queries.parameters, dynamic_queries.created_by, dynamic_queries.visibility, dynamic_queries.folder_id, dynamic_queries.tags, dynamic_queries.explanation, dynamic_queries.explanation_error, dynamic_queries.explanation_started_at, dynamic_queries.prompt_template_id, dynamic_queries.latest_version, dynamic_queries.created_at, dynamic_queries.updated_at,
+    dynamic_query_recent_runs.run_count,
+    dynamic_query_recent_runs.last_run_at
+FROM
+    dynamic_query_recent_runs
+    JOIN dynamic_queries ON dynamic_queries.id = dynamic_query_recent_runs.dynamic_query_id
+WHERE
+    dynamic_query_recent_runs.user_id = $2::uuid
+    AND dynamic_query_visible(dynamic_queries.id, $2::uuid, $3::role_type)
+ORDER BY
+    dynamic_query_recent_runs.last_run_at DESC
+LIMIT $1
+`
+
+type GetRecentDynamicQueriesParams struct {
+	Limit  int32
+	UserID uuid.UUID
+	Role   RoleType
+}
+
+type GetRecentDynamicQueriesRow struct {
+	ID                   uuid.UUID
+	Name                 string
+	Query                pgtype.Text
+	ResponseID           pgtype.Text
+	Status               DynamicQueryStatus
+	Prompt               string
+	Parameters           system.DynamicQueryParameters
+	CreatedBy            pgtype.UUID
+	Visibility           DynamicQueryVisibility
+	FolderID             pgtype.UUID
+	Tags                 []string
+	Explanation          *system.DynamicQueryExplanation
+	ExplanationError     pgtype.Text
+	ExplanationStartedAt pgtype.Timestamp
+	PromptTemplateID     pgtype.UUID
+	LatestVersion        int32
+	CreatedAt            pgtype.Timestamp
+	UpdatedAt            pgtype.Timestamp
+	RunCount             int64
+	LastRunAt            pgtype.Timestamp
+}
+
+func (q *Queries) GetRecentDynamicQueries(ctx context.Context, arg GetRecentDynamicQueriesParams) ([]GetRecentDynamicQueriesRow, error) {
+	rows, err := q.db.Query(ctx, getRecentDynamicQueries, arg.Limit, arg.UserID, arg.Role)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRecentDynamicQueriesRow
+	for rows.Next() {
+		var i GetRecentDynamicQueriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Query,
+			&i.ResponseID,
+			&i.Status,
+			&i.Prompt,
+			&i.Parameters,
+			&i.CreatedBy,
+			&i.Visibility,
+			&i.FolderID,
+			&i.Tags,
+			&i.Explanation,
+			&i.ExplanationError,
+			&i.ExplanationStartedAt,
+			&i.PromptTemplateID,
+			&i.LatestVersion,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RunCount,
+			&i.LastRunAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTotalDynamicQueries = `-- name: GetTotalDynamicQueries :one
 SELECT
     COUNT(*) AS total
 FROM
     dynamic_queries
+WHERE
+    (
+        $1::text = ''
+        OR to_tsvector('english', dynamic_queries.name || ' ' || dynamic_queries.prompt) @@ websearch_to_tsquery('english', $1::text)
+        OR TRIM(LOWER(dynamic_queries.name)) ILIKE '%' || TRIM(LOWER($1::text)) || '%'
+    )
+    AND dynamic_query_visible(dynamic_queries.id, $2::uuid, $3::role_type)
+    AND (
+        $4::uuid IS NULL
+        OR dynamic_queries.folder_id IN (
+            WITH RECURSIVE
+                folders AS (
+                    SELECT
+                        dynamic_query_folders.id
+                    FROM
+                        dynamic_query_folders
+                    WHERE
+                        dynamic_query_folders.id = $4::uuid
+                    UNION ALL
+                    SELECT
+                        dynamic_query_folders.id
+                    FROM
+                        dynamic_query_folders
+                        JOIN folders ON dynamic_query_folders.parent_id = folders.id
+                )
+            SELECT
+                folders.id
+            FROM
+                folders
+        )
+    )
+    AND (
+        $5::text IS NULL
+        OR dynamic_queries.tags @> ARRAY[$5::text]
+    )
+    AND (
+        $6::dynamic_query_status IS NULL
+        OR dynamic_queries.status = $6::dynamic_query_status
+    )
+    AND (
+        $7::uuid IS NULL
+        OR dynamic_queries.created_by = $7::uuid
+    )
+    AND (
+        NOT $8::boolean
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                dynamic_query_favourites
+            WHERE
+                dynamic_query_favourites.dynamic_query_id = dynamic_queries.id
+                AND dynamic_query_favourites.user_id = $2::uuid
+        )
+    )
 LIMIT
     1
 `
 
-func (q *Queries) GetTotalDynamicQueries(ctx context.Context) (int64, error) {
-	row := q.db.QueryRow(ctx, getTotalDynamicQueries)
+type GetTotalDynamicQueriesParams struct {
+	SearchTerm     string
+	UserID         uuid.UUID
+	Role           RoleType
+	FolderID       pgtype.UUID
+	Tag            pgtype.Text
+	Status         NullDynamicQueryStatus
+	OwnerID        pgtype.UUID
+	FavouritesOnly bool
+}
+
+func (q *Queries) GetTotalDynamicQueries(ctx context.Context, arg GetTotalDynamicQueriesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getTotalDynamicQueries,
+		arg.SearchTerm,
+		arg.UserID,
+		arg.Role,
+		arg.FolderID,
+		arg.Tag,
+		arg.Status,
+		arg.OwnerID,
+		arg.FavouritesOnly,
+	)
 	var total int64
 	err := row.Scan(&total)
 	return total, err
 }
 
+const resetDynamicQueryExplanation = `-- name: ResetDynamicQueryExplanation :one
+UPDATE dynamic_queries
+SET
+    explanation = NULL,
+    explanation_error = NULL,
+    explanation_started_at = NULL
+WHERE
+    id = $1 RETURNING id, name, query, response_id, status, prompt, parameters, created_by, visibility, folder_id, tags, explanation, explanation_error, explanation_started_at, prompt_template_id, latest_version, created_at, updated_at
+`
+
+func (q *Queries) ResetDynamicQueryExplanation(ctx context.Context, id uuid.UUID) (DynamicQuery, error) {
+	row := q.db.QueryRow(ctx, resetDynamicQueryExplanation, id)
+	var i DynamicQuery
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Query,
+		&i.ResponseID,
+		&i.Status,
+		&i.Prompt,
+		&i.Parameters,
+		&i.CreatedBy,
+		&i.Visibility,
+		&i.FolderID,
+		&i.Tags,
+		&i.Explanation,
+		&i.ExplanationError,
+		&i.ExplanationStartedAt,
+		&i.PromptTemplateID,
+		&i.LatestVersion,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const setDynamicQueryGenerated = `-- name: SetDynamicQueryGenerated :one
+UPDATE dynamic_queries
+SET
+    query = $1,
+    response_id = $2,
+    status = $3,
+    parameters = $4,
+    explanation = CASE
+        WHEN query IS DISTINCT FROM $1 THEN NULL
+        ELSE explanation
+    END,
+    explanation_error = CASE
+        WHEN query IS DISTINCT FROM $1 THEN NULL
+        ELSE explanation_error
+    END,
+    explanation_started_at = CASE
+        WHEN query IS DISTINCT FROM $1 THEN NULL
+        ELSE explanation_started_at
+    END,
+    updated_at = NOW()
+WHERE
+    id = $5 RETURNING id, name, query, response_id, status, prompt, parameters, created_by, visibility, folder_id, tags, explanation, explanation_error, explanation_started_at, prompt_template_id, latest_version, created_at, updated_at
+`
+
+type SetDynamicQueryGeneratedParams struct {
+	Query      pgtype.Text
+	ResponseID pgtype.Text
+	Status     DynamicQueryStatus
+	Parameters system.DynamicQueryParameters
+	ID         uuid.UUID
+}
+
+func (q *Queries) SetDynamicQueryGenerated(ctx context.Context, arg SetDynamicQueryGeneratedParams) (DynamicQuery, error) {
+	row := q.db.QueryRow(ctx, setDynamicQueryGenerated,
+		arg.Query,
+		arg.ResponseID,
+		arg.Status,
+		arg.Parameters,
+		arg.ID,
+	)
+	var i DynamicQuery
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Query,
+		&i.ResponseID,
+		&i.Status,
+		&i.Prompt,
+		&i.Parameters,
+		&i.CreatedBy,
+		&i.Visibility,
+		&i.FolderID,
+		&i.Tags,
+		&i.Explanation,
+		&i.ExplanationError,
+		&i.ExplanationStartedAt,
+		&i.PromptTemplateID,
+		&i.LatestVersion,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const setDynamicQueryStatus = `-- name: SetDynamicQueryStatus :one
+UPDATE dynamic_queries
+SET
+    status = $1,
+    response_id = COALESCE($2, response_id),
+    updated_at = NOW()
+WHERE
+    id = $3 RETURNING id, name, query, response_id, status, prompt, parameters, created_by, visibility, folder_id, tags, explanation, explanation_error, explanation_started_at, prompt_template_id, latest_version, created_at, updated_at
+`
+
+type SetDynamicQueryStatusParams struct {
+	Status     DynamicQueryStatus
+	ResponseID pgtype.Text
+	ID         uuid.UUID
+}
+
+func (q *Queries) SetDynamicQueryStatus(ctx context.Context, arg SetDynamicQueryStatusParams) (DynamicQuery, error) {
+	row := q.db.QueryRow(ctx, setDynamicQueryStatus, arg.Status, arg.ResponseID, arg.ID)
+	var i DynamicQuery
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Query,
+		&i.ResponseID,
+		&i.Status,
+		&i.Prompt,
+		&i.Parameters,
+		&i.CreatedBy,
+		&i.Visibility,
+		&i.FolderID,
+		&i.Tags,
+		&i.Explanation,
+		&i.ExplanationError,
+		&i.ExplanationStartedAt,
+		&i.PromptTemplateID,
+		&i.LatestVersion,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateDynamicQuery = `-- name: UpdateDynamicQuery :one
 UPDATE dynamic_queries
 SET
@@ -176,9 +743,22 @@ SET
     response_id = $3,
     status = $4,
     prompt = $5,
+    parameters = $6,
+    explanation = CASE
+        WHEN query IS DISTINCT FROM $2 THEN NULL
+        ELSE explanation
+    END,
+    explanation_error = CASE
+        WHEN query IS DISTINCT FROM $2 THEN NULL
+        ELSE explanation_error
+    END,
+    explanation_started_at = CASE
+        WHEN query IS DISTINCT FROM $2 THEN NULL
+        ELSE explanation_started_at
+    END,
     updated_at = NOW()
 WHERE
-    id = $6 RETURNING id, name, query, response_id, status, prompt, created_at, updated_at
+    id = $7 RETURNING id, name, query, response_id, status, prompt, parameters, created_by, visibility, folder_id, tags, explanation, explanation_error, explanation_started_at, prompt_template_id, latest_version, created_at, updated_at
 `
 
 type UpdateDynamicQueryParams struct {
@@ -187,6 +767,7 @@ type UpdateDynamicQueryParams struct {
 	ResponseID pgtype.Text
 	Status     DynamicQueryStatus
 	Prompt     string
+	Parameters system.DynamicQueryParameters
 	ID         uuid.UUID
 }
 
@@ -197,8 +778,158 @@ func (q *Queries) UpdateDynamicQuery(ctx context.Context, arg UpdateDynamicQuery
 		arg.ResponseID,
 		arg.Status,
 		arg.Prompt,
+		arg.Parameters,
+		arg.ID,
+	)
+	var i DynamicQuery
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Query,
+		&i.ResponseID,
+		&i.Status,
+		&i.Prompt,
+		&i.Parameters,
+		&i.CreatedBy,
+		&i.Visibility,
+		&i.FolderID,
+		&i.Tags,
+		&i.Explanation,
+		&i.ExplanationError,
+		&i.ExplanationStartedAt,
+		&i.PromptTemplateID,
+		&i.LatestVersion,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateDynamicQueryExplanation = `-- name: UpdateDynamicQueryExplanation :exec
+UPDATE dynamic_queries
+SET
+    explanation = $1,
+    explanation_error = $2
+WHERE
+    id = $3
+    AND query = $4
+`
+
+type UpdateDynamicQueryExplanationParams struct {
+	Explanation      *system.DynamicQueryExplanation
+	ExplanationError pgtype.Text
+	ID               uuid.UUID
+	Query            pgtype.Text
+}
+
+func (q *Queries) UpdateDynamicQueryExplanation(ctx context.Context, arg UpdateDynamicQueryExplanationParams) error {
+	_, err := q.db.Exec(ctx, updateDynamicQueryExplanation,
+		arg.Explanation,
+		arg.ExplanationError,
 		arg.ID,
+		arg.Query,
+	)
+	return err
+}
+
+const updateDynamicQueryFolderAndTags = `-- name: UpdateDynamicQueryFolderAndTags :one
+UPDATE dynamic_queries
+SET
+    folder_id = $1,
+    tags = $2,
+    updated_at = NOW()
+WHERE
+    id = $3 RETURNING id, name, query, response_id, status, prompt, parameters, created_by, visibility, folder_id, tags, explanation, explanation_error, explanation_started_at, prompt_template_id, latest_version, created_at, updated_at
+`
+
+type UpdateDynamicQueryFolderAndTagsParams struct {
+	FolderID pgtype.UUID
+	Tags     []string
+	ID       uuid.UUID
+}
+
+func (q *Queries) UpdateDynamicQueryFolderAndTags(ctx context.Context, arg UpdateDynamicQueryFolderAndTagsParams) (DynamicQuery, error) {
+	row := q.db.QueryRow(ctx, updateDynamicQueryFolderAndTags, arg.FolderID, arg.Tags, arg.ID)
+	var i DynamicQuery
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Query,
+		&i.ResponseID,
+		&i.Status,
+		&i.Prompt,
+		&i.Parameters,
+		&i.CreatedBy,
+		&i.Visibility,
+		&i.FolderID,
+		&i.Tags,
+		&i.Explanation,
+		&i.ExplanationError,
+		&i.ExplanationStartedAt,
+		&i.PromptTemplateID,
+		&i.LatestVersion,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateDynamicQueryPromptTemplate = `-- name: UpdateDynamicQueryPromptTemplate :one
+UPDATE dynamic_queries
+SET
+    prompt_template_id = $1,
+    updated_at = NOW()
+WHERE
+    id = $2 RETURNING id, name, query, response_id, status, prompt, parameters, created_by, visibility, folder_id, tags, explanation, explanation_error, explanation_started_at, prompt_template_id, latest_version, created_at, updated_at
+`
+
+type UpdateDynamicQueryPromptTemplateParams struct {
+	PromptTemplateID pgtype.UUID
+	ID               uuid.UUID
+}
+
+func (q *Queries) UpdateDynamicQueryPromptTemplate(ctx context.Context, arg UpdateDynamicQueryPromptTemplateParams) (DynamicQuery, error) {
+	row := q.db.QueryRow(ctx, updateDynamicQueryPromptTemplate, arg.PromptTemplateID, arg.ID)
+	var i DynamicQuery
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Query,
+		&i.ResponseID,
+		&i.Status,
+		&i.Prompt,
+		&i.Parameters,
+		&i.CreatedBy,
+		&i.Visibility,
+		&i.FolderID,
+		&i.Tags,
+		&i.Explanation,
+		&i.ExplanationError,
+		&i.ExplanationStartedAt,
+		&i.PromptTemplateID,
+		&i.LatestVersion,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
+	return i, err
+}
+
+const updateDynamicQueryVisibility = `-- name: UpdateDynamicQueryVisibility :one
+UPDATE dynamic_queries
+SET
+    visibility = $1,
+    updated_at = NOW()
+WHERE
+    id = $2 RETURNING id, name, query, response_id, status, prompt, parameters, created_by, visibility, folder_id, tags, explanation, explanation_error, explanation_started_at, prompt_template_id, latest_version, created_at, updated_at
+`
+
+type UpdateDynamicQueryVisibilityParams struct {
+	Visibility DynamicQueryVisibility
+	ID         uuid.UUID
+}
+
+func (q *Queries) UpdateDynamicQueryVisibility(ctx context.Context, arg UpdateDynamicQueryVisibilityParams) (DynamicQuery, error) {
+	row := q.db.QueryRow(ctx, updateDynamicQueryVisibility, arg.Visibility, arg.ID)
 	var i DynamicQuery
 	err := row.Scan(
 		&i.ID,
@@ -207,6 +938,16 @@ func (q *Queries) UpdateDynamicQuery(ctx context.Context, arg UpdateDynamicQuery
 		&i.ResponseID,
 		&i.Status,
 		&i.Prompt,
+		&i.Parameters,
+		&i.CreatedBy,
+		&i.Visibility,
+		&i.FolderID,
+		&i.Tags,
+		&i.Explanation,
+		&i.ExplanationError,
+		&i.ExplanationStartedAt,
+		&i.PromptTemplateID,
+		&i.LatestVersion,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -8,10 +8,229 @@ import (
 	"database/sql/driver"
 	"fmt"
 
+	"github.com/connor-davis/zingfibre-core/internal/models/system"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type DynamicQueryDelivery string
+
+const (
+	DynamicQueryDeliveryEmail     DynamicQueryDelivery = "email"
+	DynamicQueryDeliveryDirectory DynamicQueryDelivery = "directory"
+)
+
+func (e *DynamicQueryDelivery) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DynamicQueryDelivery(s)
+	case string:
+		*e = DynamicQueryDelivery(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DynamicQueryDelivery: %T", src)
+	}
+	return nil
+}
+
+type NullDynamicQueryDelivery struct {
+	DynamicQueryDelivery DynamicQueryDelivery
+	Valid                bool // Valid is true if DynamicQueryDelivery is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDynamicQueryDelivery) Scan(value interface{}) error {
+	if value == nil {
+		ns.DynamicQueryDelivery, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DynamicQueryDelivery.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDynamicQueryDelivery) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DynamicQueryDelivery), nil
+}
+
+type DynamicQueryJobStatus string
+
+const (
+	DynamicQueryJobStatusQueued    DynamicQueryJobStatus = "queued"
+	DynamicQueryJobStatusRunning   DynamicQueryJobStatus = "running"
+	DynamicQueryJobStatusComplete  DynamicQueryJobStatus = "complete"
+	DynamicQueryJobStatusError     DynamicQueryJobStatus = "error"
+	DynamicQueryJobStatusCancelled DynamicQueryJobStatus = "cancelled"
+)
+
+func (e *DynamicQueryJobStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DynamicQueryJobStatus(s)
+	case string:
+		*e = DynamicQueryJobStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DynamicQueryJobStatus: %T", src)
+	}
+	return nil
+}
+
+type NullDynamicQueryJobStatus struct {
+	DynamicQueryJobStatus DynamicQueryJobStatus
+	Valid                 bool // Valid is true if DynamicQueryJobStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDynamicQueryJobStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.DynamicQueryJobStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DynamicQueryJobStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDynamicQueryJobStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DynamicQueryJobStatus), nil
+}
+
+type DynamicQueryMessageStatus string
+
+const (
+	DynamicQueryMessageStatusGenerating DynamicQueryMessageStatus = "generating"
+	DynamicQueryMessageStatusProposed   DynamicQueryMessageStatus = "proposed"
+	DynamicQueryMessageStatusAccepted   DynamicQueryMessageStatus = "accepted"
+	DynamicQueryMessageStatusDiscarded  DynamicQueryMessageStatus = "discarded"
+	DynamicQueryMessageStatusError      DynamicQueryMessageStatus = "error"
+)
+
+func (e *DynamicQueryMessageStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DynamicQueryMessageStatus(s)
+	case string:
+		*e = DynamicQueryMessageStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DynamicQueryMessageStatus: %T", src)
+	}
+	return nil
+}
+
+type NullDynamicQueryMessageStatus struct {
+	DynamicQueryMessageStatus DynamicQueryMessageStatus
+	Valid                     bool // Valid is true if DynamicQueryMessageStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDynamicQueryMessageStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.DynamicQueryMessageStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DynamicQueryMessageStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDynamicQueryMessageStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DynamicQueryMessageStatus), nil
+}
+
+type DynamicQueryPermission string
+
+const (
+	DynamicQueryPermissionView DynamicQueryPermission = "view"
+	DynamicQueryPermissionRun  DynamicQueryPermission = "run"
+	DynamicQueryPermissionEdit DynamicQueryPermission = "edit"
+)
+
+func (e *DynamicQueryPermission) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DynamicQueryPermission(s)
+	case string:
+		*e = DynamicQueryPermission(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DynamicQueryPermission: %T", src)
+	}
+	return nil
+}
+
+type NullDynamicQueryPermission struct {
+	DynamicQueryPermission DynamicQueryPermission
+	Valid                  bool // Valid is true if DynamicQueryPermission is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDynamicQueryPermission) Scan(value interface{}) error {
+	if value == nil {
+		ns.DynamicQueryPermission, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DynamicQueryPermission.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDynamicQueryPermission) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DynamicQueryPermission), nil
+}
+
+type DynamicQueryScheduleRunStatus string
+
+const (
+	DynamicQueryScheduleRunStatusRunning  DynamicQueryScheduleRunStatus = "running"
+	DynamicQueryScheduleRunStatusComplete DynamicQueryScheduleRunStatus = "complete"
+	DynamicQueryScheduleRunStatusError    DynamicQueryScheduleRunStatus = "error"
+)
+
+func (e *DynamicQueryScheduleRunStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DynamicQueryScheduleRunStatus(s)
+	case string:
+		*e = DynamicQueryScheduleRunStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DynamicQueryScheduleRunStatus: %T", src)
+	}
+	return nil
+}
+
+type NullDynamicQueryScheduleRunStatus struct {
+	DynamicQueryScheduleRunStatus DynamicQueryScheduleRunStatus
+	Valid                         bool // Valid is true if DynamicQueryScheduleRunStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDynamicQueryScheduleRunStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.DynamicQueryScheduleRunStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DynamicQueryScheduleRunStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDynamicQueryScheduleRunStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DynamicQueryScheduleRunStatus), nil
+}
+
 type DynamicQueryStatus string
 
 const (
@@ -55,6 +274,94 @@ func (ns NullDynamicQueryStatus) Value() (driver.Value, error) {
 	return string(ns.DynamicQueryStatus), nil
 }
 
+type DynamicQueryVisibility string
+
+const (
+	DynamicQueryVisibilityPrivate DynamicQueryVisibility = "private"
+	DynamicQueryVisibilityShared  DynamicQueryVisibility = "shared"
+	DynamicQueryVisibilityPublic  DynamicQueryVisibility = "public"
+)
+
+func (e *DynamicQueryVisibility) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DynamicQueryVisibility(s)
+	case string:
+		*e = DynamicQueryVisibility(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DynamicQueryVisibility: %T", src)
+	}
+	return nil
+}
+
+type NullDynamicQueryVisibility struct {
+	DynamicQueryVisibility DynamicQueryVisibility
+	Valid                  bool // Valid is true if DynamicQueryVisibility is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDynamicQueryVisibility) Scan(value interface{}) error {
+	if value == nil {
+		ns.DynamicQueryVisibility, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DynamicQueryVisibility.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDynamicQueryVisibility) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DynamicQueryVisibility), nil
+}
+
+type MaskingStrategy string
+
+const (
+	MaskingStrategyRedact    MaskingStrategy = "redact"
+	MaskingStrategyShowFirst MaskingStrategy = "show_first"
+	MaskingStrategyShowLast  MaskingStrategy = "show_last"
+	MaskingStrategyEmail     MaskingStrategy = "email"
+	MaskingStrategyHash      MaskingStrategy = "hash"
+)
+
+func (e *MaskingStrategy) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = MaskingStrategy(s)
+	case string:
+		*e = MaskingStrategy(s)
+	default:
+		return fmt.Errorf("unsupported scan type for MaskingStrategy: %T", src)
+	}
+	return nil
+}
+
+type NullMaskingStrategy struct {
+	MaskingStrategy MaskingStrategy
+	Valid           bool // Valid is true if MaskingStrategy is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullMaskingStrategy) Scan(value interface{}) error {
+	if value == nil {
+		ns.MaskingStrategy, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.MaskingStrategy.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullMaskingStrategy) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.MaskingStrategy), nil
+}
+
 type RoleType string
 
 const (
@@ -99,16 +406,205 @@ func (ns NullRoleType) Value() (driver.Value, error) {
 }
 
 type DynamicQuery struct {
+	ID                   uuid.UUID
+	Name                 string
+	Query                pgtype.Text
+	ResponseID           pgtype.Text
+	Status               DynamicQueryStatus
+	Prompt               string
+	Parameters           system.DynamicQueryParameters
+	CreatedBy            pgtype.UUID
+	Visibility           DynamicQueryVisibility
+	FolderID             pgtype.UUID
+	Tags                 []string
+	Explanation          *system.DynamicQueryExplanation
+	ExplanationError     pgtype.Text
+	ExplanationStartedAt pgtype.Timestamp
+	PromptTemplateID     pgtype.UUID
+	LatestVersion        int32
+	CreatedAt            pgtype.Timestamp
+	UpdatedAt            pgtype.Timestamp
+}
+
+type DynamicQueryFavourite struct {
+	UserID         uuid.UUID
+	DynamicQueryID uuid.UUID
+	CreatedAt      pgtype.Timestamp
+}
+
+type DynamicQueryFolder struct {
+	ID        uuid.UUID
+	ParentID  pgtype.UUID
+	Name      string
+	CreatedBy pgtype.UUID
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+}
+
+type DynamicQueryJob struct {
+	ID                    uuid.UUID
+	DynamicQueryID        uuid.UUID
+	Status                DynamicQueryJobStatus
+	Error                 pgtype.Text
+	CreatedBy             pgtype.UUID
+	MessageID             pgtype.UUID
+	PromptTemplateID      pgtype.UUID
+	PromptTemplateVersion pgtype.Int4
+	ThoughtProcess        pgtype.Text
+	SqlQuery              pgtype.Text
+	Parameters            system.DynamicQueryParameters
+	ResponseID            pgtype.Text
+	InputTokens           int64
+	OutputTokens          int64
+	TotalTokens           int64
+	StartedAt             pgtype.Timestamp
+	HeartbeatAt           pgtype.Timestamp
+	FinishedAt            pgtype.Timestamp
+	CreatedAt             pgtype.Timestamp
+	UpdatedAt             pgtype.Timestamp
+}
+
+type DynamicQueryJobEvent struct {
+	ID        int64
+	JobID     uuid.UUID
+	Event     string
+	Data      string
+	CreatedAt pgtype.Timestamp
+}
+
+type DynamicQueryJobToolCall struct {
+	ID        uuid.UUID
+	JobID     uuid.UUID
+	Name      string
+	Arguments string
+	Output    pgtype.Text
+	Error     pgtype.Text
+	LatencyMs int64
+	StartedAt pgtype.Timestamp
+	CreatedAt pgtype.Timestamp
+}
+
+type DynamicQueryMessage struct {
+	ID             uuid.UUID
+	DynamicQueryID uuid.UUID
+	ParentID       pgtype.UUID
+	Content        string
+	Status         DynamicQueryMessageStatus
+	SqlQuery       pgtype.Text
+	Parameters     system.DynamicQueryParameters
+	ThoughtProcess pgtype.Text
+	ResponseID     pgtype.Text
+	Error          pgtype.Text
+	CreatedBy      pgtype.UUID
+	CreatedAt      pgtype.Timestamp
+	UpdatedAt      pgtype.Timestamp
+}
+
+type DynamicQueryRecentRun struct {
+	UserID         uuid.UUID
+	DynamicQueryID uuid.UUID
+	RunCount       int64
+	LastRunAt      pgtype.Timestamp
+}
+
+type DynamicQueryResult struct {
+	ID             uuid.UUID
+	DynamicQueryID uuid.UUID
+	SqlHash        string
+	CacheKey       string
+	Result         []byte
+	Total          int64
+	CreatedAt      pgtype.Timestamptz
+	ExpiresAt      pgtype.Timestamptz
+}
+
+type DynamicQuerySchedule struct {
+	ID             uuid.UUID
+	DynamicQueryID uuid.UUID
+	Cron           string
+	Timezone       string
+	Parameters     system.DynamicQueryParameterValues
+	Delivery       DynamicQueryDelivery
+	Recipients     []string
+	Enabled        bool
+	NextRunAt      pgtype.Timestamp
+	LastRunAt      pgtype.Timestamp
+	CreatedBy      pgtype.UUID
+	CreatedAt      pgtype.Timestamp
+	UpdatedAt      pgtype.Timestamp
+}
+
+type DynamicQueryScheduleRun struct {
+	ID             uuid.UUID
+	ScheduleID     uuid.UUID
+	DynamicQueryID uuid.UUID
+	Status         DynamicQueryScheduleRunStatus
+	RowCount       int64
+	DeliveredTo    []string
+	Error          pgtype.Text
+	StartedAt      pgtype.Timestamp
+	FinishedAt     pgtype.Timestamp
+}
+
+type DynamicQueryShare struct {
+	ID             uuid.UUID
+	DynamicQueryID uuid.UUID
+	UserID         pgtype.UUID
+	Role           NullRoleType
+	Permission     DynamicQueryPermission
+	CreatedBy      pgtype.UUID
+	CreatedAt      pgtype.Timestamp
+}
+
+type DynamicQueryVersion struct {
+	ID             uuid.UUID
+	DynamicQueryID uuid.UUID
+	Version        int32
+	Query          pgtype.Text
+	Prompt         string
+	ResponseID     pgtype.Text
+	Parameters     system.DynamicQueryParameters
+	CreatedBy      pgtype.UUID
+	CreatedAt      pgtype.Timestamp
+}
+
+type GlossaryTerm struct {
 	ID         uuid.UUID
-	Name       string
-	Query      pgtype.Text
-	ResponseID pgtype.Text
-	Status     DynamicQueryStatus
-	Prompt     string
+	Term       string
+	Definition string
+	CreatedBy  pgtype.UUID
 	CreatedAt  pgtype.Timestamp
 	UpdatedAt  pgtype.Timestamp
 }
 
+type MaskingRule struct {
+	ID                uuid.UUID
+	Role              RoleType
+	ColumnName        string
+	Strategy          MaskingStrategy
+	VisibleCharacters int32
+	CreatedBy         pgtype.UUID
+	CreatedAt         pgtype.Timestamp
+	UpdatedAt         pgtype.Timestamp
+}
+
+type McpToken struct {
+	ID          uuid.UUID
+	Name        string
+	TokenHash   string
+	TokenPrefix string
+	Tools       []string
+	Catalogs    []string
+	CreatedBy   pgtype.UUID
+	LastUsedAt  pgtype.Timestamp
+	RevokedAt   pgtype.Timestamp
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+	ExpiresAt   pgtype.Timestamptz
+	Role        RoleType
+	Pops        []string
+}
+
 type PointsOfInterest struct {
 	ID        uuid.UUID
 	Name      string
@@ -117,6 +613,27 @@ type PointsOfInterest struct {
 	UpdatedAt pgtype.Timestamptz
 }
 
+type PromptTemplate struct {
+	ID          uuid.UUID
+	Name        string
+	Description string
+	Body        string
+	Version     int32
+	IsDefault   bool
+	CreatedBy   pgtype.UUID
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+}
+
+type PromptTemplateVersion struct {
+	ID               uuid.UUID
+	PromptTemplateID uuid.UUID
+	Version          int32
+	Body             string
+	CreatedBy        pgtype.UUID
+	CreatedAt        pgtype.Timestamp
+}
+
 type User struct {
 	ID          uuid.UUID
 	Email       string
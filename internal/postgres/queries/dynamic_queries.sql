@@ -13,21 +13,172 @@ SELECT
     COUNT(*) AS total
 FROM
     dynamic_queries
+WHERE
+    (
+        sqlc.arg(search_term)::text = ''
+        OR to_tsvector('english', dynamic_queries.name || ' ' || dynamic_queries.prompt) @@ websearch_to_tsquery('english', sqlc.arg(search_term)::text)
+        OR TRIM(LOWER(dynamic_queries.name)) ILIKE '%' || TRIM(LOWER(sqlc.arg(search_term)::text)) || '%'
+    )
+    AND dynamic_query_visible(dynamic_queries.id, sqlc.arg(user_id)::uuid, sqlc.arg(role)::role_type)
+    AND (
+        sqlc.narg(folder_id)::uuid IS NULL
+        OR dynamic_queries.folder_id IN (
+            WITH RECURSIVE
+                folders AS (
+                    SELECT
+                        dynamic_query_folders.id
+                    FROM
+                        dynamic_query_folders
+                    WHERE
+                        dynamic_query_folders.id = sqlc.narg(folder_id)::uuid
+                    UNION ALL
+                    SELECT
+                        dynamic_query_folders.id
+                    FROM
+                        dynamic_query_folders
+                        JOIN folders ON dynamic_query_folders.parent_id = folders.id
+                )
+            SELECT
+                folders.id
+            FROM
+                folders
+        )
+    )
+    AND (
+        sqlc.narg(tag)::text IS NULL
+        OR dynamic_queries.tags @> ARRAY[sqlc.narg(tag)::text]
+    )
+    AND (
+        sqlc.narg(status)::dynamic_query_status IS NULL
+        OR dynamic_queries.status = sqlc.narg(status)::dynamic_query_status
+    )
+    AND (
+        sqlc.narg(owner_id)::uuid IS NULL
+        OR dynamic_queries.created_by = sqlc.narg(owner_id)::uuid
+    )
+    AND (
+        NOT sqlc.arg(favourites_only)::boolean
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                dynamic_query_favourites
+            WHERE
+                dynamic_query_favourites.dynamic_query_id = dynamic_queries.id
+                AND dynamic_query_favourites.user_id = sqlc.arg(user_id)::uuid
+        )
+    )
 LIMIT
     1;
 
 -- name: GetDynamicQueries :many
 SELECT
-    *
+    dynamic_queries.*,
+    EXISTS (
+        SELECT
+            1
+        FROM
+            dynamic_query_favourites
+        WHERE
+            dynamic_query_favourites.dynamic_query_id = dynamic_queries.id
+            AND dynamic_query_favourites.user_id = sqlc.arg(user_id)::uuid
+    ) AS favourite,
+    dynamic_query_recent_runs.last_run_at
 FROM
     dynamic_queries
+    LEFT JOIN dynamic_query_recent_runs ON dynamic_query_recent_runs.dynamic_query_id = dynamic_queries.id
+    AND dynamic_query_recent_runs.user_id = sqlc.arg(user_id)::uuid
 WHERE
-    TRIM(LOWER(name)) ILIKE '%' || TRIM(LOWER(sqlc.arg(search_term)::text)) || '%'
+    (
+        sqlc.arg(search_term)::text = ''
+        OR to_tsvector('english', dynamic_queries.name || ' ' || dynamic_queries.prompt) @@ websearch_to_tsquery('english', sqlc.arg(search_term)::text)
+        OR TRIM(LOWER(dynamic_queries.name)) ILIKE '%' || TRIM(LOWER(sqlc.arg(search_term)::text)) || '%'
+    )
+    AND dynamic_query_visible(dynamic_queries.id, sqlc.arg(user_id)::uuid, sqlc.arg(role)::role_type)
+    AND (
+        sqlc.narg(folder_id)::uuid IS NULL
+        OR dynamic_queries.folder_id IN (
+            WITH RECURSIVE
+                folders AS (
+                    SELECT
+                        dynamic_query_folders.id
+                    FROM
+                        dynamic_query_folders
+                    WHERE
+                        dynamic_query_folders.id = sqlc.narg(folder_id)::uuid
+                    UNION ALL
+                    SELECT
+                        dynamic_query_folders.id
+                    FROM
+                        dynamic_query_folders
+                        JOIN folders ON dynamic_query_folders.parent_id = folders.id
+                )
+            SELECT
+                folders.id
+            FROM
+                folders
+        )
+    )
+    AND (
+        sqlc.narg(tag)::text IS NULL
+        OR dynamic_queries.tags @> ARRAY[sqlc.narg(tag)::text]
+    )
+    AND (
+        sqlc.narg(status)::dynamic_query_status IS NULL
+        OR dynamic_queries.status = sqlc.narg(status)::dynamic_query_status
+    )
+    AND (
+        sqlc.narg(owner_id)::uuid IS NULL
+        OR dynamic_queries.created_by = sqlc.narg(owner_id)::uuid
+    )
+    AND (
+        NOT sqlc.arg(favourites_only)::boolean
+        OR EXISTS (
+            SELECT
+                1
+            FROM
+                dynamic_query_favourites
+            WHERE
+                dynamic_query_favourites.dynamic_query_id = dynamic_queries.id
+                AND dynamic_query_favourites.user_id = sqlc.arg(user_id)::uuid
+        )
+    )
 ORDER BY
-    name ASC
+    CASE
+        WHEN sqlc.arg(sort)::text = 'recent' THEN dynamic_query_recent_runs.last_run_at
+    END DESC NULLS LAST,
+    CASE
+        WHEN sqlc.arg(sort)::text = 'updated' THEN dynamic_queries.updated_at
+    END DESC NULLS LAST,
+    dynamic_queries.name ASC
 LIMIT $1
 OFFSET $2;
 
+-- name: GetRecentDynamicQueries :many
+SELECT
+    dynamic_queries.*,
+    dynamic_query_recent_runs.run_count,
+    dynamic_query_recent_runs.last_run_at
+FROM
+    dynamic_query_recent_runs
+    JOIN dynamic_queries ON dynamic_queries.id = dynamic_query_recent_runs.dynamic_query_id
+WHERE
+    dynamic_query_recent_runs.user_id = sqlc.arg(user_id)::uuid
+    AND dynamic_query_visible(dynamic_queries.id, sqlc.arg(user_id)::uuid, sqlc.arg(role)::role_type)
+ORDER BY
+    dynamic_query_recent_runs.last_run_at DESC
+LIMIT $1;
+
+-- name: GetDynamicQueryTags :many
+SELECT DISTINCT
+    UNNEST(dynamic_queries.tags)::text AS tag
+FROM
+    dynamic_queries
+WHERE
+    dynamic_query_visible(dynamic_queries.id, sqlc.arg(user_id)::uuid, sqlc.arg(role)::role_type)
+ORDER BY
+    tag ASC;
+
 -- name: CreateDynamicQuery :one
 INSERT INTO
     dynamic_queries (
@@ -35,10 +186,16 @@ INSERT INTO
         query,
         response_id,
         status,
-        prompt
+        prompt,
+        parameters,
+        created_by,
+        visibility,
+        folder_id,
+        tags,
+        prompt_template_id
     )
 VALUES
-    ($1, $2, $3, $4, $5) RETURNING *;
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING *;
 
 -- name: UpdateDynamicQuery :one
 UPDATE dynamic_queries
@@ -48,9 +205,123 @@ SET
     response_id = $3,
     status = $4,
     prompt = $5,
+    parameters = $6,
+    explanation = CASE
+        WHEN query IS DISTINCT FROM $2 THEN NULL
+        ELSE explanation
+    END,
+    explanation_error = CASE
+        WHEN query IS DISTINCT FROM $2 THEN NULL
+        ELSE explanation_error
+    END,
+    explanation_started_at = CASE
+        WHEN query IS DISTINCT FROM $2 THEN NULL
+        ELSE explanation_started_at
+    END,
+    updated_at = NOW()
+WHERE
+    id = $7 RETURNING *;
+
+-- name: SetDynamicQueryGenerated :one
+UPDATE dynamic_queries
+SET
+    query = $1,
+    response_id = $2,
+    status = $3,
+    parameters = $4,
+    explanation = CASE
+        WHEN query IS DISTINCT FROM $1 THEN NULL
+        ELSE explanation
+    END,
+    explanation_error = CASE
+        WHEN query IS DISTINCT FROM $1 THEN NULL
+        ELSE explanation_error
+    END,
+    explanation_started_at = CASE
+        WHEN query IS DISTINCT FROM $1 THEN NULL
+        ELSE explanation_started_at
+    END,
+    updated_at = NOW()
+WHERE
+    id = $5 RETURNING *;
+
+-- name: SetDynamicQueryStatus :one
+UPDATE dynamic_queries
+SET
+    status = $1,
+    response_id = COALESCE($2, response_id),
+    updated_at = NOW()
+WHERE
+    id = $3 RETURNING *;
+
+-- name: UpdateDynamicQueryVisibility :one
+UPDATE dynamic_queries
+SET
+    visibility = $1,
     updated_at = NOW()
 WHERE
-    id = $6 RETURNING *;
+    id = $2 RETURNING *;
+
+-- name: UpdateDynamicQueryFolderAndTags :one
+UPDATE dynamic_queries
+SET
+    folder_id = $1,
+    tags = $2,
+    updated_at = NOW()
+WHERE
+    id = $3 RETURNING *;
+
+-- name: UpdateDynamicQueryPromptTemplate :one
+UPDATE dynamic_queries
+SET
+    prompt_template_id = $1,
+    updated_at = NOW()
+WHERE
+    id = $2 RETURNING *;
+
+-- name: ClaimDynamicQueryExplanation :one
+UPDATE dynamic_queries
+SET
+    explanation_started_at = NOW()
+WHERE
+    id = (
+        SELECT
+            id
+        FROM
+            dynamic_queries
+        WHERE
+            query IS NOT NULL
+            AND explanation IS NULL
+            AND explanation_error IS NULL
+            AND (
+                explanation_started_at IS NULL
+                OR explanation_started_at < NOW() - make_interval(secs => sqlc.arg(stale_seconds)::FLOAT8)
+            )
+        ORDER BY
+            updated_at ASC
+        LIMIT
+            1
+        FOR UPDATE
+            SKIP LOCKED
+    ) RETURNING *;
+
+-- name: UpdateDynamicQueryExplanation :exec
+UPDATE dynamic_queries
+SET
+    explanation = $1,
+    explanation_error = $2
+WHERE
+    id = $3
+    AND query = $4;
+
+-- name: ResetDynamicQueryExplanation :one
+UPDATE dynamic_queries
+SET
+    explanation = NULL,
+    explanation_error = NULL,
+    explanation_started_at = NULL
+WHERE
+    id = $1 RETURNING *;
 
 -- name: DeleteDynamicQuery :one
 DELETE FROM dynamic_queries
@@ -0,0 +1,22 @@
+-- name: CreateDynamicQueryFavourite :exec
+INSERT INTO
+    dynamic_query_favourites (user_id, dynamic_query_id)
+VALUES
+    ($1, $2)
+ON CONFLICT (user_id, dynamic_query_id) DO NOTHING;
+
+-- name: DeleteDynamicQueryFavourite :exec
+DELETE FROM dynamic_query_favourites
+WHERE
+    user_id = $1
+    AND dynamic_query_id = $2;
+
+-- name: RecordDynamicQueryRun :exec
+INSERT INTO
+    dynamic_query_recent_runs (user_id, dynamic_query_id)
+VALUES
+    ($1, $2)
+ON CONFLICT (user_id, dynamic_query_id) DO UPDATE
+SET
+    run_count = dynamic_query_recent_runs.run_count + 1,
+    last_run_at = NOW();
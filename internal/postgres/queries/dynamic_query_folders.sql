@@ -0,0 +1,74 @@
+-- name: GetDynamicQueryFolders :many
+SELECT
+    *
+FROM
+    dynamic_query_folders
+ORDER BY
+    name ASC;
+
+-- name: GetDynamicQueryFolder :one
+SELECT
+    *
+FROM
+    dynamic_query_folders
+WHERE
+    id = $1
+LIMIT
+    1;
+
+-- name: GetDynamicQueryFolderByName :one
+SELECT
+    *
+FROM
+    dynamic_query_folders
+WHERE
+    parent_id IS NOT DISTINCT FROM sqlc.narg(parent_id)::uuid
+    AND LOWER(name) = LOWER(sqlc.arg(name)::text)
+LIMIT
+    1;
+
+-- name: IsDynamicQueryFolderDescendant :one
+WITH RECURSIVE
+    descendants AS (
+        SELECT
+            dynamic_query_folders.id
+        FROM
+            dynamic_query_folders
+        WHERE
+            dynamic_query_folders.id = sqlc.arg(ancestor_id)::uuid
+        UNION ALL
+        SELECT
+            dynamic_query_folders.id
+        FROM
+            dynamic_query_folders
+            JOIN descendants ON dynamic_query_folders.parent_id = descendants.id
+    )
+SELECT
+    EXISTS (
+        SELECT
+            1
+        FROM
+            descendants
+        WHERE
+            descendants.id = sqlc.arg(folder_id)::uuid
+    ) AS descendant;
+
+-- name: CreateDynamicQueryFolder :one
+INSERT INTO
+    dynamic_query_folders (parent_id, name, created_by)
+VALUES
+    ($1, $2, $3) RETURNING *;
+
+-- name: UpdateDynamicQueryFolder :one
+UPDATE dynamic_query_folders
+SET
+    parent_id = $1,
+    name = $2,
+    updated_at = NOW()
+WHERE
+    id = $3 RETURNING *;
+
+-- name: DeleteDynamicQueryFolder :one
+DELETE FROM dynamic_query_folders
+WHERE
+    id = $1 RETURNING *;
@@ -0,0 +1,177 @@
+-- name: CreateDynamicQueryJob :one
+INSERT INTO
+    dynamic_query_jobs (dynamic_query_id, created_by, message_id)
+VALUES
+    ($1, $2, $3) RETURNING *;
+
+-- name: GetDynamicQueryJob :one
+SELECT
+    *
+FROM
+    dynamic_query_jobs
+WHERE
+    id = $1
+LIMIT
+    1;
+
+-- name: GetDynamicQueryJobByMessage :one
+SELECT
+    *
+FROM
+    dynamic_query_jobs
+WHERE
+    message_id = $1
+LIMIT
+    1;
+
+-- name: GetLatestDynamicQueryJob :one
+SELECT
+    *
+FROM
+    dynamic_query_jobs
+WHERE
+    dynamic_query_id = $1
+ORDER BY
+    created_at DESC
+LIMIT
+    1;
+
+-- name: ClaimDynamicQueryJob :one
+UPDATE dynamic_query_jobs
+SET
+    status = 'running',
+    started_at = NOW(),
+    heartbeat_at = NOW(),
+    updated_at = NOW()
+WHERE
+    id = (
+        SELECT
+            id
+        FROM
+            dynamic_query_jobs
+        WHERE
+            status = 'queued'
+        ORDER BY
+            created_at ASC
+        LIMIT
+            1
+        FOR UPDATE
+            SKIP LOCKED
+    ) RETURNING *;
+
+-- name: HeartbeatDynamicQueryJob :one
+UPDATE dynamic_query_jobs
+SET
+    heartbeat_at = NOW(),
+    updated_at = NOW()
+WHERE
+    id = $1
+    AND status = 'running' RETURNING *;
+
+-- name: FinishDynamicQueryJob :one
+UPDATE dynamic_query_jobs
+SET
+    status = $2,
+    error = $3,
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE
+    id = $1
+    AND status IN ('queued', 'running') RETURNING *;
+
+-- name: FailStaleDynamicQueryJobs :many
+UPDATE dynamic_query_jobs
+SET
+    status = 'error',
+    error = sqlc.arg(error),
+    finished_at = NOW(),
+    updated_at = NOW()
+WHERE
+    status = 'running'
+    AND heartbeat_at < NOW() - make_interval(secs => sqlc.arg(stale_seconds)::FLOAT8) RETURNING *;
+
+-- name: CreateDynamicQueryJobEvent :one
+INSERT INTO
+    dynamic_query_job_events (job_id, event, data)
+VALUES
+    ($1, $2, $3) RETURNING *;
+
+-- name: GetDynamicQueryJobEvents :many
+SELECT
+    *
+FROM
+    dynamic_query_job_events
+WHERE
+    job_id = $1
+    AND id > $2
+ORDER BY
+    id ASC;
+
+-- name: GetTotalDynamicQueryJobs :one
+SELECT
+    COUNT(*) AS total
+FROM
+    dynamic_query_jobs
+WHERE
+    dynamic_query_id = $1
+LIMIT
+    1;
+
+-- name: GetDynamicQueryJobs :many
+SELECT
+    *
+FROM
+    dynamic_query_jobs
+WHERE
+    dynamic_query_id = $1
+ORDER BY
+    created_at DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: RecordDynamicQueryJobPromptTemplate :exec
+UPDATE dynamic_query_jobs
+SET
+    prompt_template_id = $2,
+    prompt_template_version = $3,
+    updated_at = NOW()
+WHERE
+    id = $1;
+
+-- name: RecordDynamicQueryJobResult :one
+UPDATE dynamic_query_jobs
+SET
+    thought_process = $2,
+    sql_query = $3,
+    parameters = $4,
+    response_id = $5,
+    input_tokens = $6,
+    output_tokens = $7,
+    total_tokens = $8,
+    updated_at = NOW()
+WHERE
+    id = $1 RETURNING *;
+
+-- name: CreateDynamicQueryJobToolCall :one
+INSERT INTO
+    dynamic_query_job_tool_calls (
+        job_id,
+        name,
+        arguments,
+        output,
+        error,
+        latency_ms,
+        started_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7) RETURNING *;
+
+-- name: GetDynamicQueryJobToolCalls :many
+SELECT
+    *
+FROM
+    dynamic_query_job_tool_calls
+WHERE
+    job_id = $1
+ORDER BY
+    started_at ASC;
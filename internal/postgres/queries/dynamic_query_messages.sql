@@ -0,0 +1,94 @@
+-- name: GetDynamicQueryMessages :many
+SELECT
+    *
+FROM
+    dynamic_query_messages
+WHERE
+    dynamic_query_id = $1
+ORDER BY
+    created_at ASC;
+
+-- name: GetDynamicQueryMessage :one
+SELECT
+    *
+FROM
+    dynamic_query_messages
+WHERE
+    id = $1
+    AND dynamic_query_id = $2
+LIMIT
+    1;
+
+-- name: GetLatestProposedDynamicQueryMessage :one
+SELECT
+    *
+FROM
+    dynamic_query_messages
+WHERE
+    dynamic_query_id = $1
+    AND status = 'proposed'
+ORDER BY
+    created_at DESC
+LIMIT
+    1;
+
+-- name: CreateDynamicQueryMessage :one
+INSERT INTO
+    dynamic_query_messages (dynamic_query_id, parent_id, content, created_by)
+VALUES
+    ($1, $2, $3, $4) RETURNING *;
+
+-- name: ProposeDynamicQueryMessage :one
+UPDATE dynamic_query_messages
+SET
+    status = 'proposed',
+    sql_query = $2,
+    parameters = $3,
+    thought_process = $4,
+    response_id = $5,
+    updated_at = NOW()
+WHERE
+    id = $1
+    AND status = 'generating' RETURNING *;
+
+-- name: FailDynamicQueryMessage :exec
+UPDATE dynamic_query_messages
+SET
+    status = 'error',
+    error = $2,
+    updated_at = NOW()
+WHERE
+    id = $1
+    AND status = 'generating';
+
+-- name: AcceptDynamicQueryMessage :one
+UPDATE dynamic_query_messages
+SET
+    status = 'accepted',
+    updated_at = NOW()
+WHERE
+    id = $1
+    AND status = 'proposed' RETURNING *;
+
+-- name: DiscardDynamicQueryMessage :one
+UPDATE dynamic_query_messages
+SET
+    status = 'discarded',
+    updated_at = NOW()
+WHERE
+    id = $1
+    AND status = 'proposed' RETURNING *;
+
+-- name: DiscardProposedDynamicQueryMessages :exec
+UPDATE dynamic_query_messages
+SET
+    status = 'discarded',
+    updated_at = NOW()
+WHERE
+    dynamic_query_id = $1
+    AND status = 'proposed';
+
+-- name: DeleteDynamicQueryMessage :exec
+DELETE FROM dynamic_query_messages
+WHERE
+    id = $1;
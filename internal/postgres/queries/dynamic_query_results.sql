@@ -0,0 +1,57 @@
+-- name: GetDynamicQueryResultCache :one
+SELECT
+    *,
+    EXTRACT(
+        EPOCH
+        FROM
+            NOW() - created_at
+    )::BIGINT AS age_seconds
+FROM
+    dynamic_query_results
+WHERE
+    dynamic_query_id = $1
+    AND sql_hash = $2
+    AND cache_key = $3
+    AND expires_at > NOW()
+LIMIT
+    1;
+
+-- name: UpsertDynamicQueryResultCache :exec
+INSERT INTO
+    dynamic_query_results (
+        dynamic_query_id,
+        sql_hash,
+        cache_key,
+        result,
+        total,
+        expires_at
+    )
+VALUES
+    (
+        sqlc.arg(dynamic_query_id),
+        sqlc.arg(sql_hash),
+        sqlc.arg(cache_key),
+        sqlc.arg(result),
+        sqlc.arg(total),
+        NOW() + make_interval(secs => sqlc.arg(ttl_seconds)::DOUBLE PRECISION)
+    )
+ON CONFLICT (dynamic_query_id, sql_hash, cache_key) DO UPDATE
+SET
+    result = EXCLUDED.result,
+    total = EXCLUDED.total,
+    created_at = NOW(),
+    expires_at = EXCLUDED.expires_at;
+
+-- name: DeleteStaleDynamicQueryResultCaches :exec
+DELETE FROM dynamic_query_results
+WHERE
+    dynamic_query_id = $1
+    AND (
+        sql_hash <> $2
+        OR expires_at <= NOW()
+    );
+
+-- name: DeleteDynamicQueryResultCaches :exec
+DELETE FROM dynamic_query_results
+WHERE
+    dynamic_query_id = $1;
@@ -0,0 +1,116 @@
+-- name: GetDynamicQuerySchedule :one
+SELECT
+    *
+FROM
+    dynamic_query_schedules
+WHERE
+    dynamic_query_id = $1
+LIMIT
+    1;
+
+-- name: UpsertDynamicQuerySchedule :one
+INSERT INTO
+    dynamic_query_schedules (
+        dynamic_query_id,
+        cron,
+        timezone,
+        parameters,
+        delivery,
+        recipients,
+        enabled,
+        next_run_at,
+        created_by
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (dynamic_query_id) DO UPDATE
+SET
+    cron = EXCLUDED.cron,
+    timezone = EXCLUDED.timezone,
+    parameters = EXCLUDED.parameters,
+    delivery = EXCLUDED.delivery,
+    recipients = EXCLUDED.recipients,
+    enabled = EXCLUDED.enabled,
+    next_run_at = EXCLUDED.next_run_at,
+    created_by = EXCLUDED.created_by,
+    updated_at = NOW() RETURNING *;
+
+-- name: DeleteDynamicQuerySchedule :one
+DELETE FROM dynamic_query_schedules
+WHERE
+    dynamic_query_id = $1 RETURNING *;
+
+-- name: GetDueDynamicQuerySchedules :many
+SELECT
+    *
+FROM
+    dynamic_query_schedules
+WHERE
+    enabled
+    AND next_run_at <= sqlc.arg(now)
+ORDER BY
+    next_run_at;
+
+-- name: AdvanceDynamicQuerySchedule :exec
+UPDATE dynamic_query_schedules
+SET
+    next_run_at = sqlc.arg(next_run_at),
+    last_run_at = sqlc.arg(last_run_at)
+WHERE
+    id = sqlc.arg(id);
+
+-- name: CreateDynamicQueryScheduleRun :one
+INSERT INTO
+    dynamic_query_schedule_runs (schedule_id, dynamic_query_id)
+VALUES
+    ($1, $2) RETURNING *;
+
+-- name: FinishDynamicQueryScheduleRun :one
+UPDATE dynamic_query_schedule_runs
+SET
+    status = $1,
+    row_count = $2,
+    delivered_to = $3,
+    error = $4,
+    finished_at = NOW()
+WHERE
+    id = $5 RETURNING *;
+
+-- name: FailUnfinishedDynamicQueryScheduleRuns :exec
+UPDATE dynamic_query_schedule_runs
+SET
+    status = 'error',
+    error = $1,
+    finished_at = NOW()
+WHERE
+    status = 'running';
+
+-- name: GetTotalDynamicQueryScheduleRuns :one
+SELECT
+    COUNT(*) AS total
+FROM
+    dynamic_query_schedule_runs
+WHERE
+    dynamic_query_id = $1;
+
+-- name: GetDynamicQueryScheduleRuns :many
+SELECT
+    *
+FROM
+    dynamic_query_schedule_runs
+WHERE
+    dynamic_query_id = $1
+ORDER BY
+    started_at DESC
+LIMIT
+    $2
+OFFSET
+    $3;
+
+-- name: TryDynamicQuerySchedulerLock :one
+SELECT
+    pg_try_advisory_lock(sqlc.arg(key)::BIGINT) AS locked;
+
+-- name: ReleaseDynamicQuerySchedulerLock :one
+SELECT
+    pg_advisory_unlock(sqlc.arg(key)::BIGINT) AS released;
@@ -0,0 +1,50 @@
+-- name: GetDynamicQueryShares :many
+SELECT
+    *
+FROM
+    dynamic_query_shares
+WHERE
+    dynamic_query_id = $1
+ORDER BY
+    created_at ASC;
+
+-- name: GetDynamicQueryShare :one
+SELECT
+    *
+FROM
+    dynamic_query_shares
+WHERE
+    id = $1
+    AND dynamic_query_id = $2
+LIMIT
+    1;
+
+-- name: GetDynamicQueryPermissions :many
+SELECT
+    permission
+FROM
+    dynamic_query_shares
+WHERE
+    dynamic_query_id = $1
+    AND (
+        user_id = $2
+        OR role = $3
+    );
+
+-- name: CreateDynamicQueryShare :one
+INSERT INTO
+    dynamic_query_shares (
+        dynamic_query_id,
+        user_id,
+        role,
+        permission,
+        created_by
+    )
+VALUES
+    ($1, $2, $3, $4, $5) RETURNING *;
+
+-- name: DeleteDynamicQueryShare :one
+DELETE FROM dynamic_query_shares
+WHERE
+    id = $1
+    AND dynamic_query_id = $2 RETURNING *;
@@ -0,0 +1,66 @@
+-- name: CreateDynamicQueryVersion :one
+WITH
+    next_version AS (
+        UPDATE dynamic_queries
+        SET
+            latest_version = latest_version + 1
+        WHERE
+            id = $1
+        RETURNING
+            latest_version
+    )
+INSERT INTO
+    dynamic_query_versions (
+        dynamic_query_id,
+        version,
+        query,
+        prompt,
+        response_id,
+        parameters,
+        created_by
+    )
+SELECT
+    $1,
+    next_version.latest_version,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+FROM
+    next_version RETURNING *;
+
+-- name: GetDynamicQueryVersion :one
+SELECT
+    *
+FROM
+    dynamic_query_versions
+WHERE
+    dynamic_query_id = $1
+    AND version = $2
+LIMIT
+    1;
+
+-- name: GetTotalDynamicQueryVersions :one
+SELECT
+    COUNT(*) AS total
+FROM
+    dynamic_query_versions
+WHERE
+    dynamic_query_id = $1
+LIMIT
+    1;
+
+-- name: GetDynamicQueryVersions :many
+SELECT
+    dynamic_query_versions.*,
+    users.email AS created_by_email
+FROM
+    dynamic_query_versions
+    LEFT JOIN users ON users.id = dynamic_query_versions.created_by
+WHERE
+    dynamic_query_versions.dynamic_query_id = $1
+ORDER BY
+    dynamic_query_versions.version DESC
+LIMIT $2
+OFFSET $3;
@@ -0,0 +1,47 @@
+-- name: GetGlossaryTerms :many
+SELECT
+    *
+FROM
+    glossary_terms
+ORDER BY
+    lower(term);
+
+-- name: GetGlossaryTerm :one
+SELECT
+    *
+FROM
+    glossary_terms
+WHERE
+    id = $1
+LIMIT
+    1;
+
+-- name: GetGlossaryTermByTerm :one
+SELECT
+    *
+FROM
+    glossary_terms
+WHERE
+    lower(term) = lower(sqlc.arg(term))
+LIMIT
+    1;
+
+-- name: CreateGlossaryTerm :one
+INSERT INTO
+    glossary_terms (term, definition, created_by)
+VALUES
+    ($1, $2, $3) RETURNING *;
+
+-- name: UpdateGlossaryTerm :one
+UPDATE glossary_terms
+SET
+    term = $1,
+    definition = $2,
+    updated_at = NOW()
+WHERE
+    id = $3 RETURNING *;
+
+-- name: DeleteGlossaryTerm :one
+DELETE FROM glossary_terms
+WHERE
+    id = $1 RETURNING *;
@@ -0,0 +1,56 @@
+-- name: GetMaskingRules :many
+SELECT
+    *
+FROM
+    masking_rules
+ORDER BY
+    role,
+    column_name;
+
+-- name: GetMaskingRulesByRole :many
+SELECT
+    *
+FROM
+    masking_rules
+WHERE
+    role = $1
+ORDER BY
+    column_name;
+
+-- name: GetMaskingRule :one
+SELECT
+    *
+FROM
+    masking_rules
+WHERE
+    id = $1
+LIMIT
+    1;
+
+-- name: CreateMaskingRule :one
+INSERT INTO
+    masking_rules (
+        role,
+        column_name,
+        strategy,
+        visible_characters,
+        created_by
+    )
+VALUES
+    ($1, $2, $3, $4, $5) RETURNING *;
+
+-- name: UpdateMaskingRule :one
+UPDATE masking_rules
+SET
+    role = $1,
+    column_name = $2,
+    strategy = $3,
+    visible_characters = $4,
+    updated_at = NOW()
+WHERE
+    id = $5 RETURNING *;
+
+-- name: DeleteMaskingRule :one
+DELETE FROM masking_rules
+WHERE
+    id = $1 RETURNING *;
@@ -0,0 +1,96 @@
+-- name: CreateMcpToken :one
+INSERT INTO
+    mcp_tokens (
+        name,
+        token_hash,
+        token_prefix,
+        tools,
+        catalogs,
+        role,
+        pops,
+        created_by,
+        expires_at
+    )
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING *;
+
+-- name: GetMcpToken :one
+SELECT
+    *
+FROM
+    mcp_tokens
+WHERE
+    id = $1
+LIMIT
+    1;
+
+-- name: GetActiveMcpTokenByHash :one
+SELECT
+    *
+FROM
+    mcp_tokens
+WHERE
+    token_hash = $1
+    AND revoked_at IS NULL
+    AND (
+        expires_at IS NULL
+        OR expires_at > NOW()
+    )
+LIMIT
+    1;
+
+-- name: GetTotalMcpTokens :one
+SELECT
+    COUNT(*) AS total
+FROM
+    mcp_tokens
+LIMIT
+    1;
+
+-- name: GetMcpTokens :many
+SELECT
+    mcp_tokens.*,
+    users.email AS created_by_email
+FROM
+    mcp_tokens
+    LEFT JOIN users ON users.id = mcp_tokens.created_by
+ORDER BY
+    mcp_tokens.revoked_at DESC NULLS FIRST,
+    mcp_tokens.created_at DESC
+LIMIT $1
+OFFSET $2;
+
+-- name: RevokeMcpToken :one
+UPDATE mcp_tokens
+SET
+    revoked_at = NOW(),
+    updated_at = NOW()
+WHERE
+    id = $1
+    AND revoked_at IS NULL RETURNING *;
+
+-- name: RevokeSystemMcpTokens :exec
+UPDATE mcp_tokens
+SET
+    revoked_at = NOW(),
+    updated_at = NOW()
+WHERE
+    name = $1
+    AND created_by IS NULL
+    AND revoked_at IS NULL;
+
+-- name: ExtendMcpToken :exec
+UPDATE mcp_tokens
+SET
+    expires_at = NOW() + make_interval(secs => sqlc.arg(ttl_seconds)::DOUBLE PRECISION),
+    updated_at = NOW()
+WHERE
+    id = sqlc.arg(id)
+    AND revoked_at IS NULL;
+
+-- name: TouchMcpToken :exec
+UPDATE mcp_tokens
+SET
+    last_used_at = NOW()
+WHERE
+    id = $1;
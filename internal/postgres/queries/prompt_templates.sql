@@ -0,0 +1,105 @@
+-- name: GetPromptTemplates :many
+SELECT
+    *
+FROM
+    prompt_templates
+ORDER BY
+    name;
+
+-- name: GetPromptTemplate :one
+SELECT
+    *
+FROM
+    prompt_templates
+WHERE
+    id = $1
+LIMIT
+    1;
+
+-- name: GetPromptTemplateByName :one
+SELECT
+    *
+FROM
+    prompt_templates
+WHERE
+    lower(name) = lower(sqlc.arg(name))
+LIMIT
+    1;
+
+-- name: GetDefaultPromptTemplate :one
+SELECT
+    *
+FROM
+    prompt_templates
+WHERE
+    is_default
+LIMIT
+    1;
+
+-- name: CreatePromptTemplate :one
+INSERT INTO
+    prompt_templates (name, description, body, created_by)
+VALUES
+    ($1, $2, $3, $4) RETURNING *;
+
+-- name: UpdatePromptTemplate :one
+UPDATE prompt_templates
+SET
+    name = $1,
+    description = $2,
+    body = $3,
+    version = CASE
+        WHEN body = $3 THEN version
+        ELSE version + 1
+    END,
+    updated_at = NOW()
+WHERE
+    id = $4 RETURNING *;
+
+-- name: ClearDefaultPromptTemplate :exec
+UPDATE prompt_templates
+SET
+    is_default = FALSE,
+    updated_at = NOW()
+WHERE
+    is_default;
+
+-- name: SetDefaultPromptTemplate :one
+UPDATE prompt_templates
+SET
+    is_default = TRUE,
+    updated_at = NOW()
+WHERE
+    id = $1 RETURNING *;
+
+-- name: DeletePromptTemplate :one
+DELETE FROM prompt_templates
+WHERE
+    id = $1 RETURNING *;
+
+-- name: CreatePromptTemplateVersion :one
+INSERT INTO
+    prompt_template_versions (prompt_template_id, version, body, created_by)
+VALUES
+    ($1, $2, $3, $4) RETURNING *;
+
+-- name: GetPromptTemplateVersions :many
+SELECT
+    *
+FROM
+    prompt_template_versions
+WHERE
+    prompt_template_id = $1
+ORDER BY
+    version DESC;
+
+-- name: GetPromptTemplateVersion :one
+SELECT
+    *
+FROM
+    prompt_template_versions
+WHERE
+    prompt_template_id = $1
+    AND version = $2
+LIMIT
+    1;
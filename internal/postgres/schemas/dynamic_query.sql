@@ -1,5 +1,7 @@
 CREATE TYPE dynamic_query_status AS ENUM ('complete', 'in_progress', 'error');
 
+CREATE TYPE dynamic_query_visibility AS ENUM ('private', 'shared', 'public');
+
 CREATE TABLE IF NOT EXISTS dynamic_queries (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     name TEXT NOT NULL,
@@ -7,6 +9,20 @@ CREATE TABLE IF NOT EXISTS dynamic_queries (
     response_id TEXT,
     status dynamic_query_status NOT NULL DEFAULT 'in_progress',
     prompt TEXT NOT NULL,
+    parameters JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+    visibility dynamic_query_visibility NOT NULL DEFAULT 'private',
+    folder_id UUID REFERENCES dynamic_query_folders (id) ON DELETE SET NULL,
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    explanation JSONB,
+    explanation_error TEXT,
+    explanation_started_at TIMESTAMP,
+    prompt_template_id UUID REFERENCES prompt_templates (id) ON DELETE SET NULL,
+    latest_version INTEGER NOT NULL DEFAULT 0,
     created_at TIMESTAMP DEFAULT NOW(),
     updated_at TIMESTAMP DEFAULT NOW()
-)
+);
+
+CREATE INDEX IF NOT EXISTS dynamic_queries_search_idx ON dynamic_queries USING GIN (to_tsvector('english', name || ' ' || prompt));
+
+CREATE INDEX IF NOT EXISTS dynamic_queries_tags_idx ON dynamic_queries USING GIN (tags);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS
+    dynamic_query_favourites (
+        user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+        dynamic_query_id UUID NOT NULL REFERENCES dynamic_queries (id) ON DELETE CASCADE,
+        created_at TIMESTAMP DEFAULT NOW(),
+        PRIMARY KEY (user_id, dynamic_query_id)
+    );
+
+CREATE TABLE IF NOT EXISTS
+    dynamic_query_recent_runs (
+        user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+        dynamic_query_id UUID NOT NULL REFERENCES dynamic_queries (id) ON DELETE CASCADE,
+        run_count BIGINT NOT NULL DEFAULT 1,
+        last_run_at TIMESTAMP NOT NULL DEFAULT NOW(),
+        PRIMARY KEY (user_id, dynamic_query_id)
+    );
+
+CREATE INDEX IF NOT EXISTS dynamic_query_recent_runs_user_idx ON dynamic_query_recent_runs (user_id, last_run_at DESC);
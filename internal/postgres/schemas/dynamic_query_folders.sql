@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS
+    dynamic_query_folders (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        parent_id UUID REFERENCES dynamic_query_folders (id) ON DELETE CASCADE,
+        name TEXT NOT NULL,
+        created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+        created_at TIMESTAMP DEFAULT NOW(),
+        updated_at TIMESTAMP DEFAULT NOW()
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS dynamic_query_folders_name_idx ON dynamic_query_folders (
+    COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'::uuid),
+    LOWER(name)
+);
@@ -0,0 +1,57 @@
+CREATE TYPE dynamic_query_job_status AS ENUM (
+    'queued',
+    'running',
+    'complete',
+    'error',
+    'cancelled'
+);
+
+CREATE TABLE IF NOT EXISTS
+    dynamic_query_jobs (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        dynamic_query_id UUID NOT NULL REFERENCES dynamic_queries (id) ON DELETE CASCADE,
+        status dynamic_query_job_status NOT NULL DEFAULT 'queued',
+        error TEXT,
+        created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+        message_id UUID REFERENCES dynamic_query_messages (id) ON DELETE CASCADE,
+        prompt_template_id UUID REFERENCES prompt_templates (id) ON DELETE SET NULL,
+        prompt_template_version INTEGER,
+        thought_process TEXT,
+        sql_query TEXT,
+        parameters JSONB,
+        response_id TEXT,
+        input_tokens BIGINT NOT NULL DEFAULT 0,
+        output_tokens BIGINT NOT NULL DEFAULT 0,
+        total_tokens BIGINT NOT NULL DEFAULT 0,
+        started_at TIMESTAMP,
+        heartbeat_at TIMESTAMP,
+        finished_at TIMESTAMP,
+        created_at TIMESTAMP DEFAULT NOW(),
+        updated_at TIMESTAMP DEFAULT NOW()
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS dynamic_query_jobs_active_idx ON dynamic_query_jobs (dynamic_query_id)
+WHERE
+    status IN ('queued', 'running');
+
+CREATE TABLE IF NOT EXISTS
+    dynamic_query_job_events (
+        id BIGSERIAL PRIMARY KEY,
+        job_id UUID NOT NULL REFERENCES dynamic_query_jobs (id) ON DELETE CASCADE,
+        event TEXT NOT NULL,
+        data TEXT NOT NULL,
+        created_at TIMESTAMP DEFAULT NOW()
+    );
+
+CREATE TABLE IF NOT EXISTS
+    dynamic_query_job_tool_calls (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        job_id UUID NOT NULL REFERENCES dynamic_query_jobs (id) ON DELETE CASCADE,
+        name TEXT NOT NULL,
+        arguments TEXT NOT NULL,
+        output TEXT,
+        error TEXT,
+        latency_ms BIGINT NOT NULL,
+        started_at TIMESTAMP NOT NULL,
+        created_at TIMESTAMP DEFAULT NOW()
+    );
@@ -0,0 +1,26 @@
+CREATE TYPE dynamic_query_message_status AS ENUM (
+    'generating',
+    'proposed',
+    'accepted',
+    'discarded',
+    'error'
+);
+
+CREATE TABLE IF NOT EXISTS
+    dynamic_query_messages (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        dynamic_query_id UUID NOT NULL REFERENCES dynamic_queries (id) ON DELETE CASCADE,
+        parent_id UUID REFERENCES dynamic_query_messages (id) ON DELETE SET NULL,
+        content TEXT NOT NULL,
+        status dynamic_query_message_status NOT NULL DEFAULT 'generating',
+        sql_query TEXT,
+        parameters JSONB,
+        thought_process TEXT,
+        response_id TEXT,
+        error TEXT,
+        created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+        created_at TIMESTAMP DEFAULT NOW(),
+        updated_at TIMESTAMP DEFAULT NOW()
+    );
+
+CREATE INDEX IF NOT EXISTS dynamic_query_messages_dynamic_query_idx ON dynamic_query_messages (dynamic_query_id, created_at);
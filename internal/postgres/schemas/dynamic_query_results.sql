@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS
+    dynamic_query_results (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        dynamic_query_id UUID NOT NULL REFERENCES dynamic_queries (id) ON DELETE CASCADE,
+        sql_hash TEXT NOT NULL,
+        cache_key TEXT NOT NULL,
+        result JSONB NOT NULL,
+        total BIGINT NOT NULL DEFAULT 0,
+        created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+        expires_at TIMESTAMPTZ NOT NULL,
+        UNIQUE (dynamic_query_id, sql_hash, cache_key)
+    );
@@ -0,0 +1,39 @@
+CREATE TYPE dynamic_query_delivery AS ENUM ('email', 'directory');
+
+CREATE TYPE dynamic_query_schedule_run_status AS ENUM ('running', 'complete', 'error');
+
+CREATE TABLE IF NOT EXISTS
+    dynamic_query_schedules (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        dynamic_query_id UUID NOT NULL UNIQUE REFERENCES dynamic_queries (id) ON DELETE CASCADE,
+        cron TEXT NOT NULL,
+        timezone TEXT NOT NULL DEFAULT 'Africa/Johannesburg',
+        parameters JSONB NOT NULL DEFAULT '{}',
+        delivery dynamic_query_delivery NOT NULL,
+        recipients TEXT[] NOT NULL DEFAULT '{}',
+        enabled BOOLEAN NOT NULL DEFAULT TRUE,
+        next_run_at TIMESTAMP NOT NULL,
+        last_run_at TIMESTAMP,
+        created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+        created_at TIMESTAMP DEFAULT NOW(),
+        updated_at TIMESTAMP DEFAULT NOW()
+    );
+
+CREATE INDEX IF NOT EXISTS dynamic_query_schedules_due_idx ON dynamic_query_schedules (next_run_at)
+WHERE
+    enabled;
+
+CREATE TABLE IF NOT EXISTS
+    dynamic_query_schedule_runs (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        schedule_id UUID NOT NULL REFERENCES dynamic_query_schedules (id) ON DELETE CASCADE,
+        dynamic_query_id UUID NOT NULL REFERENCES dynamic_queries (id) ON DELETE CASCADE,
+        status dynamic_query_schedule_run_status NOT NULL DEFAULT 'running',
+        row_count BIGINT NOT NULL DEFAULT 0,
+        delivered_to TEXT[] NOT NULL DEFAULT '{}',
+        error TEXT,
+        started_at TIMESTAMP DEFAULT NOW(),
+        finished_at TIMESTAMP
+    );
+
+CREATE INDEX IF NOT EXISTS dynamic_query_schedule_runs_dynamic_query_idx ON dynamic_query_schedule_runs (dynamic_query_id, started_at DESC);
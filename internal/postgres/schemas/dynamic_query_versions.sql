@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS dynamic_query_versions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    dynamic_query_id UUID NOT NULL REFERENCES dynamic_queries (id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    query TEXT,
+    prompt TEXT NOT NULL,
+    response_id TEXT,
+    parameters JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT NOW(),
+    UNIQUE (dynamic_query_id, version)
+)
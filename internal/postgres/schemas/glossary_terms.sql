@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS
+    glossary_terms (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        term TEXT NOT NULL,
+        definition TEXT NOT NULL,
+        created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+        created_at TIMESTAMP DEFAULT NOW(),
+        updated_at TIMESTAMP DEFAULT NOW()
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS glossary_terms_term_idx ON glossary_terms (lower(term));
@@ -0,0 +1,20 @@
+CREATE TYPE masking_strategy AS ENUM (
+    'redact',
+    'show_first',
+    'show_last',
+    'email',
+    'hash'
+);
+
+CREATE TABLE IF NOT EXISTS
+    masking_rules (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        role role_type NOT NULL,
+        column_name TEXT NOT NULL,
+        strategy masking_strategy NOT NULL,
+        visible_characters INTEGER NOT NULL DEFAULT 4,
+        created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+        created_at TIMESTAMP DEFAULT NOW(),
+        updated_at TIMESTAMP DEFAULT NOW(),
+        UNIQUE (role, column_name)
+    );
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS mcp_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    token_prefix TEXT NOT NULL,
+    tools TEXT[] NOT NULL DEFAULT '{}',
+    catalogs TEXT[] NOT NULL DEFAULT '{}',
+    created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    expires_at TIMESTAMPTZ,
+    role role_type NOT NULL DEFAULT 'user',
+    pops TEXT[] NOT NULL DEFAULT '{}'
+)
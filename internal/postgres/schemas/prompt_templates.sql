@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS
+    prompt_templates (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        name TEXT NOT NULL UNIQUE,
+        description TEXT NOT NULL DEFAULT '',
+        body TEXT NOT NULL,
+        version INTEGER NOT NULL DEFAULT 1,
+        is_default BOOLEAN NOT NULL DEFAULT FALSE,
+        created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+        created_at TIMESTAMP DEFAULT NOW(),
+        updated_at TIMESTAMP DEFAULT NOW()
+    );
+
+CREATE UNIQUE INDEX IF NOT EXISTS prompt_templates_default_idx ON prompt_templates (is_default)
+WHERE
+    is_default;
+
+CREATE TABLE IF NOT EXISTS
+    prompt_template_versions (
+        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+        prompt_template_id UUID NOT NULL REFERENCES prompt_templates (id) ON DELETE CASCADE,
+        version INTEGER NOT NULL,
+        body TEXT NOT NULL,
+        created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+        created_at TIMESTAMP DEFAULT NOW(),
+        UNIQUE (prompt_template_id, version)
+    );
@@ -0,0 +1,11 @@
+-- The fixture databases the ai-eval suites seed, one per Trino catalog. Each
+-- catalog connects as its own user so that, as in production, the zing
+-- catalog only sees the Zing database and the radius catalog only Radius.
+CREATE DATABASE IF NOT EXISTS zing;
+CREATE DATABASE IF NOT EXISTS radius;
+
+CREATE USER IF NOT EXISTS 'zing'@'%' IDENTIFIED BY 'eval';
+CREATE USER IF NOT EXISTS 'radius'@'%' IDENTIFIED BY 'eval';
+
+GRANT ALL PRIVILEGES ON zing.* TO 'zing'@'%';
+GRANT ALL PRIVILEGES ON radius.* TO 'radius'@'%';